@@ -27,9 +27,14 @@ var CmdRunsGet = cli.Command{
 	Flags:       flags.AllDefaultFlags,
 }
 
-func runRunsGet(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runRunsGet(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify a run ID")
@@ -40,34 +45,34 @@ func runRunsGet(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("invalid run ID: %w", err)
 	}
 
-	run, err := getWorkflowRun(ctx.Login, ctx.Owner, ctx.Repo, runID)
+	run, err := getWorkflowRun(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, runID)
 	if err != nil {
 		return fmt.Errorf("failed to get workflow run: %w", err)
 	}
 
 	if ctx.Output == "json" {
 		data, _ := json.MarshalIndent(run, "", "  ")
-		fmt.Println(string(data))
+		fmt.Fprintln(ctx.Out(), string(data))
 		return nil
 	}
 
 	// Print run details
-	fmt.Printf("Run #%d: %s\n", run.RunNumber, run.DisplayTitle)
-	fmt.Printf("  Status:     %s\n", run.Status)
-	fmt.Printf("  Conclusion: %s\n", run.Conclusion)
-	fmt.Printf("  Event:      %s\n", run.Event)
-	fmt.Printf("  Branch:     %s\n", run.HeadBranch)
-	fmt.Printf("  Commit:     %s\n", run.HeadSha[:8])
+	fmt.Fprintf(ctx.Out(), "Run #%d: %s\n", run.RunNumber, run.DisplayTitle)
+	fmt.Fprintf(ctx.Out(), "  Status:     %s\n", run.Status)
+	fmt.Fprintf(ctx.Out(), "  Conclusion: %s\n", run.Conclusion)
+	fmt.Fprintf(ctx.Out(), "  Event:      %s\n", run.Event)
+	fmt.Fprintf(ctx.Out(), "  Branch:     %s\n", run.HeadBranch)
+	fmt.Fprintf(ctx.Out(), "  Commit:     %s\n", run.HeadSha[:8])
 	if run.Actor != nil {
-		fmt.Printf("  Actor:      %s\n", run.Actor.UserName)
+		fmt.Fprintf(ctx.Out(), "  Actor:      %s\n", run.Actor.UserName)
 	}
 	if !run.StartedAt.IsZero() {
-		fmt.Printf("  Started:    %s\n", print.FormatTime(run.StartedAt, false))
+		fmt.Fprintf(ctx.Out(), "  Started:    %s\n", print.FormatTime(run.StartedAt, false))
 	}
 	if !run.CompletedAt.IsZero() {
-		fmt.Printf("  Completed:  %s\n", print.FormatTime(run.CompletedAt, false))
+		fmt.Fprintf(ctx.Out(), "  Completed:  %s\n", print.FormatTime(run.CompletedAt, false))
 	}
-	fmt.Printf("  URL:        %s\n", run.HTMLURL)
+	fmt.Fprintf(ctx.Out(), "  URL:        %s\n", run.HTMLURL)
 
 	return nil
 }
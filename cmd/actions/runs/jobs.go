@@ -27,9 +27,14 @@ var CmdRunsJobs = cli.Command{
 	Flags:       flags.AllDefaultFlags,
 }
 
-func runRunsJobs(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runRunsJobs(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify a run ID")
@@ -40,26 +45,26 @@ func runRunsJobs(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("invalid run ID: %w", err)
 	}
 
-	jobs, err := getWorkflowRunJobs(ctx.Login, ctx.Owner, ctx.Repo, runID)
+	jobs, err := getWorkflowRunJobs(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, runID)
 	if err != nil {
 		return fmt.Errorf("failed to get jobs: %w", err)
 	}
 
 	if len(jobs.Jobs) == 0 {
-		fmt.Println("No jobs found for this run")
+		fmt.Fprintln(ctx.Out(), "No jobs found for this run")
 		return nil
 	}
 
 	if ctx.Output == "json" {
 		data, _ := json.MarshalIndent(jobs.Jobs, "", "  ")
-		fmt.Println(string(data))
+		fmt.Fprintln(ctx.Out(), string(data))
 		return nil
 	}
 
 	// Print jobs with their steps
 	for _, job := range jobs.Jobs {
 		statusIcon := getStatusIcon(job.Status, job.Conclusion)
-		fmt.Printf("%s Job: %s (%s)\n", statusIcon, job.Name, job.Status)
+		fmt.Fprintf(ctx.Out(), "%s Job: %s (%s)\n", statusIcon, job.Name, job.Status)
 
 		if len(job.Steps) > 0 {
 			for _, step := range job.Steps {
@@ -68,17 +73,17 @@ func runRunsJobs(_ stdctx.Context, cmd *cli.Command) error {
 				if !step.StartedAt.IsZero() && !step.CompletedAt.IsZero() {
 					duration = fmt.Sprintf(" (%s)", step.CompletedAt.Sub(step.StartedAt).Round(1e9))
 				}
-				fmt.Printf("  %s Step %d: %s%s\n", stepIcon, step.Number, step.Name, duration)
+				fmt.Fprintf(ctx.Out(), "  %s Step %d: %s%s\n", stepIcon, step.Number, step.Name, duration)
 			}
 		}
 
 		if !job.StartedAt.IsZero() {
-			fmt.Printf("  Started:   %s\n", print.FormatTime(job.StartedAt, false))
+			fmt.Fprintf(ctx.Out(), "  Started:   %s\n", print.FormatTime(job.StartedAt, false))
 		}
 		if !job.CompletedAt.IsZero() {
-			fmt.Printf("  Completed: %s\n", print.FormatTime(job.CompletedAt, false))
+			fmt.Fprintf(ctx.Out(), "  Completed: %s\n", print.FormatTime(job.CompletedAt, false))
 		}
-		fmt.Println()
+		fmt.Fprintln(ctx.Out())
 	}
 
 	return nil
@@ -5,11 +5,9 @@ package runs
 
 import (
 	stdctx "context"
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
-	"strings"
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
@@ -18,6 +16,10 @@ import (
 	"github.com/urfave/cli/v3"
 )
 
+var runsFieldsFlag = flags.FieldsFlag(print.ActionRunFields, []string{
+	"id", "number", "status", "conclusion", "event", "branch", "title", "started",
+})
+
 // CmdRunsList lists workflow runs
 var CmdRunsList = cli.Command{
 	Name:        "list",
@@ -45,12 +47,18 @@ var CmdRunsList = cli.Command{
 			Name:  "event",
 			Usage: "Filter by event type (push, pull_request, issues, issue_comment, etc)",
 		},
+		runsFieldsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
-func runRunsList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runRunsList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	// Build query parameters
 	params := url.Values{}
@@ -67,54 +75,19 @@ func runRunsList(_ stdctx.Context, cmd *cli.Command) error {
 		params.Set("event", event)
 	}
 
-	runList, err := getWorkflowRuns(ctx.Login, ctx.Owner, ctx.Repo, params.Encode())
+	runList, err := getWorkflowRuns(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, params.Encode())
 	if err != nil {
 		return fmt.Errorf("failed to get workflow runs: %w", err)
 	}
 
-	if len(runList.WorkflowRuns) == 0 {
-		fmt.Println("No workflow runs found")
+	if len(runList.WorkflowRuns) == 0 && !print.IsMachineReadable(ctx.Output) {
+		fmt.Fprintln(ctx.Out(), "No workflow runs found")
 		return nil
 	}
 
-	// Print runs
-	printRuns(runList.WorkflowRuns, ctx.Output)
-	return nil
-}
-
-func printRuns(runs []*ActionRun, output string) {
-	if output == "json" {
-		data, _ := json.MarshalIndent(runs, "", "  ")
-		fmt.Println(string(data))
-		return
-	}
-
-	// Print table header (ID is used for get/jobs commands, # is display number)
-	fmt.Printf("%-7s %-4s %-12s %-10s %-15s %-10s %-35s %s\n",
-		"ID", "#", "STATUS", "CONCLUSION", "EVENT", "BRANCH", "TITLE", "STARTED")
-	fmt.Println(strings.Repeat("-", 115))
-
-	for _, run := range runs {
-		started := ""
-		if !run.StartedAt.IsZero() {
-			started = print.FormatTime(run.StartedAt, false)
-		}
-		fmt.Printf("%-7d %-4d %-12s %-10s %-15s %-10s %-35s %s\n",
-			run.ID,
-			run.RunNumber,
-			run.Status,
-			run.Conclusion,
-			run.Event,
-			truncateString(run.HeadBranch, 10),
-			truncateString(run.DisplayTitle, 35),
-			started,
-		)
-	}
-}
-
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+	fields, err := runsFieldsFlag.GetValues(cmd)
+	if err != nil {
+		return err
 	}
-	return s[:maxLen-3] + "..."
+	return print.ActionRunsList(ctx.Out(), runList.WorkflowRuns, ctx.Output, fields)
 }
@@ -13,6 +13,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -44,8 +45,14 @@ func runSecretsCreate(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("secret name is required")
 	}
 
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	secretName := cmd.Args().First()
 	var secretValue string
@@ -83,14 +90,14 @@ func runSecretsCreate(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("secret value cannot be empty")
 	}
 
-	_, err := client.CreateRepoActionSecret(c.Owner, c.Repo, gitea.CreateSecretOption{
+	err = utils.APIError(client.CreateRepoActionSecret(c.Owner, c.Repo, gitea.CreateSecretOption{
 		Name: secretName,
 		Data: secretValue,
-	})
+	}))
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Secret '%s' created successfully\n", secretName)
+	fmt.Fprintf(c.Out(), "Secret '%s' created successfully\n", secretName)
 	return nil
 }
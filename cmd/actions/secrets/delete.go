@@ -9,6 +9,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -35,8 +36,14 @@ func runSecretsDelete(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("secret name is required")
 	}
 
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	secretName := cmd.Args().First()
 
@@ -50,11 +57,11 @@ func runSecretsDelete(ctx stdctx.Context, cmd *cli.Command) error {
 		}
 	}
 
-	_, err := client.DeleteRepoActionSecret(c.Owner, c.Repo, secretName)
+	err = utils.APIError(client.DeleteRepoActionSecret(c.Owner, c.Repo, secretName))
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Secret '%s' deleted successfully\n", secretName)
+	fmt.Fprintf(c.Out(), "Secret '%s' deleted successfully\n", secretName)
 	return nil
 }
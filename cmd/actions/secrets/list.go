@@ -24,21 +24,30 @@ var CmdSecretsList = cli.Command{
 	Flags: append([]cli.Flag{
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
 // RunSecretsList list action secrets
 func RunSecretsList(ctx stdctx.Context, cmd *cli.Command) error {
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
-	secrets, _, err := client.ListRepoActionSecret(c.Owner, c.Repo, gitea.ListRepoActionSecretOption{
-		ListOptions: flags.GetListOptions(),
+	secrets, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Secret, *gitea.Response, error) {
+		return client.ListRepoActionSecret(c.Owner, c.Repo, gitea.ListRepoActionSecretOption{
+			ListOptions: opts,
+		})
 	})
 	if err != nil {
 		return err
 	}
 
-	print.ActionSecretsList(secrets, c.Output)
-	return nil
+	return print.ActionSecretsList(c.Out(), secrets, c.Output)
 }
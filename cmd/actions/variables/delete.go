@@ -9,6 +9,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -35,8 +36,14 @@ func runVariablesDelete(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("variable name is required")
 	}
 
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	variableName := cmd.Args().First()
 
@@ -50,11 +57,11 @@ func runVariablesDelete(ctx stdctx.Context, cmd *cli.Command) error {
 		}
 	}
 
-	_, err := client.DeleteRepoActionVariable(c.Owner, c.Repo, variableName)
+	err = utils.APIError(client.DeleteRepoActionVariable(c.Owner, c.Repo, variableName))
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Variable '%s' deleted successfully\n", variableName)
+	fmt.Fprintf(c.Out(), "Variable '%s' deleted successfully\n", variableName)
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -31,25 +32,31 @@ var CmdVariablesList = cli.Command{
 
 // RunVariablesList list action variables
 func RunVariablesList(ctx stdctx.Context, cmd *cli.Command) error {
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	if name := cmd.String("name"); name != "" {
 		// Get specific variable
-		variable, _, err := client.GetRepoActionVariable(c.Owner, c.Repo, name)
+		variable, err := utils.APIResult(client.GetRepoActionVariable(c.Owner, c.Repo, name))
 		if err != nil {
 			return err
 		}
 
-		print.ActionVariableDetails(variable)
+		print.ActionVariableDetails(c.Out(), variable)
 		return nil
 	}
 
 	// List all variables - Note: SDK doesn't have ListRepoActionVariables yet
 	// This is a limitation of the current SDK
-	fmt.Println("Note: Listing all variables is not yet supported by the Gitea SDK.")
-	fmt.Println("Use 'tea actions variables list --name <variable-name>' to get a specific variable.")
-	fmt.Println("You can also check your repository's Actions settings in the web interface.")
+	fmt.Fprintln(c.Out(), "Note: Listing all variables is not yet supported by the Gitea SDK.")
+	fmt.Fprintln(c.Out(), "Use 'tea actions variables list --name <variable-name>' to get a specific variable.")
+	fmt.Fprintln(c.Out(), "You can also check your repository's Actions settings in the web interface.")
 
 	return nil
 }
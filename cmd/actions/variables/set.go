@@ -13,6 +13,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -42,8 +43,14 @@ func runVariablesSet(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("variable name is required")
 	}
 
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	variableName := cmd.Args().First()
 	var variableValue string
@@ -78,12 +85,12 @@ func runVariablesSet(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("variable value cannot be empty")
 	}
 
-	_, err := client.CreateRepoActionVariable(c.Owner, c.Repo, variableName, variableValue)
+	err = utils.APIError(client.CreateRepoActionVariable(c.Owner, c.Repo, variableName, variableValue))
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Variable '%s' set successfully\n", variableName)
+	fmt.Fprintf(c.Out(), "Variable '%s' set successfully\n", variableName)
 	return nil
 }
 
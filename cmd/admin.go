@@ -9,6 +9,7 @@ import (
 	"code.gitea.io/tea/cmd/admin/users"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 	"github.com/urfave/cli/v3"
 )
 
@@ -43,14 +44,20 @@ var cmdAdminUsers = cli.Command{
 	Flags: users.CmdUserList.Flags,
 }
 
-func runAdminUserDetail(_ stdctx.Context, cmd *cli.Command, u string) error {
-	ctx := context.InitCommand(cmd)
-	client := ctx.Login.Client()
-	user, _, err := client.GetUserInfo(u)
+func runAdminUserDetail(stdCtx stdctx.Context, cmd *cli.Command, u string) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	user, err := utils.APIResult(client.GetUserInfo(u))
 	if err != nil {
 		return err
 	}
 
-	print.UserDetails(user)
+	print.UserDetails(ctx.Out(), user)
 	return nil
 }
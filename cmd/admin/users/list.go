@@ -29,27 +29,35 @@ var CmdUserList = cli.Command{
 		userFieldsFlag,
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
 // RunUserList list users
-func RunUserList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
+func RunUserList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
 
 	fields, err := userFieldsFlag.GetValues(cmd)
 	if err != nil {
 		return err
 	}
 
-	client := ctx.Login.Client()
-	users, _, err := client.AdminListUsers(gitea.AdminListUsersOptions{
-		ListOptions: flags.GetListOptions(),
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	users, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.User, *gitea.Response, error) {
+		return client.AdminListUsers(gitea.AdminListUsersOptions{
+			ListOptions: opts,
+		})
 	})
 	if err != nil {
 		return err
 	}
 
-	print.UserList(users, ctx.Output, fields)
-
-	return nil
+	return print.UserList(ctx.Out(), users, ctx.Output, fields)
 }
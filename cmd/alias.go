@@ -0,0 +1,217 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	stdctx "context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/extension"
+	"code.gitea.io/tea/modules/print"
+
+	"github.com/anmitsu/go-shlex"
+	"github.com/urfave/cli/v3"
+)
+
+// CmdAlias represents the command to manage user defined command aliases
+var CmdAlias = cli.Command{
+	Name:     "aliases",
+	Aliases:  []string{"alias"},
+	Category: catSetup,
+	Usage:    "Manage command aliases",
+	Description: `Define shortcuts for tea commands. Aliases are listed in help & shell completion,
+and run like any other command.
+
+An alias expands to tea arguments, in which $1, $2, ... are replaced by the arguments
+given to the alias. Remaining arguments are appended to the expansion.
+Expansions starting with "!" are run by the shell (sh -c) instead, with the arguments
+available as $1, $2, ... and "$@".
+
+Examples:
+  tea alias set mine 'issues list --assignee @me --state open -o simple'
+  tea alias set co 'pulls checkout $1'
+  tea alias set todo '!tea issues list -o simple | grep "$1"'`,
+	Action: runAliasList,
+	Commands: []*cli.Command{
+		&cmdAliasList,
+		&cmdAliasSet,
+		&cmdAliasDelete,
+	},
+	Flags: []cli.Flag{&flags.OutputFlag},
+}
+
+var cmdAliasList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List command aliases",
+	Description: "List command aliases",
+	ArgsUsage:   " ", // command does not accept arguments
+	Action:      runAliasList,
+	Flags:       []cli.Flag{&flags.OutputFlag},
+}
+
+var cmdAliasSet = cli.Command{
+	Name:        "set",
+	Usage:       "Create or replace a command alias",
+	Description: "Create or replace a command alias. Quote the expansion to pass it as a single argument.",
+	ArgsUsage:   "<name> <expansion>",
+	Action:      runAliasSet,
+}
+
+var cmdAliasDelete = cli.Command{
+	Name:        "delete",
+	Aliases:     []string{"rm"},
+	Usage:       "Delete a command alias",
+	Description: "Delete a command alias",
+	ArgsUsage:   "<name>",
+	Action: func(_ stdctx.Context, cmd *cli.Command) error {
+		if cmd.Args().Len() != 1 {
+			return errors.New("expected an alias name")
+		}
+		return config.DeleteAlias(cmd.Args().First())
+	},
+}
+
+func runAliasList(_ stdctx.Context, cmd *cli.Command) error {
+	aliases, err := config.GetAliases()
+	if err != nil {
+		return err
+	}
+	return print.AliasesList(os.Stdout, aliases, cmd.String("output"))
+}
+
+func runAliasSet(_ stdctx.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 2 {
+		return errors.New("expected arguments <name> <expansion>")
+	}
+	name, expansion := cmd.Args().Get(0), cmd.Args().Get(1)
+
+	if len(name) == 0 || strings.ContainsAny(name, " \t\n") || strings.HasPrefix(name, "-") {
+		return fmt.Errorf("invalid alias name '%s'", name)
+	}
+	if isBuiltinCommand(cmd.Root(), name) {
+		return fmt.Errorf("'%s' is already a tea command", name)
+	}
+
+	if !strings.HasPrefix(expansion, "!") {
+		args, err := shlex.Split(expansion, true)
+		if err != nil {
+			return fmt.Errorf("invalid expansion: %w", err)
+		}
+		if len(args) == 0 || !isCommand(cmd.Root(), args[0]) {
+			return fmt.Errorf("expansion must start with a tea command, or with '!' to run it in the shell")
+		}
+	}
+
+	return config.SetAlias(name, expansion)
+}
+
+// isBuiltinCommand checks if name refers to a command of app, which is not an alias or extension
+func isBuiltinCommand(app *cli.Command, name string) bool {
+	c := app.Command(name)
+	return c != nil && c.Category != catAliases && c.Category != catExtensions
+}
+
+// isCommand checks if name refers to a built-in command of app or an extension
+func isCommand(app *cli.Command, name string) bool {
+	if isBuiltinCommand(app, name) {
+		return true
+	}
+	_, err := extension.Get(name)
+	return err == nil
+}
+
+// aliasCommands returns a command for each user defined alias, which doesn't
+// conflict with a built-in command
+func aliasCommands(app *cli.Command, aliases map[string]string) []*cli.Command {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var cmds []*cli.Command
+	for _, name := range names {
+		expansion := aliases[name]
+		if app.Command(name) != nil {
+			continue
+		}
+		cmds = append(cmds, &cli.Command{
+			Name:            name,
+			Category:        catAliases,
+			Usage:           fmt.Sprintf("Alias for '%s'", expansion),
+			ArgsUsage:       "[<arguments>...]",
+			SkipFlagParsing: true,
+			Action: func(ctx stdctx.Context, cmd *cli.Command) error {
+				return runAlias(ctx, cmd, expansion)
+			},
+		})
+	}
+	return cmds
+}
+
+// runAlias runs an alias expanding to a shell script with the arguments given to it.
+// Other aliases are expanded by ResolveArgs before the command dispatch, so they
+// only end up here if they are used in the expansion of another alias.
+func runAlias(ctx stdctx.Context, cmd *cli.Command, expansion string) error {
+	script, ok := strings.CutPrefix(expansion, "!")
+	if !ok {
+		return fmt.Errorf("alias '%s' can't be used in the expansion of another alias", cmd.Name)
+	}
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	c := exec.CommandContext(ctx, shell, append([]string{flag, script, cmd.Name}, cmd.Args().Slice()...)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return cli.Exit("", exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
+
+var aliasArgRegex = regexp.MustCompile(`\$(\d+)`)
+
+// expandAlias splits an alias expansion into arguments, and replaces $1, $2, ...
+// with the given arguments. Arguments not referenced are appended.
+func expandAlias(expansion string, args []string) ([]string, error) {
+	words, err := shlex.Split(expansion, true)
+	if err != nil {
+		return nil, err
+	}
+
+	used := 0
+	for i, w := range words {
+		var missing error
+		words[i] = aliasArgRegex.ReplaceAllStringFunc(w, func(ref string) string {
+			n, _ := strconv.Atoi(ref[1:])
+			if n == 0 || n > len(args) {
+				missing = fmt.Errorf("expected at least %d argument(s), got %d", max(n, 1), len(args))
+				return ref
+			}
+			used = max(used, n)
+			return args[n-1]
+		})
+		if missing != nil {
+			return nil, missing
+		}
+	}
+	return append(words, args[used:]...), nil
+}
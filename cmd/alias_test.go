@@ -0,0 +1,94 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandAlias(t *testing.T) {
+	tests := []struct {
+		name      string
+		expansion string
+		args      []string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "append arguments",
+			expansion: "issues list --state open -o simple",
+			args:      []string{"--limit", "5"},
+			want:      []string{"issues", "list", "--state", "open", "-o", "simple", "--limit", "5"},
+		},
+		{
+			name:      "positional arguments",
+			expansion: `pulls checkout $1 --repo=$2`,
+			args:      []string{"12", "gitea/tea", "--branch"},
+			want:      []string{"pulls", "checkout", "12", "--repo=gitea/tea", "--branch"},
+		},
+		{
+			name:      "quoted words",
+			expansion: `issues create --title "fix: $1"`,
+			args:      []string{"typo"},
+			want:      []string{"issues", "create", "--title", "fix: typo"},
+		},
+		{
+			name:      "missing argument",
+			expansion: "pulls checkout $1",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandAlias(tt.expansion, tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveArgs(t *testing.T) {
+	aliases := map[string]string{
+		"mine": "issues list --state $1",
+		"todo": `!tea issues list | grep "$1"`,
+	}
+
+	// global flags given before an alias apply to its expansion
+	app := App()
+	args := []string{"tea", "--dry-run", "--timeout", "5s", "mine", "open", "--limit", "5"}
+	got, err := resolveArgs(app, args, commandIndex(app, args), aliases)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tea", "--dry-run", "--timeout", "5s", "issues", "list", "--state", "open", "--limit", "5"}, got)
+	assert.Equal(t, catAliases, app.Command("mine").Category)
+
+	// aliases run by the shell are dispatched as commands
+	app = App()
+	args = []string{"tea", "--timeout=5s", "todo", "x"}
+	got, err = resolveArgs(app, args, commandIndex(app, args), aliases)
+	require.NoError(t, err)
+	assert.Equal(t, args, got)
+	assert.NotNil(t, app.Command("todo"))
+
+	_, err = resolveArgs(App(), []string{"tea", "mine"}, 1, aliases)
+	assert.Error(t, err)
+
+	// built-in commands are run as given
+	app = App()
+	commands := len(app.Commands)
+	args = []string{"tea", "--debug", "issues", "list"}
+	got, err = ResolveArgs(app, args)
+	require.NoError(t, err)
+	assert.Equal(t, args, got)
+	assert.Len(t, app.Commands, commands)
+	assert.Equal(t, 1, commandIndex(app, []string{"tea", "mine", "--timeout", "5s"}))
+	assert.Equal(t, 3, commandIndex(app, []string{"tea", "--timeout", "5s"}))
+}
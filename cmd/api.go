@@ -0,0 +1,314 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	stdctx "context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/api"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdAPI represents the command to make authenticated raw API requests
+var CmdAPI = cli.Command{
+	Name:     "api",
+	Category: catHelpers,
+	Usage:    "Make an authenticated request to the Gitea API",
+	Description: `Send a request to the Gitea API (/api/v1) as the selected login, and print the response.
+
+The method defaults to GET, or POST if a request body is given. The path may contain
+the placeholders {owner} and {repo}, which are resolved from the repository context
+(--repo, --remote or the git repo in $PWD).
+
+The request body is read from --data, --input (use "-" for stdin), or built as JSON
+object from --field key=value pairs. Field values true, false, null and numbers are
+sent as JSON literals, everything else as string.
+
+Examples:
+  tea api /user
+  tea api repos/{owner}/{repo}/issues --query state=closed --paginate
+  tea api POST repos/{owner}/{repo}/labels -f name=bug -f color=#ee0701
+  tea api PATCH repos/{owner}/{repo} --input settings.json`,
+	ArgsUsage: "[<method>] <path>",
+	Action:    runAPI,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "data",
+			Aliases: []string{"d"},
+			Usage:   "Raw request body",
+		},
+		&cli.StringFlag{
+			Name:  "input",
+			Usage: `Read request body from file, or from stdin if "-"`,
+		},
+		&cli.StringSliceFlag{
+			Name:    "field",
+			Aliases: []string{"f"},
+			Usage:   "Add a key=value pair to the JSON request body. Can be repeated",
+		},
+		&cli.StringSliceFlag{
+			Name:    "header",
+			Aliases: []string{"H"},
+			Usage:   `Add a "Key: Value" request header. Can be repeated`,
+		},
+		&cli.StringSliceFlag{
+			Name:    "query",
+			Aliases: []string{"q"},
+			Usage:   "Add a key=value query parameter. Can be repeated",
+		},
+		&cli.BoolFlag{
+			Name:  "paginate",
+			Usage: "Follow Link headers to fetch all pages, and merge JSON array results",
+		},
+		&cli.BoolFlag{
+			Name:    "include",
+			Aliases: []string{"i"},
+			Usage:   "Print response status and headers before the body",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+func runAPI(stdCtx stdctx.Context, cmd *cli.Command) error {
+	method, path, err := parseAPIArgs(cmd.Args().Slice())
+	if err != nil {
+		return err
+	}
+
+	body, err := apiRequestBody(cmd)
+	if err != nil {
+		return err
+	}
+	if len(method) == 0 {
+		method = http.MethodGet
+		if body != nil {
+			method = http.MethodPost
+		}
+	}
+
+	header, err := parseAPIHeaders(cmd.StringSlice("header"))
+	if err != nil {
+		return err
+	}
+
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if api.HasPlaceholders(path) {
+		if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+			return err
+		}
+		path = api.ExpandPlaceholders(path, ctx.Owner, ctx.Repo)
+	}
+
+	path, err = addAPIQuery(path, cmd.StringSlice("query"))
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewClient(ctx.Ctx, ctx.Login)
+	if err != nil {
+		return err
+	}
+	paginate := cmd.Bool("paginate")
+	var pages []json.RawMessage
+
+	for path != "" {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		resp, err := client.Do(method, path, reqBody, header)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if cmd.Bool("include") {
+			printAPIResponseHeader(resp)
+		}
+		if resp.StatusCode >= 400 {
+			os.Stdout.Write(data)
+			return utils.StatusError(resp.StatusCode, fmt.Errorf("API error (status %d)", resp.StatusCode))
+		}
+
+		path = ""
+		if paginate {
+			path = api.NextPageURL(resp)
+			if json.Valid(data) && bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+				pages = append(pages, data)
+				continue
+			}
+		}
+		printAPIBody(data)
+	}
+
+	if len(pages) != 0 {
+		merged, err := mergeJSONArrays(pages)
+		if err != nil {
+			return err
+		}
+		printAPIBody(merged)
+	}
+	return nil
+}
+
+// parseAPIArgs returns the method and path given as positional arguments
+func parseAPIArgs(args []string) (method, path string, err error) {
+	switch len(args) {
+	case 1:
+		return "", args[0], nil
+	case 2:
+		return strings.ToUpper(args[0]), args[1], nil
+	default:
+		return "", "", fmt.Errorf("expected arguments [<method>] <path>, got %d arguments", len(args))
+	}
+}
+
+// apiRequestBody returns the request body given via flags, or nil if no body was given
+func apiRequestBody(cmd *cli.Command) ([]byte, error) {
+	data := cmd.String("data")
+	input := cmd.String("input")
+	fields := cmd.StringSlice("field")
+
+	given := 0
+	for _, set := range []bool{cmd.IsSet("data"), len(input) != 0, len(fields) != 0} {
+		if set {
+			given++
+		}
+	}
+	if given > 1 {
+		return nil, fmt.Errorf("only one of --data, --input and --field may be used")
+	}
+
+	switch {
+	case cmd.IsSet("data"):
+		return []byte(data), nil
+	case input == "-":
+		return io.ReadAll(os.Stdin)
+	case len(input) != 0:
+		return os.ReadFile(input)
+	case len(fields) != 0:
+		return parseAPIFields(fields)
+	}
+	return nil, nil
+}
+
+// parseAPIFields builds a JSON object from key=value pairs
+func parseAPIFields(fields []string) ([]byte, error) {
+	obj := make(map[string]any, len(fields))
+	for _, f := range fields {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok || len(key) == 0 {
+			return nil, fmt.Errorf("invalid field '%s', expected key=value", f)
+		}
+		obj[key] = parseAPIFieldValue(value)
+	}
+	return json.Marshal(obj)
+}
+
+// parseAPIFieldValue converts JSON literals to their typed value
+func parseAPIFieldValue(value string) any {
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
+
+// parseAPIHeaders parses "Key: Value" pairs into a header
+func parseAPIHeaders(headers []string) (http.Header, error) {
+	header := http.Header{}
+	for _, h := range headers {
+		key, value, ok := strings.Cut(h, ":")
+		if !ok || len(strings.TrimSpace(key)) == 0 {
+			return nil, fmt.Errorf("invalid header '%s', expected 'Key: Value'", h)
+		}
+		header.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	return header, nil
+}
+
+// addAPIQuery appends key=value pairs as query parameters to path
+func addAPIQuery(path string, params []string) (string, error) {
+	if len(params) == 0 {
+		return path, nil
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path '%s': %w", path, err)
+	}
+	query := u.Query()
+	for _, p := range params {
+		key, value, ok := strings.Cut(p, "=")
+		if !ok || len(key) == 0 {
+			return "", fmt.Errorf("invalid query parameter '%s', expected key=value", p)
+		}
+		query.Add(key, value)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// mergeJSONArrays concatenates the elements of multiple JSON arrays into one array
+func mergeJSONArrays(pages []json.RawMessage) ([]byte, error) {
+	merged := []json.RawMessage{}
+	for _, page := range pages {
+		var items []json.RawMessage
+		if err := json.Unmarshal(page, &items); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		merged = append(merged, items...)
+	}
+	return json.Marshal(merged)
+}
+
+func printAPIResponseHeader(resp *http.Response) {
+	fmt.Printf("%s %s\n", resp.Proto, resp.Status)
+	for _, key := range slices.Sorted(maps.Keys(resp.Header)) {
+		for _, v := range resp.Header[key] {
+			fmt.Printf("%s: %s\n", key, v)
+		}
+	}
+	fmt.Println()
+}
+
+// printAPIBody prints the response body, indenting valid JSON
+func printAPIBody(data []byte) {
+	var out bytes.Buffer
+	if json.Valid(data) && json.Indent(&out, data, "", "  ") == nil {
+		fmt.Println(out.String())
+		return
+	}
+	os.Stdout.Write(data)
+}
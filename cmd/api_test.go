@@ -0,0 +1,71 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPIArgs(t *testing.T) {
+	method, path, err := parseAPIArgs([]string{"/user"})
+	require.NoError(t, err)
+	assert.Empty(t, method)
+	assert.Equal(t, "/user", path)
+
+	method, path, err = parseAPIArgs([]string{"patch", "repos/{owner}/{repo}"})
+	require.NoError(t, err)
+	assert.Equal(t, "PATCH", method)
+	assert.Equal(t, "repos/{owner}/{repo}", path)
+
+	_, _, err = parseAPIArgs(nil)
+	assert.Error(t, err)
+}
+
+func TestParseAPIFields(t *testing.T) {
+	data, err := parseAPIFields([]string{"name=bug", "exclusive=true", "priority=3", "color=#ee0701", "description="})
+	require.NoError(t, err)
+
+	var obj map[string]any
+	require.NoError(t, json.Unmarshal(data, &obj))
+	assert.Equal(t, map[string]any{
+		"name":        "bug",
+		"exclusive":   true,
+		"priority":    float64(3),
+		"color":       "#ee0701",
+		"description": "",
+	}, obj)
+
+	_, err = parseAPIFields([]string{"invalid"})
+	assert.Error(t, err)
+}
+
+func TestParseAPIHeaders(t *testing.T) {
+	header, err := parseAPIHeaders([]string{"Accept: text/plain", "X-Custom:value"})
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", header.Get("Accept"))
+	assert.Equal(t, "value", header.Get("X-Custom"))
+
+	_, err = parseAPIHeaders([]string{"no header"})
+	assert.Error(t, err)
+}
+
+func TestAddAPIQuery(t *testing.T) {
+	path, err := addAPIQuery("repos/a/b/issues?type=issues", []string{"state=closed", "labels=bug"})
+	require.NoError(t, err)
+	assert.Equal(t, "repos/a/b/issues?labels=bug&state=closed&type=issues", path)
+}
+
+func TestMergeJSONArrays(t *testing.T) {
+	merged, err := mergeJSONArrays([]json.RawMessage{
+		json.RawMessage(`[{"id":1},{"id":2}]`),
+		json.RawMessage(`[]`),
+		json.RawMessage(`[{"id":3}]`),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":1},{"id":2},{"id":3}]`, string(merged))
+}
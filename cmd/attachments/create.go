@@ -11,6 +11,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -26,10 +27,18 @@ var CmdReleaseAttachmentCreate = cli.Command{
 	Flags:       flags.AllDefaultFlags,
 }
 
-func runReleaseAttachmentCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runReleaseAttachmentCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if ctx.Args().Len() < 2 {
 		return fmt.Errorf("No release tag or assets specified.\nUsage:\t%s", ctx.Command.UsageText)
@@ -53,7 +62,7 @@ func runReleaseAttachmentCreate(_ stdctx.Context, cmd *cli.Command) error {
 
 		filePath := filepath.Base(asset)
 
-		if _, _, err = ctx.Login.Client().CreateReleaseAttachment(ctx.Owner, ctx.Repo, release.ID, file, filePath); err != nil {
+		if _, err = utils.APIResult(client.CreateReleaseAttachment(ctx.Owner, ctx.Repo, release.ID, file, filePath)); err != nil {
 			file.Close()
 			return err
 		}
@@ -9,6 +9,8 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -31,10 +33,18 @@ var CmdReleaseAttachmentDelete = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runReleaseAttachmentDelete(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runReleaseAttachmentDelete(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if ctx.Args().Len() < 2 {
 		return fmt.Errorf("No release tag or attachment names specified.\nUsage:\t%s", ctx.Command.UsageText)
@@ -55,14 +65,14 @@ func runReleaseAttachmentDelete(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	existing, _, err := client.ListReleaseAttachments(ctx.Owner, ctx.Repo, release.ID, gitea.ListReleaseAttachmentsOptions{
+	existing, err := utils.APIResult(client.ListReleaseAttachments(ctx.Owner, ctx.Repo, release.ID, gitea.ListReleaseAttachmentsOptions{
 		ListOptions: gitea.ListOptions{Page: -1},
-	})
+	}))
 	if err != nil {
 		return err
 	}
 
-	for _, name := range ctx.Args().Slice()[1:] {
+	return task.ForEachItem(ctx.Args().Slice()[1:], task.ArgName, func(name string) error {
 		var attachment *gitea.Attachment
 		for _, a := range existing {
 			if a.Name == name {
@@ -70,22 +80,18 @@ func runReleaseAttachmentDelete(_ stdctx.Context, cmd *cli.Command) error {
 			}
 		}
 		if attachment == nil {
-			return fmt.Errorf("Release does not have attachment named '%s'", name)
-		}
-
-		_, err = client.DeleteReleaseAttachment(ctx.Owner, ctx.Repo, release.ID, attachment.ID)
-		if err != nil {
-			return err
+			return utils.NewNotFoundErrorf("Release does not have attachment named '%s'", name)
 		}
-	}
 
-	return nil
+		err := utils.APIError(client.DeleteReleaseAttachment(ctx.Owner, ctx.Repo, release.ID, attachment.ID))
+		return err
+	})
 }
 
 func getReleaseAttachmentByName(owner, repo string, release int64, name string, client *gitea.Client) (*gitea.Attachment, error) {
-	al, _, err := client.ListReleaseAttachments(owner, repo, release, gitea.ListReleaseAttachmentsOptions{
+	al, err := utils.APIResult(client.ListReleaseAttachments(owner, repo, release, gitea.ListReleaseAttachmentsOptions{
 		ListOptions: gitea.ListOptions{Page: -1},
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,7 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -26,14 +27,24 @@ var CmdReleaseAttachmentList = cli.Command{
 	Flags: append([]cli.Flag{
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
 // RunReleaseAttachmentList list release attachments
-func RunReleaseAttachmentList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func RunReleaseAttachmentList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	tag := ctx.Args().First()
 	if len(tag) == 0 {
@@ -45,21 +56,22 @@ func RunReleaseAttachmentList(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	attachments, _, err := ctx.Login.Client().ListReleaseAttachments(ctx.Owner, ctx.Repo, release.ID, gitea.ListReleaseAttachmentsOptions{
-		ListOptions: flags.GetListOptions(),
+	attachments, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Attachment, *gitea.Response, error) {
+		return client.ListReleaseAttachments(ctx.Owner, ctx.Repo, release.ID, gitea.ListReleaseAttachmentsOptions{
+			ListOptions: opts,
+		})
 	})
 	if err != nil {
 		return err
 	}
 
-	print.ReleaseAttachmentsList(attachments, ctx.Output)
-	return nil
+	return print.ReleaseAttachmentsList(ctx.Out(), attachments, ctx.Output)
 }
 
 func getReleaseByTag(owner, repo, tag string, client *gitea.Client) (*gitea.Release, error) {
-	rl, _, err := client.ListReleases(owner, repo, gitea.ListReleasesOptions{
+	rl, err := utils.APIResult(client.ListReleases(owner, repo, gitea.ListReleasesOptions{
 		ListOptions: gitea.ListOptions{Page: -1},
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
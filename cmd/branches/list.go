@@ -23,6 +23,8 @@ var CmdBranchesListFlags = append([]cli.Flag{
 	branchFieldsFlag,
 	&flags.PaginationPageFlag,
 	&flags.PaginationLimitFlag,
+	&flags.PaginationAllFlag,
+	&flags.PaginationMaxItemsFlag,
 }, flags.AllDefaultFlags...)
 
 // CmdBranchesList represents a sub command of branches to list branches
@@ -37,28 +39,40 @@ var CmdBranchesList = cli.Command{
 }
 
 // RunBranchesList list branches
-func RunBranchesList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func RunBranchesList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	owner := ctx.Owner
 	if ctx.IsSet("owner") {
 		owner = ctx.String("owner")
 	}
 
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 	var branches []*gitea.Branch
 	var protections []*gitea.BranchProtection
-	var err error
-	branches, _, err = ctx.Login.Client().ListRepoBranches(owner, ctx.Repo, gitea.ListRepoBranchesOptions{
-		ListOptions: flags.GetListOptions(),
+	branches, err = flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Branch, *gitea.Response, error) {
+		return client.ListRepoBranches(owner, ctx.Repo, gitea.ListRepoBranchesOptions{
+			ListOptions: opts,
+		})
 	})
 
 	if err != nil {
 		return err
 	}
 
-	protections, _, err = ctx.Login.Client().ListBranchProtections(owner, ctx.Repo, gitea.ListBranchProtectionsOptions{
-		ListOptions: flags.GetListOptions(),
+	protections, err = flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.BranchProtection, *gitea.Response, error) {
+		return client.ListBranchProtections(owner, ctx.Repo, gitea.ListBranchProtectionsOptions{
+			ListOptions: opts,
+		})
 	})
 
 	if err != nil {
@@ -70,6 +84,5 @@ func RunBranchesList(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	print.BranchesList(branches, protections, ctx.Output, fields)
-	return nil
+	return print.BranchesList(ctx.Out(), branches, protections, ctx.Output, fields)
 }
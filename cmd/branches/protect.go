@@ -9,6 +9,8 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -44,9 +46,18 @@ var CmdBranchesUnprotect = cli.Command{
 }
 
 // RunBranchesProtect function to protect/unprotect a list of branches
-func RunBranchesProtect(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func RunBranchesProtect(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify at least one branch")
@@ -57,12 +68,15 @@ func RunBranchesProtect(_ stdctx.Context, cmd *cli.Command) error {
 		owner = ctx.String("owner")
 	}
 
-	for _, branch := range ctx.Args().Slice() {
+	command := ctx.Command.Name
+	if command != "protect" && command != "unprotect" {
+		return fmt.Errorf("command %s is not supported", command)
+	}
 
+	return task.ForEachItem(ctx.Args().Slice(), task.ArgName, func(branch string) error {
 		var err error
-		command := ctx.Command.Name
 		if command == "protect" {
-			_, _, err = ctx.Login.Client().CreateBranchProtection(owner, ctx.Repo, gitea.CreateBranchProtectionOption{
+			_, err = utils.APIResult(client.CreateBranchProtection(owner, ctx.Repo, gitea.CreateBranchProtectionOption{
 				BranchName:                    branch,
 				RuleName:                      "",
 				EnablePush:                    false,
@@ -86,17 +100,10 @@ func RunBranchesProtect(_ stdctx.Context, cmd *cli.Command) error {
 				RequireSignedCommits:          false,
 				ProtectedFilePatterns:         "",
 				UnprotectedFilePatterns:       "",
-			})
-		} else if command == "unprotect" {
-			_, err = ctx.Login.Client().DeleteBranchProtection(owner, ctx.Repo, branch)
+			}))
 		} else {
-			return fmt.Errorf("command %s is not supported", command)
+			err = utils.APIError(client.DeleteBranchProtection(owner, ctx.Repo, branch))
 		}
-
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+		return err
+	})
 }
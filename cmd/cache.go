@@ -0,0 +1,41 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	stdctx "context"
+	"fmt"
+
+	"code.gitea.io/tea/modules/cache"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdCache represents the command to manage the cache of API responses
+var CmdCache = cli.Command{
+	Name:     "cache",
+	Category: catMisc,
+	Usage:    "Manage the cache of API responses",
+	Description: `tea caches responses of the Gitea API in $XDG_CACHE_HOME/tea/http.
+Lookup data like labels, milestones, assignees and repository details is reused
+for a few minutes; other responses are revalidated with the server on every request.
+Use the global --no-cache flag to ignore cached responses for a single command.`,
+	Action: func(_ stdctx.Context, cmd *cli.Command) error {
+		fmt.Println(cache.Dir())
+		return nil
+	},
+	Commands: []*cli.Command{
+		&cmdCacheClear,
+	},
+}
+
+var cmdCacheClear = cli.Command{
+	Name:        "clear",
+	Usage:       "Remove all cached API responses",
+	Description: "Remove all cached API responses",
+	ArgsUsage:   " ", // command does not accept arguments
+	Action: func(_ stdctx.Context, cmd *cli.Command) error {
+		return cache.Clear()
+	},
+}
@@ -4,8 +4,10 @@
 package cmd
 
 var (
-	catSetup    = "SETUP"
-	catEntities = "ENTITIES"
-	catHelpers  = "HELPERS"
-	catMisc     = "MISCELLANEOUS"
+	catSetup      = "SETUP"
+	catEntities   = "ENTITIES"
+	catHelpers    = "HELPERS"
+	catMisc       = "MISCELLANEOUS"
+	catAliases    = "ALIASES"
+	catExtensions = "EXTENSIONS"
 )
@@ -5,7 +5,7 @@ package cmd
 
 import (
 	stdctx "context"
-	"fmt"
+	"errors"
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/config"
@@ -48,7 +48,10 @@ When a host is specified in the repo-slug, it will override the login specified
 }
 
 func runRepoClone(ctx stdctx.Context, cmd *cli.Command) error {
-	teaCmd := context.InitCommand(cmd)
+	teaCmd, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
 
 	args := teaCmd.Args()
 	if args.Len() < 1 {
@@ -73,14 +76,17 @@ func runRepoClone(ctx stdctx.Context, cmd *cli.Command) error {
 
 	owner, repo = utils.GetOwnerAndRepo(url.Path, login.User)
 	if url.Host != "" {
-		login = config.GetLoginByHost(url.Host)
-		if login == nil {
-			return fmt.Errorf("No login configured matching host '%s', run `tea login add` first", url.Host)
+		login, err = config.GetLoginByHost(url.Host)
+		if errors.Is(err, utils.ErrMissingLogin) {
+			return utils.NewMissingLoginErrorf("No login configured matching host '%s', run `tea login add` first", url.Host)
+		} else if err != nil {
+			return err
 		}
 		debug.Printf("Matched login '%s' for host '%s'", login.Name, url.Host)
 	}
 
 	_, err = task.RepoClone(
+		teaCmd.Ctx,
 		dir,
 		login,
 		owner,
@@ -6,9 +6,16 @@ package cmd // import "code.gitea.io/tea"
 
 import (
 	"fmt"
+	"os"
 	"runtime"
+	"slices"
 	"strings"
 
+	"code.gitea.io/tea/modules/cache"
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/debug"
+	"code.gitea.io/tea/modules/dryrun"
+
 	"github.com/urfave/cli/v3"
 )
 
@@ -23,18 +30,21 @@ var Tags = ""
 // SDK holds the sdk version from go.mod
 var SDK = ""
 
-// App creates and returns a tea Command with all subcommands set
-// it was separated from main so docs can be generated for it
+// App creates and returns a tea Command with all built-in subcommands set
+// it was separated from main so docs can be generated for it.
+// User defined aliases and extensions are added by ResolveArgs.
 func App() *cli.Command {
 	// make parsing tea --version easier, by printing /just/ the version string
 	cli.VersionPrinter = func(c *cli.Command) { fmt.Fprintln(c.Writer, c.Version) }
 
-	return &cli.Command{
+	app := &cli.Command{
 		Name:               "tea",
 		Usage:              "command line tool to interact with Gitea",
 		Description:        appDescription,
 		CustomHelpTemplate: helpTemplate,
 		Version:            formatVersion(),
+		// set upfront, as errors of ResolveArgs are printed to it before running the app
+		ErrWriter: os.Stderr,
 		Commands: []*cli.Command{
 			&CmdLogin,
 			&CmdLogout,
@@ -58,13 +68,78 @@ func App() *cli.Command {
 			&CmdOpen,
 			&CmdNotifications,
 			&CmdRepoClone,
+			&CmdAPI,
+			&CmdServe,
+			&CmdAlias,
+			&CmdExtension,
+			&CmdCache,
+			&CmdStack,
 
 			&CmdAdmin,
 
 			&CmdGenerateManPage,
 		},
+		Flags:                 append([]cli.Flag{debug.CliFlag(), cache.CliFlag(), dryrun.CliFlag(), &timeoutFlag}, debug.TraceFlags()...),
+		Before:                applyTimeout,
+		After:                 releaseTimeout,
 		EnableShellCompletion: true,
 	}
+	addReposFlag(app.Commands)
+	return app
+}
+
+// ResolveArgs prepares app to run the command line args.
+// Unless args run a built-in command, user defined aliases and extensions are added
+// as commands, so they are resolved by the regular command dispatch, and are listed
+// in help & shell completion. An alias is expanded in args, so the global flags
+// given before it apply to its expansion.
+func ResolveArgs(app *cli.Command, args []string) ([]string, error) {
+	i := commandIndex(app, args)
+	if i < len(args) && app.Command(args[i]) != nil {
+		// the config isn't loaded & $PATH isn't searched for built-in commands
+		return args, nil
+	}
+	aliases, _ := config.GetAliases()
+	return resolveArgs(app, args, i, aliases)
+}
+
+func resolveArgs(app *cli.Command, args []string, i int, aliases map[string]string) ([]string, error) {
+	app.Commands = append(app.Commands, aliasCommands(app, aliases)...)
+	app.Commands = append(app.Commands, extensionCommands(app)...)
+	if i == len(args) {
+		return args, nil
+	}
+	name := args[i]
+	expansion, ok := aliases[name]
+	if !ok || strings.HasPrefix(expansion, "!") || app.Command(name).Category != catAliases {
+		return args, nil
+	}
+	expanded, err := expandAlias(expansion, args[i+1:])
+	if err != nil {
+		return nil, fmt.Errorf("alias '%s': %w", name, err)
+	}
+	return append(slices.Clone(args[:i]), expanded...), nil
+}
+
+// commandIndex returns the index of the command name in args, which follows the
+// program name & global flags, or len(args) if no command is given
+func commandIndex(app *cli.Command, args []string) int {
+	for i := 1; i < len(args); i++ {
+		name, isFlag := strings.CutPrefix(args[i], "-")
+		if !isFlag || args[i] == "-" {
+			return i
+		}
+		if args[i] == "--" {
+			return min(i+1, len(args))
+		}
+		name, _, hasValue := strings.Cut(strings.TrimPrefix(name, "-"), "=")
+		for _, f := range app.Flags {
+			if v, ok := f.(cli.DocGenerationFlag); ok && v.TakesValue() && !hasValue && slices.Contains(f.Names(), name) {
+				i++ // skip the value of the flag
+			}
+		}
+	}
+	return len(args)
 }
 
 func formatVersion() string {
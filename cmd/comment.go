@@ -5,7 +5,6 @@ package cmd
 
 import (
 	stdctx "context"
-	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -14,12 +13,13 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/interact"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
 	"code.gitea.io/tea/modules/theme"
 	"code.gitea.io/tea/modules/utils"
 
-	"code.gitea.io/sdk/gitea"
 	"github.com/charmbracelet/huh"
 	"github.com/urfave/cli/v3"
 )
@@ -44,9 +44,14 @@ var CmdComment = cli.Command{
 // For backwards compatibility, keep the old CmdAddComment as an alias
 var CmdAddComment = CmdComment
 
-func runAddComment(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runAddComment(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	args := ctx.Args()
 	if args.Len() == 0 {
@@ -81,19 +86,16 @@ func runAddComment(_ stdctx.Context, cmd *cli.Command) error {
 		}
 	}
 
-	if len(body) == 0 {
-		return errors.New("no comment content provided")
-	}
-
-	client := ctx.Login.Client()
-	comment, _, err := client.CreateIssueComment(ctx.Owner, ctx.Repo, idx, gitea.CreateIssueCommentOption{
-		Body: body,
-	})
+	comment, err := task.CreateComment(ctx, idx, body)
 	if err != nil {
 		return err
 	}
+	if dryrun.Enabled() {
+		// the comment isn't posted in dry-run mode
+		return nil
+	}
 
-	print.Comment(comment)
+	print.Comment(ctx.Out(), comment)
 
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -24,9 +25,14 @@ var CmdCommentsDelete = cli.Command{
 	Flags:       flags.AllDefaultFlags,
 }
 
-func runCommentsDelete(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runCommentsDelete(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	args := ctx.Args()
 	if args.Len() == 0 {
@@ -38,12 +44,15 @@ func runCommentsDelete(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	client := ctx.Login.Client()
-	_, err = client.DeleteIssueComment(ctx.Owner, ctx.Repo, commentID)
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	err = utils.APIError(client.DeleteIssueComment(ctx.Owner, ctx.Repo, commentID))
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Comment %d deleted\n", commentID)
+	fmt.Fprintf(ctx.Out(), "Comment %d deleted\n", commentID)
 	return nil
 }
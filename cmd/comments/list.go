@@ -34,9 +34,14 @@ var CmdCommentsList = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runCommentsList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runCommentsList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify issue/pr index")
@@ -47,23 +52,26 @@ func runCommentsList(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	opt := gitea.ListIssueCommentOptions{}
 	if limit := cmd.Int("limit"); limit > 0 {
 		opt.PageSize = int(limit)
 	}
 
-	comments, _, err := client.ListIssueComments(ctx.Owner, ctx.Repo, index, opt)
+	comments, err := utils.APIResult(client.ListIssueComments(ctx.Owner, ctx.Repo, index, opt))
 	if err != nil {
 		return err
 	}
 
 	if len(comments) == 0 {
-		fmt.Println("No comments found")
+		fmt.Fprintln(ctx.Out(), "No comments found")
 		return nil
 	}
 
-	print.Comments(comments)
+	print.Comments(ctx.Out(), comments)
 	return nil
 }
@@ -13,9 +13,11 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/interact"
 	"code.gitea.io/tea/modules/print"
 	"code.gitea.io/tea/modules/theme"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/charmbracelet/huh"
@@ -33,9 +35,14 @@ var CmdCommentsUpdate = cli.Command{
 	Flags:       flags.AllDefaultFlags,
 }
 
-func runCommentsUpdate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runCommentsUpdate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	args := ctx.Args()
 	if args.Len() == 0 {
@@ -56,8 +63,11 @@ func runCommentsUpdate(_ stdctx.Context, cmd *cli.Command) error {
 		}
 	} else if len(body) == 0 {
 		// Get existing comment body for editing
-		client := ctx.Login.Client()
-		existingComment, _, err := client.GetIssueComment(ctx.Owner, ctx.Repo, commentID)
+		client, err := ctx.Client()
+		if err != nil {
+			return err
+		}
+		existingComment, err := utils.APIResult(client.GetIssueComment(ctx.Owner, ctx.Repo, commentID))
 		if err != nil {
 			return fmt.Errorf("failed to get existing comment: %w", err)
 		}
@@ -81,15 +91,22 @@ func runCommentsUpdate(_ stdctx.Context, cmd *cli.Command) error {
 		return errors.New("no comment content provided")
 	}
 
-	client := ctx.Login.Client()
-	comment, _, err := client.EditIssueComment(ctx.Owner, ctx.Repo, commentID, gitea.EditIssueCommentOption{
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	comment, err := utils.APIResult(client.EditIssueComment(ctx.Owner, ctx.Repo, commentID, gitea.EditIssueCommentOption{
 		Body: body,
-	})
+	}))
 	if err != nil {
 		return err
 	}
+	if dryrun.Enabled() {
+		// the comment returned in dry-run mode is empty
+		return nil
+	}
 
-	print.Comment(comment)
+	print.Comment(ctx.Out(), comment)
 	return nil
 }
 
@@ -0,0 +1,233 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	stdctx "context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/extension"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdExtension represents the command to manage extensions
+var CmdExtension = cli.Command{
+	Name:     "extensions",
+	Aliases:  []string{"extension", "ext"},
+	Category: catSetup,
+	Usage:    "Manage extensions",
+	Description: `Extensions are executables named tea-<name>, which are run as 'tea <name>'.
+They are found in $PATH, or installed in $XDG_DATA_HOME/tea/extensions via 'tea extension install'.
+
+Before running an extension, tea resolves the login & repository like for any other command,
+and passes them via the environment variables
+  TEA_LOGIN, TEA_URL, TEA_TOKEN, TEA_INSECURE  the login to use
+  TEA_OWNER, TEA_REPO                          the repository, empty if none was detected or given
+  TEA_OUTPUT                                   the selected output format
+  TEA_BIN                                      the path of tea itself
+The flags --login, --repo, --remote and --output are handled by tea for this,
+all other arguments are passed to the extension.
+
+Examples:
+  tea extension install ./tea-deploy
+  tea extension install https://gitea.com/myorg/tea-deploy
+  tea deploy --repo myorg/app ticket 42`,
+	Action: runExtensionList,
+	Commands: []*cli.Command{
+		&cmdExtensionList,
+		&cmdExtensionInstall,
+		&cmdExtensionRemove,
+	},
+	Flags: []cli.Flag{&flags.OutputFlag},
+}
+
+var cmdExtensionList = cli.Command{
+	Name:        "list",
+	Aliases:     []string{"ls"},
+	Usage:       "List extensions",
+	Description: "List the extensions found in $PATH and the installed ones",
+	ArgsUsage:   " ", // command does not accept arguments
+	Action:      runExtensionList,
+	Flags:       []cli.Flag{&flags.OutputFlag},
+}
+
+var cmdExtensionInstall = cli.Command{
+	Name:  "install",
+	Usage: "Install an extension",
+	Description: `Install an extension from the path of an executable named tea-<name>, or from the URL
+of a git repository named tea-<name>, which contains an executable of the same name.`,
+	ArgsUsage: "<path | repository url>",
+	Action:    runExtensionInstall,
+}
+
+var cmdExtensionRemove = cli.Command{
+	Name:        "remove",
+	Aliases:     []string{"rm"},
+	Usage:       "Remove an installed extension",
+	Description: "Remove an extension installed via 'tea extension install'",
+	ArgsUsage:   "<name>",
+	Action: func(_ stdctx.Context, cmd *cli.Command) error {
+		if cmd.Args().Len() != 1 {
+			return utils.NewValidationErrorf("expected an extension name")
+		}
+		return extension.Remove(cmd.Args().First())
+	},
+}
+
+func runExtensionList(_ stdctx.Context, cmd *cli.Command) error {
+	return print.ExtensionsList(os.Stdout, extension.Find(), cmd.String("output"))
+}
+
+func runExtensionInstall(ctx stdctx.Context, cmd *cli.Command) error {
+	if cmd.Args().Len() != 1 {
+		return utils.NewValidationErrorf("expected the path or repository url of an extension")
+	}
+	ext, err := extension.Install(ctx, cmd.Args().First())
+	if err != nil {
+		return err
+	}
+	if c := cmd.Root().Command(ext.Name); c != nil && c.Category != catExtensions {
+		if err := extension.Remove(ext.Name); err != nil {
+			return err
+		}
+		return utils.NewValidationErrorf("extension '%s' would be shadowed by the tea command '%s'", ext.Name, c.Name)
+	}
+	fmt.Printf("Installed extension '%s' to %s\n", ext.Name, ext.Path)
+	return nil
+}
+
+// extensionCommands returns a command for each extension, which doesn't
+// conflict with a built-in command or an alias
+func extensionCommands(app *cli.Command) []*cli.Command {
+	var cmds []*cli.Command
+	for _, ext := range extension.Find() {
+		if app.Command(ext.Name) != nil {
+			continue
+		}
+		cmds = append(cmds, &cli.Command{
+			Name:            ext.Name,
+			Category:        catExtensions,
+			Usage:           fmt.Sprintf("Extension %s", ext.Path),
+			ArgsUsage:       "[<arguments>...]",
+			SkipFlagParsing: true,
+			Action: func(ctx stdctx.Context, cmd *cli.Command) error {
+				return runExtension(ctx, cmd, ext)
+			},
+		})
+	}
+	return cmds
+}
+
+// extensionContextFlags are the flags handled by tea to resolve the context of an extension
+var extensionContextFlags = []cli.Flag{
+	&flags.LoginFlag,
+	&flags.RepoFlag,
+	&flags.RemoteFlag,
+	&flags.OutputFlag,
+}
+
+// runExtension resolves the context given by the extensionContextFlags in the
+// arguments of cmd, and runs the extension with the remaining arguments
+func runExtension(ctx stdctx.Context, cmd *cli.Command, ext extension.Extension) error {
+	teaArgs, extArgs := splitExtensionArgs(cmd.Args().Slice())
+
+	var env []string
+	contextCmd := &cli.Command{
+		Name:     cmd.Name,
+		Flags:    extensionContextFlags,
+		HideHelp: true,
+		Action: func(ctx stdctx.Context, c *cli.Command) (err error) {
+			env, err = extensionEnv(ctx, c)
+			return err
+		},
+	}
+	if err := contextCmd.Run(ctx, append([]string{cmd.Name}, teaArgs...)); err != nil {
+		return err
+	}
+
+	c := exec.CommandContext(ctx, ext.Path, extArgs...)
+	c.Env = append(os.Environ(), env...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return cli.Exit("", exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
+
+// splitExtensionArgs separates the long forms of the extensionContextFlags from
+// the arguments for the extension. Arguments after "--" are passed to the extension.
+func splitExtensionArgs(args []string) (teaArgs, extArgs []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return teaArgs, append(extArgs, args[i:]...)
+		}
+		name, isFlag := strings.CutPrefix(arg, "--")
+		name, _, hasValue := strings.Cut(name, "=")
+		if !isFlag || !slices.ContainsFunc(extensionContextFlags, func(f cli.Flag) bool { return f.Names()[0] == name }) {
+			extArgs = append(extArgs, arg)
+			continue
+		}
+		teaArgs = append(teaArgs, arg)
+		if !hasValue && i+1 < len(args) {
+			i++
+			teaArgs = append(teaArgs, args[i])
+		}
+	}
+	return teaArgs, extArgs
+}
+
+// extensionEnv returns the environment variables passing the context to an extension.
+// Extensions not needing a login can run before a login is configured.
+func extensionEnv(ctx stdctx.Context, cmd *cli.Command) ([]string, error) {
+	login := &config.Login{}
+	output := cmd.String("output")
+	var owner, repo string
+
+	teaCtx, err := context.InitCommand(ctx, cmd)
+	if err == nil {
+		if err := teaCtx.Login.RefreshExpiredToken(ctx); err != nil {
+			return nil, err
+		}
+		login, output = teaCtx.Login, teaCtx.Output
+		if len(teaCtx.RepoSlug) != 0 {
+			owner, repo = teaCtx.Owner, teaCtx.Repo
+		}
+	} else if !errors.Is(err, utils.ErrMissingLogin) || cmd.IsSet("login") {
+		return nil, err
+	}
+
+	// all variables are set, to override the ones of a tea running this extension
+	env := []string{
+		"TEA_LOGIN=" + login.Name,
+		"TEA_URL=" + login.URL,
+		"TEA_TOKEN=" + login.Token,
+		"TEA_INSECURE=" + strconv.FormatBool(login.Insecure),
+		"TEA_OWNER=" + owner,
+		"TEA_REPO=" + repo,
+		"TEA_OUTPUT=" + output,
+	}
+	if bin, err := os.Executable(); err == nil {
+		env = append(env, "TEA_BIN="+bin)
+	}
+	return env, nil
+}
@@ -0,0 +1,18 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitExtensionArgs(t *testing.T) {
+	teaArgs, extArgs := splitExtensionArgs([]string{
+		"ticket", "--repo", "gitea/tea", "-o", "out.txt", "--output=json", "--force", "--login", "work", "--", "--remote", "x",
+	})
+	assert.Equal(t, []string{"--repo", "gitea/tea", "--output=json", "--login", "work"}, teaArgs)
+	assert.Equal(t, []string{"ticket", "-o", "out.txt", "--force", "--", "--remote", "x"}, extArgs)
+}
@@ -12,6 +12,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -53,9 +54,14 @@ var CmdFilesCreate = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runFilesCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runFilesCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify a file path")
@@ -74,7 +80,6 @@ func runFilesCreate(_ stdctx.Context, cmd *cli.Command) error {
 
 	// Get content
 	var fileContent []byte
-	var err error
 
 	if fromFile != "" {
 		fileContent, err = os.ReadFile(fromFile)
@@ -92,7 +97,10 @@ func runFilesCreate(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("must specify --content or --from-file")
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	opts := gitea.CreateFileOptions{
 		FileOptions: gitea.FileOptions{
@@ -103,14 +111,14 @@ func runFilesCreate(_ stdctx.Context, cmd *cli.Command) error {
 		Content: base64.StdEncoding.EncodeToString(fileContent),
 	}
 
-	resp, _, err := client.CreateFile(ctx.Owner, ctx.Repo, filePath, opts)
+	resp, err := utils.APIResult(client.CreateFile(ctx.Owner, ctx.Repo, filePath, opts))
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
 
-	fmt.Printf("Created %s\n", filePath)
+	fmt.Fprintf(ctx.Out(), "Created %s\n", filePath)
 	if resp.Commit != nil {
-		fmt.Printf("Commit: %s\n", resp.Commit.SHA)
+		fmt.Fprintf(ctx.Out(), "Commit: %s\n", resp.Commit.SHA)
 	}
 
 	return nil
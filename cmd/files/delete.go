@@ -9,6 +9,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -44,9 +45,14 @@ var CmdFilesDelete = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runFilesDelete(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runFilesDelete(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify a file path")
@@ -62,11 +68,14 @@ func runFilesDelete(_ stdctx.Context, cmd *cli.Command) error {
 		message = fmt.Sprintf("Delete %s", filePath)
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	// Auto-detect SHA if not provided
 	if sha == "" {
-		existing, _, err := client.GetContents(ctx.Owner, ctx.Repo, branch, filePath)
+		existing, err := utils.APIResult(client.GetContents(ctx.Owner, ctx.Repo, branch, filePath))
 		if err != nil {
 			return fmt.Errorf("failed to get existing file (use --sha to provide manually): %w", err)
 		}
@@ -82,12 +91,12 @@ func runFilesDelete(_ stdctx.Context, cmd *cli.Command) error {
 		SHA: sha,
 	}
 
-	_, err := client.DeleteFile(ctx.Owner, ctx.Repo, filePath, opts)
+	err = utils.APIError(client.DeleteFile(ctx.Owner, ctx.Repo, filePath, opts))
 	if err != nil {
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
 
-	fmt.Printf("Deleted %s\n", filePath)
+	fmt.Fprintf(ctx.Out(), "Deleted %s\n", filePath)
 
 	return nil
 }
@@ -8,11 +8,11 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -43,9 +43,14 @@ var CmdFilesGet = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runFilesGet(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runFilesGet(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify a file path")
@@ -56,10 +61,13 @@ func runFilesGet(_ stdctx.Context, cmd *cli.Command) error {
 	outputFile := cmd.String("output")
 	raw := cmd.Bool("raw")
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	// Get file contents
-	contents, _, err := client.GetContents(ctx.Owner, ctx.Repo, ref, filePath)
+	contents, err := utils.APIResult(client.GetContents(ctx.Owner, ctx.Repo, ref, filePath))
 	if err != nil {
 		return fmt.Errorf("failed to get file: %w", err)
 	}
@@ -79,7 +87,7 @@ func runFilesGet(_ stdctx.Context, cmd *cli.Command) error {
 	}
 
 	// Write to file or stdout
-	var writer io.Writer = os.Stdout
+	writer := ctx.Out()
 	if outputFile != "" {
 		f, err := os.Create(outputFile)
 		if err != nil {
@@ -103,15 +111,15 @@ func runFilesGet(_ stdctx.Context, cmd *cli.Command) error {
 			"encoding": contents.Encoding,
 			"content":  string(decoded),
 		}, "", "  ")
-		fmt.Println(string(data))
+		fmt.Fprintln(ctx.Out(), string(data))
 		return nil
 	}
 
-	fmt.Printf("Path: %s\n", contents.Path)
-	fmt.Printf("SHA:  %s\n", contents.SHA)
-	fmt.Printf("Size: %d bytes\n", contents.Size)
-	fmt.Println("---")
-	fmt.Println(string(decoded))
+	fmt.Fprintf(ctx.Out(), "Path: %s\n", contents.Path)
+	fmt.Fprintf(ctx.Out(), "SHA:  %s\n", contents.SHA)
+	fmt.Fprintf(ctx.Out(), "Size: %d bytes\n", contents.Size)
+	fmt.Fprintln(ctx.Out(), "---")
+	fmt.Fprintln(ctx.Out(), string(decoded))
 
 	return nil
 }
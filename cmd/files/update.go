@@ -12,6 +12,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -57,9 +58,14 @@ var CmdFilesUpdate = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runFilesUpdate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runFilesUpdate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify a file path")
@@ -77,11 +83,14 @@ func runFilesUpdate(_ stdctx.Context, cmd *cli.Command) error {
 		message = fmt.Sprintf("Update %s", filePath)
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	// Auto-detect SHA if not provided
 	if sha == "" {
-		existing, _, err := client.GetContents(ctx.Owner, ctx.Repo, branch, filePath)
+		existing, err := utils.APIResult(client.GetContents(ctx.Owner, ctx.Repo, branch, filePath))
 		if err != nil {
 			return fmt.Errorf("failed to get existing file (use --sha to provide manually): %w", err)
 		}
@@ -90,7 +99,6 @@ func runFilesUpdate(_ stdctx.Context, cmd *cli.Command) error {
 
 	// Get content
 	var fileContent []byte
-	var err error
 
 	if fromFile != "" {
 		fileContent, err = os.ReadFile(fromFile)
@@ -118,14 +126,14 @@ func runFilesUpdate(_ stdctx.Context, cmd *cli.Command) error {
 		Content: base64.StdEncoding.EncodeToString(fileContent),
 	}
 
-	resp, _, err := client.UpdateFile(ctx.Owner, ctx.Repo, filePath, opts)
+	resp, err := utils.APIResult(client.UpdateFile(ctx.Owner, ctx.Repo, filePath, opts))
 	if err != nil {
 		return fmt.Errorf("failed to update file: %w", err)
 	}
 
-	fmt.Printf("Updated %s\n", filePath)
+	fmt.Fprintf(ctx.Out(), "Updated %s\n", filePath)
 	if resp.Commit != nil {
-		fmt.Printf("Commit: %s\n", resp.Commit.SHA)
+		fmt.Fprintf(ctx.Out(), "Commit: %s\n", resp.Commit.SHA)
 	}
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/utils"
 	"github.com/urfave/cli/v3"
 )
@@ -16,6 +17,8 @@ import (
 type CsvFlag struct {
 	cli.StringFlag
 	AvailableFields []string
+	// configurable flags take their default from the fields flag default of the config
+	configurable bool
 }
 
 // NewCsvFlag creates a CsvFlag, while setting its usage string and default values
@@ -40,6 +43,11 @@ func NewCsvFlag(name, usage string, aliases, availableValues, defaults []string)
 // GetValues returns the value of the flag, parsed as a commaseparated list
 func (f CsvFlag) GetValues(cmd *cli.Command) ([]string, error) {
 	val := cmd.String(f.Name)
+	if f.configurable && !cmd.IsSet(f.Name) {
+		if fields, ok := config.GetPreferences().FlagDefaults.Fields[fieldsKey(cmd)]; ok {
+			val = strings.Join(fields, ",")
+		}
+	}
 	selection := strings.Split(val, ",")
 	if f.AvailableFields != nil && val != "" {
 		for _, field := range selection {
@@ -50,3 +58,17 @@ func (f CsvFlag) GetValues(cmd *cli.Command) ([]string, error) {
 	}
 	return selection, nil
 }
+
+// fieldsKey returns the key of a command in the fields flag default,
+// its name path without the root command and a trailing "list", e.g. "repos search"
+func fieldsKey(cmd *cli.Command) string {
+	lineage := cmd.Lineage()
+	names := make([]string, 0, len(lineage))
+	for i := len(lineage) - 2; i >= 0; i-- {
+		names = append(names, lineage[i].Name)
+	}
+	if len(names) > 1 && names[len(names)-1] == "list" {
+		names = names[:len(names)-1]
+	}
+	return strings.Join(names, " ")
+}
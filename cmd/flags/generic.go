@@ -4,9 +4,10 @@
 package flags
 
 import (
-	"errors"
-
 	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/pagination"
+	"code.gitea.io/tea/modules/utils"
+
 	"github.com/urfave/cli/v3"
 )
 
@@ -35,15 +36,25 @@ var RemoteFlag = cli.StringFlag{
 var OutputFlag = cli.StringFlag{
 	Name:    "output",
 	Aliases: []string{"o"},
-	Usage:   "Output format. (simple, table, csv, tsv, yaml, json)",
+	Usage:   "Output format. (simple, table, csv, tsv, yaml, json, template=<go-template>)",
+}
+
+// JQFlag provides flag to filter output with a jq expression
+var JQFlag = cli.StringFlag{
+	Name:  "jq",
+	Usage: "Filter JSON output with a jq expression, e.g. '.[] | select(.state == \"open\") | .title'",
 }
 
 var (
-	paging gitea.ListOptions
+	paging   gitea.ListOptions
+	fetchAll bool
+	maxItems int
 	// ErrPage indicates that the provided page value is invalid (less than -1 or equal to 0).
-	ErrPage = errors.New("page cannot be smaller than 1")
+	ErrPage = utils.NewValidationErrorf("page cannot be smaller than 1")
 	// ErrLimit indicates that the provided limit value is invalid (negative).
-	ErrLimit = errors.New("limit cannot be negative")
+	ErrLimit = utils.NewValidationErrorf("limit cannot be negative")
+	// ErrMaxItems indicates that the provided max-items value is invalid (negative).
+	ErrMaxItems = utils.NewValidationErrorf("max-items cannot be negative")
 )
 
 // GetListOptions returns configured paging struct
@@ -51,10 +62,23 @@ func GetListOptions() gitea.ListOptions {
 	return paging
 }
 
+// GetPaging returns the items to fetch according to the pagination flags
+func GetPaging() pagination.Paging {
+	return pagination.Paging{ListOptions: paging, All: fetchAll, MaxItems: maxItems}
+}
+
+// FetchList fetches the items of a list endpoint according to the pagination flags:
+// a single page by default, or all pages (up to --max-items) if --all is set.
+func FetchList[T any](fetch pagination.Fetcher[T]) ([]T, error) {
+	return pagination.List(fetch, GetPaging())
+}
+
 // PaginationFlags provides all pagination related flags
 var PaginationFlags = []cli.Flag{
 	&PaginationPageFlag,
 	&PaginationLimitFlag,
+	&PaginationAllFlag,
+	&PaginationMaxItemsFlag,
 }
 
 // PaginationPageFlag provides flag for pagination options
@@ -87,6 +111,26 @@ var PaginationLimitFlag = cli.IntFlag{
 	Destination: &paging.PageSize,
 }
 
+// PaginationAllFlag provides flag to fetch all pages of a list
+var PaginationAllFlag = cli.BoolFlag{
+	Name:        "all",
+	Usage:       "fetch all pages, using --limit as page size. Ignores --page",
+	Destination: &fetchAll,
+}
+
+// PaginationMaxItemsFlag provides flag to cap the number of items fetched with --all
+var PaginationMaxItemsFlag = cli.IntFlag{
+	Name:  "max-items",
+	Usage: "maximum number of items to fetch with --all, 0 for no limit",
+	Validator: func(i int) error {
+		if i < 0 {
+			return ErrMaxItems
+		}
+		return nil
+	},
+	Destination: &maxItems,
+}
+
 // LoginOutputFlags defines login and output flags that should
 // added to all subcommands and appended to the flags of the
 // subcommand to work around issue and provide --login and --output:
@@ -94,6 +138,7 @@ var PaginationLimitFlag = cli.IntFlag{
 var LoginOutputFlags = []cli.Flag{
 	&LoginFlag,
 	&OutputFlag,
+	&JQFlag,
 }
 
 // LoginRepoFlags defines login and repo flags that should
@@ -125,6 +170,8 @@ var NotificationFlags = append([]cli.Flag{
 	},
 	&PaginationPageFlag,
 	&PaginationLimitFlag,
+	&PaginationAllFlag,
+	&PaginationMaxItemsFlag,
 }, AllDefaultFlags...)
 
 // NotificationStateFlag is a csv flag applied to all notification subcommands as filter
@@ -137,7 +184,10 @@ var NotificationStateFlag = NewCsvFlag(
 )
 
 // FieldsFlag generates a flag selecting printable fields.
-// To retrieve the value, use f.GetValues()
+// To retrieve the value, use f.GetValues(). Its default can be overridden
+// per command via the fields flag default in the config or .tea.yml.
 func FieldsFlag(availableFields, defaultFields []string) *CsvFlag {
-	return NewCsvFlag("fields", "fields to print", []string{"f"}, availableFields, defaultFields)
+	f := NewCsvFlag("fields", "fields to print", []string{"f"}, availableFields, defaultFields)
+	f.configurable = true
+	return f
 }
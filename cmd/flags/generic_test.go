@@ -101,6 +101,11 @@ func TestPaginationFailures(t *testing.T) {
 			args:          []string{"test", "--page", "0"},
 			expectedError: ErrPage,
 		},
+		{
+			name:          "negative max items",
+			args:          []string{"test", "--all", "--max-items", "-1"},
+			expectedError: ErrMaxItems,
+		},
 		{
 			//urfave does not validate all flags in one pass
 			name:          "negative paging and paging",
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/araddon/dateparse"
 	"github.com/urfave/cli/v3"
@@ -40,6 +42,8 @@ var PRListingFlags = append([]cli.Flag{
 	&StateFlag,
 	&PaginationPageFlag,
 	&PaginationLimitFlag,
+	&PaginationAllFlag,
+	&PaginationMaxItemsFlag,
 }, AllDefaultFlags...)
 
 // IssueListingFlags defines flags that should be available on issue listing flags.
@@ -86,6 +90,8 @@ var IssueListingFlags = append([]cli.Flag{
 	},
 	&PaginationPageFlag,
 	&PaginationLimitFlag,
+	&PaginationAllFlag,
+	&PaginationMaxItemsFlag,
 }, AllDefaultFlags...)
 
 // issuePRFlags defines shared flags between flags IssuePRCreateFlags and IssuePREditFlags
@@ -115,6 +121,17 @@ var issuePRFlags = append([]cli.Flag{
 	},
 }, LoginRepoFlags...)
 
+// GetMergeStyle returns the merge style given via --style, falling back to the
+// merge style configured as flag default
+func GetMergeStyle(ctx *context.TeaContext) gitea.MergeStyle {
+	if !ctx.IsSet("style") {
+		if style := config.GetPreferences().FlagDefaults.MergeStyle; len(style) != 0 {
+			return gitea.MergeStyle(style)
+		}
+	}
+	return gitea.MergeStyle(ctx.String("style"))
+}
+
 // IssuePRCreateFlags defines flags for creation of issues and PRs
 var IssuePRCreateFlags = append([]cli.Flag{
 	&cli.StringFlag{
@@ -127,6 +144,12 @@ var IssuePRCreateFlags = append([]cli.Flag{
 		Aliases: []string{"L"},
 		Usage:   "Comma-separated list of labels to assign",
 	},
+	&cli.StringFlag{
+		Name:  "template",
+		Usage: "Name of the issue or pull request template to apply: its title prefix, labels, assignees and content, unless given via --description",
+	},
+	&OutputFlag,
+	&JQFlag,
 }, issuePRFlags...)
 
 // GetIssuePRCreateFlags parses all IssuePREditFlags
@@ -147,12 +170,18 @@ func GetIssuePRCreateFlags(ctx *context.TeaContext) (*gitea.CreateIssueOption, e
 		opts.Deadline = &t
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
 
 	labelNames := strings.Split(ctx.String("labels"), ",")
 	if len(labelNames) != 0 {
 		if client == nil {
-			client = ctx.Login.Client()
+			client, err = ctx.Client()
+			if err != nil {
+				return nil, err
+			}
 		}
 		if opts.Labels, err = task.ResolveLabelNames(client, ctx.Owner, ctx.Repo, labelNames); err != nil {
 			return nil, err
@@ -161,9 +190,12 @@ func GetIssuePRCreateFlags(ctx *context.TeaContext) (*gitea.CreateIssueOption, e
 
 	if milestoneName := ctx.String("milestone"); len(milestoneName) != 0 {
 		if client == nil {
-			client = ctx.Login.Client()
+			client, err = ctx.Client()
+			if err != nil {
+				return nil, err
+			}
 		}
-		ms, _, err := client.GetMilestoneByName(ctx.Owner, ctx.Repo, milestoneName)
+		ms, err := utils.APIResult(client.GetMilestoneByName(ctx.Owner, ctx.Repo, milestoneName))
 		if err != nil {
 			return nil, fmt.Errorf("Milestone '%s' not found", milestoneName)
 		}
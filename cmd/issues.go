@@ -79,37 +79,44 @@ func runIssues(ctx stdctx.Context, cmd *cli.Command) error {
 	return issues.RunIssuesList(ctx, cmd)
 }
 
-func runIssueDetail(_ stdctx.Context, cmd *cli.Command, index string) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runIssueDetail(stdCtx stdctx.Context, cmd *cli.Command, index string) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	idx, err := utils.ArgToIndex(index)
 	if err != nil {
 		return err
 	}
-	client := ctx.Login.Client()
-	issue, _, err := client.GetIssue(ctx.Owner, ctx.Repo, idx)
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	issue, err := utils.APIResult(client.GetIssue(ctx.Owner, ctx.Repo, idx))
 	if err != nil {
 		return err
 	}
-	reactions, _, err := client.GetIssueReactions(ctx.Owner, ctx.Repo, idx)
+	reactions, err := utils.APIResult(client.GetIssueReactions(ctx.Owner, ctx.Repo, idx))
 	if err != nil {
 		return err
 	}
 
-	if ctx.IsSet("output") {
-		switch ctx.String("output") {
-		case "json":
-			return runIssueDetailAsJSON(ctx, issue)
-		}
+	if ctx.Output == "json" {
+		return runIssueDetailAsJSON(ctx, issue)
 	}
 
-	print.IssueDetails(issue, reactions)
+	if err := print.IssueDetails(ctx.Out(), issue, reactions, ctx.Output); err != nil {
+		return err
+	}
 
-	if issue.Comments > 0 {
+	if issue.Comments > 0 && !print.IsQueryOutput(ctx.Output) {
 		err = interact.ShowCommentsMaybeInteractive(ctx, idx, issue.Comments)
 		if err != nil {
-			return fmt.Errorf("error loading comments: %v", err)
+			return fmt.Errorf("error loading comments: %w", err)
 		}
 	}
 
@@ -117,7 +124,10 @@ func runIssueDetail(_ stdctx.Context, cmd *cli.Command, index string) error {
 }
 
 func runIssueDetailAsJSON(ctx *context.TeaContext, issue *gitea.Issue) error {
-	c := ctx.Login.Client()
+	c, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 	opts := gitea.ListIssueCommentOptions{ListOptions: flags.GetListOptions()}
 
 	labelSlice := make([]labelData, 0, len(issue.Labels))
@@ -146,7 +156,7 @@ func runIssueDetailAsJSON(ctx *context.TeaContext, issue *gitea.Issue) error {
 	}
 
 	if ctx.Bool("comments") {
-		comments, _, err := c.ListIssueComments(ctx.Owner, ctx.Repo, issue.Index, opts)
+		comments, err := utils.APIResult(c.ListIssueComments(ctx.Owner, ctx.Repo, issue.Index, opts))
 		issueSlice.Comments = make([]commentData, 0, len(comments))
 
 		if err != nil {
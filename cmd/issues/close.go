@@ -10,7 +10,10 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/retry"
+	"code.gitea.io/tea/modules/task"
 	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
@@ -31,9 +34,14 @@ var CmdIssuesClose = cli.Command{
 }
 
 // editIssueState abstracts the arg parsing to edit the given issue
-func editIssueState(_ stdctx.Context, cmd *cli.Command, opts gitea.EditIssueOption) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func editIssueState(stdCtx stdctx.Context, cmd *cli.Command, opts gitea.EditIssueOption) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 	if ctx.Args().Len() == 0 {
 		return errors.New(ctx.Command.ArgsUsage)
 	}
@@ -43,18 +51,30 @@ func editIssueState(_ stdctx.Context, cmd *cli.Command, opts gitea.EditIssueOpti
 		return err
 	}
 
-	client := ctx.Login.Client()
-	for _, index := range indices {
-		issue, _, err := client.EditIssue(ctx.Owner, ctx.Repo, index, opts)
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	// setting the state again has no further effect
+	client.SetContext(retry.WithSafeWrites(stdCtx))
+
+	return task.ForEachItem(indices, task.IndexName, func(index int64) error {
+		issue, err := utils.APIResult(client.EditIssue(ctx.Owner, ctx.Repo, index, opts))
 		if err != nil {
 			return err
 		}
+		if dryrun.Enabled() {
+			// the issue returned in dry-run mode is empty
+			return nil
+		}
 
 		if len(indices) > 1 {
-			fmt.Println(issue.HTMLURL)
+			fmt.Fprintln(ctx.Out(), issue.HTMLURL)
 		} else {
-			print.IssueDetails(issue, nil)
+			if err := print.IssueDetails(ctx.Out(), issue, nil, ctx.Output); err != nil {
+				return err
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
@@ -6,7 +6,9 @@ package issues
 import (
 	stdctx "context"
 
+	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/interact"
 	"code.gitea.io/tea/modules/task"
@@ -25,12 +27,17 @@ var CmdIssuesCreate = cli.Command{
 	Flags:       flags.IssuePRCreateFlags,
 }
 
-func runIssuesCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runIssuesCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if ctx.NumFlags() == 0 {
-		err := interact.CreateIssue(ctx.Login, ctx.Owner, ctx.Repo)
+		err := interact.CreateIssue(ctx)
 		if err != nil && !interact.IsQuitting(err) {
 			return err
 		}
@@ -41,11 +48,48 @@ func runIssuesCreate(_ stdctx.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+	if err := applyIssueDefaults(ctx, opts); err != nil {
+		return err
+	}
+	if name := ctx.String("template"); len(name) != 0 {
+		tmpl, err := task.FindIssueTemplate(ctx, name)
+		if err != nil {
+			return err
+		}
+		client, err := ctx.Client()
+		if err != nil {
+			return err
+		}
+		if err := task.ApplyTemplate(client, ctx.Owner, ctx.Repo, tmpl, opts); err != nil {
+			return err
+		}
+	}
 
 	return task.CreateIssue(
-		ctx.Login,
+		ctx,
 		ctx.Owner,
 		ctx.Repo,
 		*opts,
 	)
 }
+
+// applyIssueDefaults sets the labels & assignees configured as flag defaults,
+// unless they were given via flags
+func applyIssueDefaults(ctx *context.TeaContext, opts *gitea.CreateIssueOption) error {
+	defaults := config.GetPreferences().FlagDefaults
+	if !ctx.IsSet("assignees") && len(defaults.IssueAssignees) != 0 {
+		opts.Assignees = defaults.IssueAssignees
+	}
+	if !ctx.IsSet("labels") && len(defaults.IssueLabels) != 0 {
+		client, err := ctx.Client()
+		if err != nil {
+			return err
+		}
+		labels, err := task.ResolveLabelNames(client, ctx.Owner, ctx.Repo, defaults.IssueLabels)
+		if err != nil {
+			return err
+		}
+		opts.Labels = labels
+	}
+	return nil
+}
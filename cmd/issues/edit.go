@@ -10,10 +10,14 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/interact"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/retry"
 	"code.gitea.io/tea/modules/task"
 	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
 )
 
@@ -29,9 +33,14 @@ use an empty string (eg. --milestone "").`,
 	Flags:     flags.IssuePREditFlags,
 }
 
-func runIssuesEdit(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runIssuesEdit(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify at least one issue index")
@@ -47,29 +56,51 @@ func runIssuesEdit(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	client := ctx.Login.Client()
-	for _, opts.Index = range indices {
-		if ctx.NumFlags() == 0 {
-			var err error
-			opts, err = interact.EditIssue(*ctx, opts.Index)
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	// edits set absolute values, so they can be applied again
+	client.SetContext(retry.WithSafeWrites(stdCtx))
+
+	if ctx.NumFlags() == 0 {
+		for _, index := range indices {
+			opts, err := interact.EditIssue(*ctx, index)
 			if err != nil {
 				if interact.IsQuitting(err) {
 					return nil // user quit
 				}
 				return err
 			}
+			if err := editIssue(ctx, client, opts, len(indices) > 1); err != nil {
+				return err
+			}
 		}
+		return nil
+	}
 
-		issue, err := task.EditIssue(ctx, client, *opts)
-		if err != nil {
+	return task.ForEachItem(indices, task.IndexName, func(index int64) error {
+		itemOpts := *opts
+		itemOpts.Index = index
+		return editIssue(ctx, client, &itemOpts, len(indices) > 1)
+	})
+}
+
+func editIssue(ctx *context.TeaContext, client *gitea.Client, opts *task.EditIssueOption, multiple bool) error {
+	issue, err := task.EditIssue(ctx, client, *opts)
+	if err != nil {
+		return err
+	}
+	if dryrun.Enabled() {
+		// the issue returned in dry-run mode is empty
+		return nil
+	}
+	if multiple {
+		fmt.Fprintln(ctx.Out(), issue.HTMLURL)
+	} else {
+		if err := print.IssueDetails(ctx.Out(), issue, nil, ctx.Output); err != nil {
 			return err
 		}
-		if ctx.Args().Len() > 1 {
-			fmt.Println(issue.HTMLURL)
-		} else {
-			print.IssueDetails(issue, nil)
-		}
 	}
-
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/araddon/dateparse"
@@ -33,8 +34,11 @@ var CmdIssuesList = cli.Command{
 }
 
 // RunIssuesList list issues
-func RunIssuesList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
+func RunIssuesList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
 
 	state := gitea.StateOpen
 	switch ctx.String("state") {
@@ -60,7 +64,6 @@ func RunIssuesList(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("unknown kind '%s'", ctx.String("kind"))
 	}
 
-	var err error
 	var from, until time.Time
 	if ctx.IsSet("from") {
 		from, err = dateparse.ParseLocal(ctx.String("from"))
@@ -82,44 +85,24 @@ func RunIssuesList(_ stdctx.Context, cmd *cli.Command) error {
 	// ignore error, as we don't do any input validation on these flags
 	labels, _ := flags.LabelFilterFlag.GetValues(cmd)
 	milestones, _ := flags.MilestoneFilterFlag.GetValues(cmd)
-	var issues []*gitea.Issue
-	if ctx.Repo != "" {
-		issues, _, err = ctx.Login.Client().ListRepoIssues(owner, ctx.Repo, gitea.ListIssueOption{
-			ListOptions: flags.GetListOptions(),
-			State:       state,
-			Type:        kind,
-			KeyWord:     ctx.String("keyword"),
-			CreatedBy:   ctx.String("author"),
-			AssignedBy:  ctx.String("assigned-to"),
-			MentionedBy: ctx.String("mentions"),
-			Labels:      labels,
-			Milestones:  milestones,
-			Since:       from,
-			Before:      until,
-		})
-
-		if err != nil {
-			return err
-		}
-	} else {
-		issues, _, err = ctx.Login.Client().ListIssues(gitea.ListIssueOption{
-			ListOptions: flags.GetListOptions(),
-			State:       state,
-			Type:        kind,
-			KeyWord:     ctx.String("keyword"),
-			CreatedBy:   ctx.String("author"),
-			AssignedBy:  ctx.String("assigned-to"),
-			MentionedBy: ctx.String("mentions"),
-			Labels:      labels,
-			Milestones:  milestones,
-			Since:       from,
-			Before:      until,
-			Owner:       owner,
-		})
-
-		if err != nil {
-			return err
-		}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	issues, err := task.ListIssues(client, owner, ctx.Repo, gitea.ListIssueOption{
+		State:       state,
+		Type:        kind,
+		KeyWord:     ctx.String("keyword"),
+		CreatedBy:   ctx.String("author"),
+		AssignedBy:  ctx.String("assigned-to"),
+		MentionedBy: ctx.String("mentions"),
+		Labels:      labels,
+		Milestones:  milestones,
+		Since:       from,
+		Before:      until,
+	}, flags.GetPaging())
+	if err != nil {
+		return err
 	}
 
 	fields, err := issueFieldsFlag.GetValues(cmd)
@@ -127,6 +110,5 @@ func RunIssuesList(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	print.IssuesPullsList(issues, ctx.Output, fields)
-	return nil
+	return print.IssuesPullsList(ctx.Out(), issues, ctx.Output, fields)
 }
@@ -126,6 +126,7 @@ func TestRunIssueDetailAsJSON(t *testing.T) {
 			URL:  "http://127.0.0.1:8081",
 		},
 		Command: &cmd,
+		Ctx:     t.Context(),
 	}
 
 	testCases := []TestCase{
@@ -12,6 +12,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -45,18 +46,26 @@ var CmdLabelCreate = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runLabelCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runLabelCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	labelFile := ctx.String("file")
-	var err error
 	if len(labelFile) == 0 {
-		_, _, err = ctx.Login.Client().CreateLabel(ctx.Owner, ctx.Repo, gitea.CreateLabelOption{
+		_, err = utils.APIResult(client.CreateLabel(ctx.Owner, ctx.Repo, gitea.CreateLabelOption{
 			Name:        ctx.String("name"),
 			Color:       ctx.String("color"),
 			Description: ctx.String("description"),
-		})
+		}))
 	} else {
 		f, err := os.Open(labelFile)
 		if err != nil {
@@ -72,11 +81,11 @@ func runLabelCreate(_ stdctx.Context, cmd *cli.Command) error {
 			if color == "" || name == "" {
 				log.Printf("Line %d ignored because lack of enough fields: %s\n", i, line)
 			} else {
-				_, _, err = ctx.Login.Client().CreateLabel(ctx.Owner, ctx.Repo, gitea.CreateLabelOption{
+				_, err = utils.APIResult(client.CreateLabel(ctx.Owner, ctx.Repo, gitea.CreateLabelOption{
 					Name:        name,
 					Color:       color,
 					Description: description,
-				})
+				}))
 			}
 
 			i++
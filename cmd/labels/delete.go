@@ -8,6 +8,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -28,10 +29,19 @@ var CmdLabelDelete = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runLabelDelete(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runLabelDelete(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
-	_, err := ctx.Login.Client().DeleteLabel(ctx.Owner, ctx.Repo, ctx.Int64("id"))
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	err = utils.APIError(client.DeleteLabel(ctx.Owner, ctx.Repo, ctx.Int64("id")))
 	return err
 }
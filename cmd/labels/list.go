@@ -31,17 +31,29 @@ var CmdLabelsList = cli.Command{
 		},
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
 // RunLabelsList list labels.
-func RunLabelsList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func RunLabelsList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
-	client := ctx.Login.Client()
-	labels, _, err := client.ListRepoLabels(ctx.Owner, ctx.Repo, gitea.ListLabelsOptions{
-		ListOptions: flags.GetListOptions(),
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	labels, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Label, *gitea.Response, error) {
+		return client.ListRepoLabels(ctx.Owner, ctx.Repo, gitea.ListLabelsOptions{
+			ListOptions: opts,
+		})
 	})
 	if err != nil {
 		return err
@@ -51,6 +63,5 @@ func RunLabelsList(_ stdctx.Context, cmd *cli.Command) error {
 		return task.LabelsExport(labels, ctx.String("save"))
 	}
 
-	print.LabelsList(labels, ctx.Output)
-	return nil
+	return print.LabelsList(ctx.Out(), labels, ctx.Output)
 }
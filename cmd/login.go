@@ -5,7 +5,7 @@ package cmd
 
 import (
 	"context"
-	"fmt"
+	"os"
 
 	"code.gitea.io/tea/cmd/login"
 	"code.gitea.io/tea/modules/config"
@@ -31,6 +31,7 @@ var CmdLogin = cli.Command{
 		&login.CmdLoginSetDefault,
 		&login.CmdLoginHelper,
 		&login.CmdLoginOAuthRefresh,
+		&login.CmdLoginMigrateSecrets,
 	},
 }
 
@@ -42,12 +43,11 @@ func runLogins(ctx context.Context, cmd *cli.Command) error {
 }
 
 func runLoginDetail(name string) error {
-	l := config.GetLoginByName(name)
-	if l == nil {
-		fmt.Printf("Login '%s' do not exist\n\n", name)
-		return nil
+	l, err := config.GetLoginByName(name)
+	if err != nil {
+		return err
 	}
 
-	print.LoginDetails(l)
+	print.LoginDetails(os.Stdout, l)
 	return nil
 }
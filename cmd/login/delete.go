@@ -6,7 +6,6 @@ package login
 import (
 	"context"
 	"errors"
-	"log"
 
 	"code.gitea.io/tea/modules/config"
 
@@ -27,7 +26,7 @@ var CmdLoginDelete = cli.Command{
 func RunLoginDelete(_ context.Context, cmd *cli.Command) error {
 	logins, err := config.GetLogins()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	var name string
@@ -7,7 +7,6 @@ import (
 	"bufio"
 	"context"
 	"fmt"
-	"log"
 	"net/url"
 	"os"
 	"strings"
@@ -16,6 +15,7 @@ import (
 	"code.gitea.io/tea/modules/auth"
 	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
 	"github.com/urfave/cli/v3"
 )
 
@@ -59,7 +59,7 @@ var CmdLoginHelper = cli.Command{
 		{
 			Name:        "get",
 			Description: "Get token to auth",
-			Action: func(_ context.Context, cmd *cli.Command) error {
+			Action: func(ctx context.Context, cmd *cli.Command) error {
 				wants := map[string]string{}
 				s := bufio.NewScanner(os.Stdin)
 				for s.Scan() {
@@ -88,16 +88,17 @@ var CmdLoginHelper = cli.Command{
 				}
 
 				if len(wants["host"]) == 0 {
-					log.Fatal("Require hostname")
+					return utils.NewValidationErrorf("Require hostname")
 				} else if len(wants["protocol"]) == 0 {
 					wants["protocol"] = "http"
 				}
 
-				userConfig := config.GetLoginByHost(wants["host"])
-				if userConfig == nil {
-					log.Fatal("host not exists")
-				} else if len(userConfig.Token) == 0 {
-					log.Fatal("User no set")
+				userConfig, err := config.GetLoginByHost(wants["host"])
+				if err != nil {
+					return err
+				}
+				if len(userConfig.Token) == 0 {
+					return utils.NewMissingLoginErrorf("User no set")
 				}
 
 				host, err := url.Parse(userConfig.URL)
@@ -107,16 +108,17 @@ var CmdLoginHelper = cli.Command{
 
 				if userConfig.TokenExpiry > 0 && time.Now().Unix() > userConfig.TokenExpiry {
 					// Token is expired, refresh it
-					err = auth.RefreshAccessToken(userConfig)
+					err = auth.RefreshAccessToken(ctx, userConfig)
 					if err != nil {
 						return err
 					}
 
 					// Once token is refreshed, get the latest from the updated config
-					refreshedConfig := config.GetLoginByHost(wants["host"])
-					if refreshedConfig != nil {
-						userConfig = refreshedConfig
+					refreshedConfig, err := config.GetLoginByHost(wants["host"])
+					if err != nil {
+						return err
 					}
+					userConfig = refreshedConfig
 				}
 
 				_, err = fmt.Fprintf(os.Stdout, "protocol=%s\nhost=%s\nusername=%s\npassword=%s\n", host.Scheme, host.Host, userConfig.User, userConfig.Token)
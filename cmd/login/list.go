@@ -5,6 +5,7 @@ package login
 
 import (
 	"context"
+	"os"
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/config"
@@ -30,6 +31,5 @@ func RunLoginList(_ context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	print.LoginsList(logins, cmd.String("output"))
-	return nil
+	return print.LoginsList(os.Stdout, logins, cmd.String("output"))
 }
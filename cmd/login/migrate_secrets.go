@@ -0,0 +1,64 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package login
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/tea/modules/config"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdLoginMigrateSecrets represents a command to move login tokens into a secret store
+var CmdLoginMigrateSecrets = cli.Command{
+	Name:  "migrate-secrets",
+	Usage: "Move login tokens out of the config file into a secret store",
+	Description: `Move the token & refresh token of logins into a secret store, so the config file
+only holds a reference to them. Without login names, all logins are migrated and
+the store becomes the default for new logins.
+
+Secret stores:
+  keyring  OS keyring (Secret Service, macOS keychain, Windows credential manager)
+  file     age encrypted file next to the config file, protected by a passphrase
+           that is prompted for or read from $TEA_SECRETS_PASSPHRASE
+  command  external program set as preferences.credential_command in the config,
+           run as '<command> get|store|erase' with $TEA_LOGIN and $TEA_LOGIN_URL set.
+           'store' reads a JSON object {"token": ..., "refresh_token": ...} from stdin,
+           'get' prints such an object or just the token.
+  config   plain text in the config file`,
+	ArgsUsage: "[<login name>...]",
+	Action:    runLoginMigrateSecrets,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "store",
+			Value: config.SecretStoreKeyring,
+			Usage: "Secret store to move tokens to: " + strings.Join(config.SecretStores, ", "),
+			Validator: func(s string) error {
+				if !slices.Contains(config.SecretStores, s) {
+					return fmt.Errorf("unknown secret store '%s', available: %s", s, strings.Join(config.SecretStores, ", "))
+				}
+				return nil
+			},
+		},
+	},
+}
+
+func runLoginMigrateSecrets(_ context.Context, cmd *cli.Command) error {
+	store := cmd.String("store")
+	migrated, err := config.MigrateSecrets(store, cmd.Args().Slice()...)
+	for _, name := range migrated {
+		fmt.Printf("Moved token of login '%s' to %s\n", name, store)
+	}
+	if err != nil {
+		return err
+	}
+	if len(migrated) == 0 {
+		fmt.Printf("All logins already use %s\n", store)
+	}
+	return nil
+}
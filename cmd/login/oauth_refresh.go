@@ -22,7 +22,7 @@ var CmdLoginOAuthRefresh = cli.Command{
 	Action:      runLoginOAuthRefresh,
 }
 
-func runLoginOAuthRefresh(_ context.Context, cmd *cli.Command) error {
+func runLoginOAuthRefresh(ctx context.Context, cmd *cli.Command) error {
 	var loginName string
 
 	// Get login name from args or use default
@@ -38,9 +38,9 @@ func runLoginOAuthRefresh(_ context.Context, cmd *cli.Command) error {
 	}
 
 	// Get the login from config
-	login := config.GetLoginByName(loginName)
-	if login == nil {
-		return fmt.Errorf("login '%s' not found", loginName)
+	login, err := config.GetLoginByName(loginName)
+	if err != nil {
+		return err
 	}
 
 	// Check if the login has a refresh token
@@ -49,7 +49,7 @@ func runLoginOAuthRefresh(_ context.Context, cmd *cli.Command) error {
 	}
 
 	// Refresh the token
-	err := auth.RefreshAccessToken(login)
+	err = auth.RefreshAccessToken(ctx, login)
 	if err != nil {
 		return fmt.Errorf("failed to refresh token: %s", err)
 	}
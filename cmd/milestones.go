@@ -9,6 +9,7 @@ import (
 	"code.gitea.io/tea/cmd/milestones"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 	"github.com/urfave/cli/v3"
 )
 
@@ -39,16 +40,24 @@ func runMilestones(ctx stdctx.Context, cmd *cli.Command) error {
 	return milestones.RunMilestonesList(ctx, cmd)
 }
 
-func runMilestoneDetail(_ stdctx.Context, cmd *cli.Command, name string) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runMilestoneDetail(stdCtx stdctx.Context, cmd *cli.Command, name string) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
-	milestone, _, err := client.GetMilestoneByName(ctx.Owner, ctx.Repo, name)
+	milestone, err := utils.APIResult(client.GetMilestoneByName(ctx.Owner, ctx.Repo, name))
 	if err != nil {
 		return err
 	}
 
-	print.MilestoneDetails(milestone)
+	print.MilestoneDetails(ctx.Out(), milestone)
 	return nil
 }
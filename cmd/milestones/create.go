@@ -49,8 +49,11 @@ var CmdMilestonesCreate = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runMilestonesCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
+func runMilestonesCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
 
 	date := ctx.String("deadline")
 	deadline := &time.Time{}
@@ -68,14 +71,14 @@ func runMilestonesCreate(_ stdctx.Context, cmd *cli.Command) error {
 	}
 
 	if ctx.NumFlags() == 0 {
-		if err := interact.CreateMilestone(ctx.Login, ctx.Owner, ctx.Repo); err != nil && !interact.IsQuitting(err) {
+		if err := interact.CreateMilestone(ctx); err != nil && !interact.IsQuitting(err) {
 			return err
 		}
 		return nil
 	}
 
 	return task.CreateMilestone(
-		ctx.Login,
+		ctx,
 		ctx.Owner,
 		ctx.Repo,
 		ctx.String("title"),
@@ -44,6 +44,8 @@ var CmdMilestonesIssues = cli.Command{
 		},
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 		msIssuesFieldsFlag,
 	}, flags.AllDefaultFlags...),
 }
@@ -70,10 +72,18 @@ var CmdMilestoneRemoveIssue = cli.Command{
 	Flags:       flags.AllDefaultFlags,
 }
 
-func runMilestoneIssueList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runMilestoneIssueList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	state := gitea.StateOpen
 	switch ctx.String("state") {
@@ -97,16 +107,18 @@ func runMilestoneIssueList(_ stdctx.Context, cmd *cli.Command) error {
 
 	milestone := ctx.Args().First()
 	// make sure milestone exist
-	_, _, err := client.GetMilestoneByName(ctx.Owner, ctx.Repo, milestone)
+	_, err = utils.APIResult(client.GetMilestoneByName(ctx.Owner, ctx.Repo, milestone))
 	if err != nil {
 		return err
 	}
 
-	issues, _, err := client.ListRepoIssues(ctx.Owner, ctx.Repo, gitea.ListIssueOption{
-		ListOptions: flags.GetListOptions(),
-		Milestones:  []string{milestone},
-		Type:        kind,
-		State:       state,
+	issues, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Issue, *gitea.Response, error) {
+		return client.ListRepoIssues(ctx.Owner, ctx.Repo, gitea.ListIssueOption{
+			ListOptions: opts,
+			Milestones:  []string{milestone},
+			Type:        kind,
+			State:       state,
+		})
 	})
 	if err != nil {
 		return err
@@ -116,14 +128,21 @@ func runMilestoneIssueList(_ stdctx.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	print.IssuesPullsList(issues, ctx.Output, fields)
-	return nil
+	return print.IssuesPullsList(ctx.Out(), issues, ctx.Output, fields)
 }
 
-func runMilestoneIssueAdd(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runMilestoneIssueAdd(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 	if ctx.Args().Len() != 2 {
 		return fmt.Errorf("need two arguments")
 	}
@@ -136,21 +155,29 @@ func runMilestoneIssueAdd(_ stdctx.Context, cmd *cli.Command) error {
 	}
 
 	// make sure milestone exist
-	mile, _, err := client.GetMilestoneByName(ctx.Owner, ctx.Repo, mileName)
+	mile, err := utils.APIResult(client.GetMilestoneByName(ctx.Owner, ctx.Repo, mileName))
 	if err != nil {
 		return err
 	}
 
-	_, _, err = client.EditIssue(ctx.Owner, ctx.Repo, idx, gitea.EditIssueOption{
+	_, err = utils.APIResult(client.EditIssue(ctx.Owner, ctx.Repo, idx, gitea.EditIssueOption{
 		Milestone: &mile.ID,
-	})
+	}))
 	return err
 }
 
-func runMilestoneIssueRemove(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runMilestoneIssueRemove(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 	if ctx.Args().Len() != 2 {
 		return fmt.Errorf("need two arguments")
 	}
@@ -162,7 +189,7 @@ func runMilestoneIssueRemove(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	issue, _, err := client.GetIssue(ctx.Owner, ctx.Repo, idx)
+	issue, err := utils.APIResult(client.GetIssue(ctx.Owner, ctx.Repo, idx))
 	if err != nil {
 		return err
 	}
@@ -176,8 +203,8 @@ func runMilestoneIssueRemove(_ stdctx.Context, cmd *cli.Command) error {
 	}
 
 	zero := int64(0)
-	_, _, err = client.EditIssue(ctx.Owner, ctx.Repo, idx, gitea.EditIssueOption{
+	_, err = utils.APIResult(client.EditIssue(ctx.Owner, ctx.Repo, idx, gitea.EditIssueOption{
 		Milestone: &zero,
-	})
+	}))
 	return err
 }
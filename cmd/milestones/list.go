@@ -35,13 +35,20 @@ var CmdMilestonesList = cli.Command{
 		},
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
 // RunMilestonesList list milestones
-func RunMilestonesList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func RunMilestonesList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	fields, err := fieldsFlag.GetValues(cmd)
 	if err != nil {
@@ -59,16 +66,20 @@ func RunMilestonesList(_ stdctx.Context, cmd *cli.Command) error {
 		state = gitea.StateClosed
 	}
 
-	client := ctx.Login.Client()
-	milestones, _, err := client.ListRepoMilestones(ctx.Owner, ctx.Repo, gitea.ListMilestoneOption{
-		ListOptions: flags.GetListOptions(),
-		State:       state,
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	milestones, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Milestone, *gitea.Response, error) {
+		return client.ListRepoMilestones(ctx.Owner, ctx.Repo, gitea.ListMilestoneOption{
+			ListOptions: opts,
+			State:       state,
+		})
 	})
 
 	if err != nil {
 		return err
 	}
 
-	print.MilestonesList(milestones, ctx.Output, fields)
-	return nil
+	return print.MilestonesList(ctx.Out(), milestones, ctx.Output, fields)
 }
@@ -11,6 +11,9 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/retry"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -29,9 +32,14 @@ var CmdMilestonesReopen = cli.Command{
 	Flags: flags.AllDefaultFlags,
 }
 
-func editMilestoneStatus(_ stdctx.Context, cmd *cli.Command, close bool) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func editMilestoneStatus(stdCtx stdctx.Context, cmd *cli.Command, close bool) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 	if ctx.Args().Len() == 0 {
 		return errors.New(ctx.Command.ArgsUsage)
 	}
@@ -41,22 +49,28 @@ func editMilestoneStatus(_ stdctx.Context, cmd *cli.Command, close bool) error {
 		state = gitea.StateClosed
 	}
 
-	client := ctx.Login.Client()
-	for _, ms := range ctx.Args().Slice() {
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	// setting the state again has no further effect
+	client.SetContext(retry.WithSafeWrites(stdCtx))
+
+	return task.ForEachItem(ctx.Args().Slice(), task.ArgName, func(ms string) error {
 		opts := gitea.EditMilestoneOption{
 			State: &state,
 			Title: ms,
 		}
-		milestone, _, err := client.EditMilestoneByName(ctx.Owner, ctx.Repo, ms, opts)
+		milestone, err := utils.APIResult(client.EditMilestoneByName(ctx.Owner, ctx.Repo, ms, opts))
 		if err != nil {
 			return err
 		}
 
 		if ctx.Args().Len() > 1 {
-			fmt.Printf("%s/milestone/%d\n", ctx.GetRemoteRepoHTMLURL(), milestone.ID)
+			fmt.Fprintf(ctx.Out(), "%s/milestone/%d\n", ctx.GetRemoteRepoHTMLURL(), milestone.ID)
 		} else {
-			print.MilestoneDetails(milestone)
+			print.MilestoneDetails(ctx.Out(), milestone)
 		}
-	}
-	return nil
+		return nil
+	})
 }
@@ -0,0 +1,159 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/cmd/pulls"
+	teacontext "code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
+)
+
+// reposFlag selects multiple repositories to run a command for
+var reposFlag = cli.StringSliceFlag{
+	Name: "repos",
+	Usage: "Run the command for each of the given repositories: owner/repo, an owner/<glob> " +
+		"matching the (non-archived) repositories of an organization or user, e.g. 'org/*', or @file listing one per line",
+}
+
+// concurrencyFlag limits the number of repositories a command runs for in parallel
+var concurrencyFlag = cli.IntFlag{
+	Name:  "concurrency",
+	Usage: "Number of repositories to run the command for in parallel, used with --repos",
+	Value: 4,
+}
+
+// singleRepoCommands work with the local checkout of a repository, so they can't run for multiple repositories
+var singleRepoCommands = []*cli.Command{
+	&pulls.CmdPullsCheckout,
+	&pulls.CmdPullsClean,
+	&CmdOpen,
+	&CmdRepoClone,
+}
+
+// addReposFlag adds --repos to the commands accepting --repo, so they can
+// run for multiple repositories in one invocation
+func addReposFlag(cmds []*cli.Command) {
+	for _, c := range cmds {
+		addReposFlag(c.Commands)
+		if c.Action == nil || slices.Contains(singleRepoCommands, c) ||
+			!slices.Contains(c.Flags, cli.Flag(&flags.RepoFlag)) ||
+			slices.Contains(c.Flags, cli.Flag(&reposFlag)) {
+			continue
+		}
+		// the flags may be shared with other commands, so they must not be modified in place
+		c.Flags = append(slices.Clip(c.Flags), &reposFlag, &concurrencyFlag)
+		c.Action = reposAction(c.Action)
+	}
+}
+
+// reposAction wraps the action of a command, to run it for each repository selected via --repos
+func reposAction(action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx context.Context, cmd *cli.Command) error {
+		if cmd.IsSet(reposFlag.Name) {
+			return runForRepos(ctx, cmd, action)
+		}
+		return action(ctx, cmd)
+	}
+}
+
+// runForRepos runs the action for each selected repository, and prints their
+// output one after another, with their tables merged into one. The login & client are resolved once, and shared by
+// the runs for all repositories.
+func runForRepos(ctx context.Context, cmd *cli.Command, action cli.ActionFunc) error {
+	if cmd.IsSet(flags.RepoFlag.Name) {
+		return utils.NewValidationErrorf("--repo and --repos can't be combined")
+	}
+	concurrency := cmd.Int(concurrencyFlag.Name)
+	if concurrency < 1 {
+		return utils.NewValidationErrorf("--concurrency must be at least 1")
+	}
+
+	teaCtx, err := teacontext.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := teaCtx.Client()
+	if err != nil {
+		return err
+	}
+	repos, err := task.ResolveRepos(client, cmd.StringSlice(reposFlag.Name))
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		return utils.NewNotFoundErrorf("no repositories match %s", strings.Join(cmd.StringSlice(reposFlag.Name), ", "))
+	}
+
+	collectors := make([]*print.Collector, len(repos))
+	errs := make([]error, len(repos))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		collectors[i] = print.NewCollector(repo)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			repoCtx := teacontext.WithRepo(ctx, teaCtx, client, repo, collectors[i])
+			errs[i] = action(repoCtx, cmd)
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// the output buffered for each repository is printed in the order of the repositories
+	out := cmd.Root().Writer
+	for _, c := range collectors {
+		if _, err := c.WriteTo(out); err != nil {
+			return err
+		}
+	}
+	if err := print.ReposTable(out, collectors, teaCtx.Output); err != nil {
+		return err
+	}
+	return reposSummary(repos, errs)
+}
+
+// reposSummary reports the result of running a command for each repository on stderr,
+// in the format of task.ForEachItem, but also listing the succeeded repositories if
+// none failed
+func reposSummary(repos []string, errs []error) error {
+	var succeeded []string
+	batchErr := &task.BatchError{Total: len(repos)}
+	for i, repo := range repos {
+		if errs[i] == nil || errors.Is(errs[i], dryrun.ErrSkipped) {
+			succeeded = append(succeeded, repo)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", repo, errs[i])
+		batchErr.Failed = append(batchErr.Failed, repo)
+		batchErr.Errs = append(batchErr.Errs, errs[i])
+	}
+	if len(succeeded) != 0 {
+		fmt.Fprintf(os.Stderr, "Succeeded: %s\n", strings.Join(succeeded, ", "))
+	}
+	if len(batchErr.Failed) == 0 {
+		return nil
+	}
+	return batchErr
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"code.gitea.io/tea/cmd/flags"
+	teacontext "code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestRunForRepos(t *testing.T) {
+	t.Cleanup(xdg.Reload)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+	t.Setenv("GITEA_INSTANCE_URL", "https://gitea.example.com")
+	t.Setenv("GITEA_TOKEN", "8fe2e3b0c44298fc1c149afbf4c8996fb92427ae")
+
+	var mu sync.Mutex
+	var ran []string
+	clients := map[*gitea.Client]bool{}
+	app := &cli.Command{
+		Name:  "tea",
+		Flags: []cli.Flag{&flags.RepoFlag, &flags.LoginFlag, &flags.OutputFlag},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			c, err := teacontext.InitCommand(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			client, err := c.Client()
+			if err != nil {
+				return err
+			}
+			// the output of each repository is printed in one piece
+			fmt.Fprint(c.Out(), c.RepoSlug)
+			fmt.Fprintln(c.Out(), " done")
+			mu.Lock()
+			ran = append(ran, c.RepoSlug)
+			clients[client] = true
+			mu.Unlock()
+			if c.Repo == "broken" {
+				return utils.NewNotFoundErrorf("gone")
+			}
+			return nil
+		},
+	}
+	addReposFlag([]*cli.Command{app})
+	out := &bytes.Buffer{}
+	app.Writer = out
+
+	err := app.Run(t.Context(), []string{"tea", "--repos", "o/a,o/broken,o/b", "--concurrency", "2"})
+	var batchErr *task.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Equal(t, []string{"o/broken"}, batchErr.Failed)
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+
+	slices.Sort(ran)
+	assert.Equal(t, []string{"o/a", "o/b", "o/broken"}, ran)
+	// the client is created once, and shared by the runs for all repositories
+	assert.Len(t, clients, 1)
+	assert.Equal(t, "o/a done\no/broken done\no/b done\n", out.String())
+
+	assert.Error(t, app.Run(t.Context(), []string{"tea", "--repos", "o/a", "--repo", "o/b"}))
+}
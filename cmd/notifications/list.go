@@ -5,7 +5,6 @@ package notifications
 
 import (
 	stdctx "context"
-	"log"
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
@@ -60,19 +59,19 @@ func RunNotificationsList(ctx stdctx.Context, cmd *cli.Command) error {
 }
 
 // listNotifications will get the notifications based on status and subject type
-func listNotifications(_ stdctx.Context, cmd *cli.Command, status []gitea.NotifyStatus, subjects []gitea.NotifySubjectType) error {
+func listNotifications(stdCtx stdctx.Context, cmd *cli.Command, status []gitea.NotifyStatus, subjects []gitea.NotifySubjectType) error {
 	var news []*gitea.NotificationThread
 	var err error
 
-	ctx := context.InitCommand(cmd)
-	client := ctx.Login.Client()
-	all := ctx.Bool("mine")
-
-	// This enforces pagination (see https://github.com/go-gitea/gitea/issues/16733)
-	listOpts := flags.GetListOptions()
-	if listOpts.Page == 0 {
-		listOpts.Page = 1
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
 	}
+	all := ctx.Bool("mine")
 
 	fields, err := notifyFieldsFlag.GetValues(cmd)
 	if err != nil {
@@ -85,23 +84,28 @@ func listNotifications(_ stdctx.Context, cmd *cli.Command, status []gitea.Notify
 			fields = append(fields, "repository")
 		}
 
-		news, _, err = client.ListNotifications(gitea.ListNotificationOptions{
-			ListOptions:  listOpts,
-			Status:       status,
-			SubjectTypes: subjects,
+		news, err = flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.NotificationThread, *gitea.Response, error) {
+			return client.ListNotifications(gitea.ListNotificationOptions{
+				ListOptions:  opts,
+				Status:       status,
+				SubjectTypes: subjects,
+			})
 		})
 	} else {
-		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-		news, _, err = client.ListRepoNotifications(ctx.Owner, ctx.Repo, gitea.ListNotificationOptions{
-			ListOptions:  listOpts,
-			Status:       status,
-			SubjectTypes: subjects,
+		if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+			return err
+		}
+		news, err = flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.NotificationThread, *gitea.Response, error) {
+			return client.ListRepoNotifications(ctx.Owner, ctx.Repo, gitea.ListNotificationOptions{
+				ListOptions:  opts,
+				Status:       status,
+				SubjectTypes: subjects,
+			})
 		})
 	}
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
-	print.NotificationsList(news, ctx.Output, fields)
-	return nil
+	return print.NotificationsList(ctx.Out(), news, ctx.Output, fields)
 }
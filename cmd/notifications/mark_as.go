@@ -22,8 +22,11 @@ var CmdNotificationsMarkRead = cli.Command{
 	Description: "Mark all filtered or a specific notification as read",
 	ArgsUsage:   "[all | <notification id>]",
 	Flags:       flags.NotificationFlags,
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
 		filter, err := flags.NotificationStateFlag.GetValues(cmd)
 		if err != nil {
 			return err
@@ -43,8 +46,11 @@ var CmdNotificationsMarkUnread = cli.Command{
 	Description: "Mark all filtered or a specific notification as unread",
 	ArgsUsage:   "[all | <notification id>]",
 	Flags:       flags.NotificationFlags,
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
 		filter, err := flags.NotificationStateFlag.GetValues(cmd)
 		if err != nil {
 			return err
@@ -64,8 +70,11 @@ var CmdNotificationsMarkPinned = cli.Command{
 	Description: "Mark all filtered or a specific notification as pinned",
 	ArgsUsage:   "[all | <notification id>]",
 	Flags:       flags.NotificationFlags,
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
 		filter, err := flags.NotificationStateFlag.GetValues(cmd)
 		if err != nil {
 			return err
@@ -84,8 +93,11 @@ var CmdNotificationsUnpin = cli.Command{
 	Description: "Marks all pinned or a specific notification as read",
 	ArgsUsage:   "[all | <notification id>]",
 	Flags:       flags.NotificationFlags,
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
 		filter := []string{string(gitea.NotifyStatusPinned)}
 		// NOTE: we implicitly mark it as read, to match web UI semantics. marking as unread might be more useful?
 		return markNotificationAs(ctx, filter, gitea.NotifyStatusRead)
@@ -93,7 +105,10 @@ var CmdNotificationsUnpin = cli.Command{
 }
 
 func markNotificationAs(cmd *context.TeaContext, filterStates []string, targetState gitea.NotifyStatus) (err error) {
-	client := cmd.Login.Client()
+	client, err := cmd.Client()
+	if err != nil {
+		return err
+	}
 	subject := cmd.Args().First()
 	allRepos := cmd.Bool("mine")
 
@@ -107,10 +122,12 @@ func markNotificationAs(cmd *context.TeaContext, filterStates []string, targetSt
 		opts := gitea.MarkNotificationOptions{Status: states, ToStatus: targetState}
 
 		if allRepos {
-			_, _, err = client.ReadNotifications(opts)
+			_, err = utils.APIResult(client.ReadNotifications(opts))
 		} else {
-			cmd.Ensure(context.CtxRequirement{RemoteRepo: true})
-			_, _, err = client.ReadRepoNotifications(cmd.Owner, cmd.Repo, opts)
+			if err := cmd.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+				return err
+			}
+			_, err = utils.APIResult(client.ReadRepoNotifications(cmd.Owner, cmd.Repo, opts))
 		}
 
 		// TODO: print all affected notification subject URLs
@@ -121,17 +138,17 @@ func markNotificationAs(cmd *context.TeaContext, filterStates []string, targetSt
 		if err != nil {
 			return err
 		}
-		_, _, err = client.ReadNotification(id, targetState)
+		_, err = utils.APIResult(client.ReadNotification(id, targetState))
 		if err != nil {
 			return err
 		}
 
-		n, _, err := client.GetNotification(id)
+		n, err := utils.APIResult(client.GetNotification(id))
 		if err != nil {
 			return err
 		}
 		// FIXME: this is an API URL, we want to display a web ui link..
-		fmt.Println(n.Subject.URL)
+		fmt.Fprintln(cmd.Out(), n.Subject.URL)
 		return nil
 	}
 
@@ -9,6 +9,7 @@ import (
 	"code.gitea.io/tea/cmd/organizations"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -31,7 +32,10 @@ var CmdOrgs = cli.Command{
 }
 
 func runOrganizations(ctx stdctx.Context, cmd *cli.Command) error {
-	teaCtx := context.InitCommand(cmd)
+	teaCtx, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
 	if teaCtx.Args().Len() == 1 {
 		return runOrganizationDetail(teaCtx)
 	}
@@ -39,11 +43,15 @@ func runOrganizations(ctx stdctx.Context, cmd *cli.Command) error {
 }
 
 func runOrganizationDetail(ctx *context.TeaContext) error {
-	org, _, err := ctx.Login.Client().GetOrg(ctx.Args().First())
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	org, err := utils.APIResult(client.GetOrg(ctx.Args().First()))
 	if err != nil {
 		return err
 	}
 
-	print.OrganizationDetails(org)
+	print.OrganizationDetails(ctx.Out(), org)
 	return nil
 }
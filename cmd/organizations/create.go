@@ -12,6 +12,7 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 	"github.com/urfave/cli/v3"
 )
 
@@ -52,8 +53,11 @@ var CmdOrganizationCreate = cli.Command{
 }
 
 // RunOrganizationCreate sets up a new organization
-func RunOrganizationCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
+func RunOrganizationCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
 
 	if ctx.Args().Len() < 1 {
 		return fmt.Errorf("You have to specify the organization name you want to create")
@@ -71,7 +75,11 @@ func RunOrganizationCreate(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("unknown visibility '%s'", ctx.String("visibility"))
 	}
 
-	org, _, err := ctx.Login.Client().CreateOrg(gitea.CreateOrgOption{
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	org, err := utils.APIResult(client.CreateOrg(gitea.CreateOrgOption{
 		Name: ctx.Args().First(),
 		// FullName: , // not really meaningful for orgs (not displayed in webui, use description instead?)
 		Description:               ctx.String("description"),
@@ -79,12 +87,12 @@ func RunOrganizationCreate(_ stdctx.Context, cmd *cli.Command) error {
 		Location:                  ctx.String("location"),
 		RepoAdminChangeTeamAccess: ctx.Bool("repo-admins-can-change-team-access"),
 		Visibility:                visibility,
-	})
+	}))
 	if err != nil {
 		return err
 	}
 
-	print.OrganizationDetails(org)
+	print.OrganizationDetails(ctx.Out(), org)
 
 	return err
 }
@@ -9,6 +9,8 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
+
 	"github.com/urfave/cli/v3"
 )
 
@@ -27,10 +29,16 @@ var CmdOrganizationDelete = cli.Command{
 }
 
 // RunOrganizationDelete delete user organization
-func RunOrganizationDelete(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
+func RunOrganizationDelete(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if ctx.Args().Len() < 1 {
 		return fmt.Errorf("You have to specify the organization name you want to delete")
@@ -41,5 +49,5 @@ func RunOrganizationDelete(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("The given organization does not exist")
 	}
 
-	return err
+	return utils.APIError(response, err)
 }
@@ -24,22 +24,30 @@ var CmdOrganizationList = cli.Command{
 	Flags: append([]cli.Flag{
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
 // RunOrganizationList list user organizations
-func RunOrganizationList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	client := ctx.Login.Client()
+func RunOrganizationList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
-	userOrganizations, _, err := client.ListUserOrgs(ctx.Login.User, gitea.ListOrgsOptions{
-		ListOptions: flags.GetListOptions(),
+	userOrganizations, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Organization, *gitea.Response, error) {
+		return client.ListUserOrgs(ctx.Login.User, gitea.ListOrgsOptions{
+			ListOptions: opts,
+		})
 	})
 	if err != nil {
 		return err
 	}
 
-	print.OrganizationsList(userOrganizations, ctx.Output)
-
-	return nil
+	return print.OrganizationsList(ctx.Out(), userOrganizations, ctx.Output)
 }
@@ -36,14 +36,21 @@ var CmdPulls = cli.Command{
 	Commands: []*cli.Command{
 		&pulls.CmdPullsList,
 		&pulls.CmdPullsCheckout,
+		&pulls.CmdPullsWorktrees,
+		&pulls.CmdPullsDiff,
+		&pulls.CmdPullsFiles,
+		&pulls.CmdPullsChecks,
 		&pulls.CmdPullsClean,
 		&pulls.CmdPullsCreate,
 		&pulls.CmdPullsClose,
 		&pulls.CmdPullsReopen,
 		&pulls.CmdPullsReview,
+		&pulls.CmdPullsReviews,
+		&pulls.CmdPullsReviewComments,
 		&pulls.CmdPullsApprove,
 		&pulls.CmdPullsReject,
 		&pulls.CmdPullsMerge,
+		&pulls.CmdPullsUpdate,
 	},
 }
 
@@ -54,16 +61,24 @@ func runPulls(ctx stdctx.Context, cmd *cli.Command) error {
 	return pulls.RunPullsList(ctx, cmd)
 }
 
-func runPullDetail(_ stdctx.Context, cmd *cli.Command, index string) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runPullDetail(stdCtx stdctx.Context, cmd *cli.Command, index string) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 	idx, err := utils.ArgToIndex(index)
 	if err != nil {
 		return err
 	}
 
-	client := ctx.Login.Client()
-	pr, _, err := client.GetPullRequest(ctx.Owner, ctx.Repo, idx)
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	pr, err := utils.APIResult(client.GetPullRequest(ctx.Owner, ctx.Repo, idx))
 	if err != nil {
 		return err
 	}
@@ -71,24 +86,26 @@ func runPullDetail(_ stdctx.Context, cmd *cli.Command, index string) error {
 		return err
 	}
 
-	reviews, _, err := client.ListPullReviews(ctx.Owner, ctx.Repo, idx, gitea.ListPullReviewsOptions{
+	reviews, err := utils.APIResult(client.ListPullReviews(ctx.Owner, ctx.Repo, idx, gitea.ListPullReviewsOptions{
 		ListOptions: gitea.ListOptions{Page: -1},
-	})
+	}))
 	if err != nil {
-		fmt.Printf("error while loading reviews: %v\n", err)
+		fmt.Fprintf(ctx.Out(), "error while loading reviews: %v\n", err)
 	}
 
-	ci, _, err := client.GetCombinedStatus(ctx.Owner, ctx.Repo, pr.Head.Sha)
+	ci, err := utils.APIResult(client.GetCombinedStatus(ctx.Owner, ctx.Repo, pr.Head.Sha))
 	if err != nil {
-		fmt.Printf("error while loading CI: %v\n", err)
+		fmt.Fprintf(ctx.Out(), "error while loading CI: %v\n", err)
 	}
 
-	print.PullDetails(pr, reviews, ci)
+	if err := print.PullDetails(ctx.Out(), pr, reviews, ci, ctx.Output); err != nil {
+		return err
+	}
 
-	if pr.Comments > 0 {
+	if pr.Comments > 0 && !print.IsQueryOutput(ctx.Output) {
 		err = interact.ShowCommentsMaybeInteractive(ctx, idx, pr.Comments)
 		if err != nil {
-			fmt.Printf("error loading comments: %v\n", err)
+			fmt.Fprintf(ctx.Out(), "error loading comments: %v\n", err)
 		}
 	}
 
@@ -24,9 +24,14 @@ var CmdPullsApprove = cli.Command{
 	Usage:       "Approve a pull request",
 	Description: "Approve a pull request",
 	ArgsUsage:   "<pull index> [<comment>]",
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
-		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+			return err
+		}
 
 		if ctx.Args().Len() == 0 {
 			return fmt.Errorf("Must specify a PR index")
@@ -39,7 +44,8 @@ var CmdPullsApprove = cli.Command{
 
 		comment := strings.Join(ctx.Args().Tail(), " ")
 
-		return task.CreatePullReview(ctx, idx, gitea.ReviewStateApproved, comment, nil)
+		_, err = task.CreatePullReview(ctx, idx, gitea.ReviewStateApproved, comment, nil)
+		return err
 	},
 	Flags: flags.AllDefaultFlags,
 }
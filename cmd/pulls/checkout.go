@@ -18,28 +18,42 @@ import (
 
 // CmdPullsCheckout is a command to locally checkout the given PR
 var CmdPullsCheckout = cli.Command{
-	Name:        "checkout",
-	Aliases:     []string{"co"},
-	Usage:       "Locally check out the given PR",
-	Description: `Locally check out the given PR`,
-	Action:      runPullsCheckout,
-	ArgsUsage:   "<pull index>",
+	Name:    "checkout",
+	Aliases: []string{"co"},
+	Usage:   "Locally check out the given PR",
+	Description: `Locally check out the given PR.
+
+With --worktree, the PR is checked out on the branch pulls/<index> in a new linked worktree,
+leaving the current one untouched. The worktree is added at the given path, or in the
+directory set via worktree_dir in the config, which defaults to ../<repo dir>.worktrees`,
+	Action:    runPullsCheckout,
+	ArgsUsage: "<pull index> [<worktree path>]",
 	Flags: append([]cli.Flag{
 		&cli.BoolFlag{
 			Name:    "branch",
 			Aliases: []string{"b"},
 			Usage:   "Create a local branch if it doesn't exist yet",
 		},
+		&cli.BoolFlag{
+			Name:    "worktree",
+			Aliases: []string{"w"},
+			Usage:   "Check out the PR in a new linked worktree instead of the current one",
+		},
 	}, flags.AllDefaultFlags...),
 }
 
-func runPullsCheckout(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{
+func runPullsCheckout(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{
 		LocalRepo:  true,
 		RemoteRepo: true,
-	})
-	if ctx.Args().Len() != 1 {
+	}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() < 1 || ctx.Args().Len() > 2 {
 		return fmt.Errorf("Must specify a PR index")
 	}
 	idx, err := utils.ArgToIndex(ctx.Args().First())
@@ -47,7 +61,17 @@ func runPullsCheckout(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	if err := task.PullCheckout(ctx.Login, ctx.Owner, ctx.Repo, ctx.Bool("branch"), idx, interact.PromptPassword); err != nil && !interact.IsQuitting(err) {
+	if ctx.Bool("worktree") {
+		err = task.PullCheckoutWorktree(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, idx, ctx.Args().Get(1), interact.PromptPassword)
+		if err != nil && !interact.IsQuitting(err) {
+			return err
+		}
+		return nil
+	}
+	if ctx.Args().Len() == 2 {
+		return utils.NewValidationErrorf("a worktree path can only be given with --worktree")
+	}
+	if err := task.PullCheckout(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, ctx.Bool("branch"), idx, interact.PromptPassword); err != nil && !interact.IsQuitting(err) {
 		return err
 	}
 	return nil
@@ -0,0 +1,146 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pulls
+
+import (
+	stdctx "context"
+	"fmt"
+	"os"
+	"time"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/muesli/termenv"
+	"github.com/urfave/cli/v3"
+)
+
+// intervalFlag sets how often a PR is polled while waiting for it
+var intervalFlag = cli.DurationFlag{
+	Name:  "interval",
+	Usage: "How often to poll the pull request while waiting",
+	Value: 10 * time.Second,
+	Validator: func(d time.Duration) error {
+		if d < time.Second {
+			return utils.NewValidationErrorf("--interval must be at least 1s")
+		}
+		return nil
+	},
+}
+
+// CmdPullsChecks lists the checks of a PR
+var CmdPullsChecks = cli.Command{
+	Name:    "checks",
+	Aliases: []string{"ci"},
+	Usage:   "Show the CI checks of a pull request",
+	Description: `Show the commit statuses of the head commit of a pull request, with the
+duration of the Actions jobs that reported them. Fails if any check failed.
+With --watch, waits until all checks are done.`,
+	ArgsUsage: "<pull index>",
+	Action:    runPullsChecks,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:    "watch",
+			Aliases: []string{"w"},
+			Usage:   "Poll the checks until all of them are done",
+		},
+		&intervalFlag,
+		&cli.BoolFlag{
+			Name:  "required-only",
+			Usage: "Only show the checks required by the branch protection of the base branch",
+		},
+	}, flags.AllDefaultFlags...),
+}
+
+func runPullsChecks(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() != 1 {
+		return utils.NewValidationErrorf("must specify a PR index")
+	}
+	idx, err := utils.ArgToIndex(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+
+	// redraw the table while watching, if it's shown on a terminal
+	redraw := ctx.Out() == os.Stdout && print.IsInteractive() && (ctx.Output == "" || ctx.Output == "table")
+	lastSummary := ""
+	var checks []*print.PullCheck
+	for {
+		// the PR is reloaded to follow new pushes
+		pr, err := utils.APIResult(client.GetPullRequest(ctx.Owner, ctx.Repo, idx))
+		if err != nil {
+			return err
+		}
+		if checks, err = task.PullChecks(ctx, pr, ctx.Bool("required-only")); err != nil {
+			return err
+		}
+		if !ctx.Bool("watch") || allChecksDone(checks) {
+			break
+		}
+
+		summary := print.PullChecksSummary(checks)
+		if redraw {
+			termenv.DefaultOutput().ClearScreen()
+			fmt.Fprintf(ctx.Out(), "Waiting for checks of #%d, refreshing every %s\n\n", idx, ctx.Duration("interval"))
+			if err := print.PullChecksList(ctx.Out(), checks, ctx.Output); err != nil {
+				return err
+			}
+			fmt.Fprintln(ctx.Out(), summary)
+		} else if summary != lastSummary {
+			fmt.Fprintln(os.Stderr, summary)
+		}
+		lastSummary = summary
+
+		select {
+		case <-ctx.Ctx.Done():
+			return ctx.Ctx.Err()
+		case <-time.After(ctx.Duration("interval")):
+		}
+	}
+
+	if redraw && lastSummary != "" {
+		termenv.DefaultOutput().ClearScreen()
+	}
+	if len(checks) == 0 && !print.IsMachineReadable(ctx.Output) {
+		fmt.Fprintln(ctx.Out(), "No checks reported")
+		return nil
+	}
+	if err := print.PullChecksList(ctx.Out(), checks, ctx.Output); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, c := range checks {
+		if c.Failed() {
+			failed++
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
+
+func allChecksDone(checks []*print.PullCheck) bool {
+	for _, c := range checks {
+		if !c.Done() {
+			return false
+		}
+	}
+	return true
+}
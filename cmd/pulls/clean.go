@@ -18,11 +18,15 @@ import (
 
 // CmdPullsClean removes the remote and local feature branches, if a PR is merged.
 var CmdPullsClean = cli.Command{
-	Name:        "clean",
-	Usage:       "Deletes local & remote feature-branches for a closed pull request",
-	Description: `Deletes local & remote feature-branches for a closed pull request`,
-	ArgsUsage:   "<pull index>",
-	Action:      runPullsClean,
+	Name:  "clean",
+	Usage: "Deletes local & remote feature-branches for a closed pull request",
+	Description: `Deletes local & remote feature-branches for a closed pull request,
+and removes the worktrees the local branch is checked out in.
+
+Without a pull index, the worktrees of all closed pull requests added by
+"tea pulls checkout --worktree" are removed, with their local branches.`,
+	ArgsUsage: "[<pull index>]",
+	Action:    runPullsClean,
 	Flags: append([]cli.Flag{
 		&cli.BoolFlag{
 			Name:  "ignore-sha",
@@ -31,11 +35,19 @@ var CmdPullsClean = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runPullsClean(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{LocalRepo: true})
-	if ctx.Args().Len() != 1 {
-		return fmt.Errorf("Must specify a PR index")
+func runPullsClean(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{LocalRepo: true}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() > 1 {
+		return fmt.Errorf("Must specify a single PR index")
+	}
+	if ctx.Args().Len() == 0 {
+		return task.PullCleanWorktrees(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo)
 	}
 
 	idx, err := utils.ArgToIndex(ctx.Args().First())
@@ -43,7 +55,7 @@ func runPullsClean(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	if err := task.PullClean(ctx.Login, ctx.Owner, ctx.Repo, idx, ctx.Bool("ignore-sha"), interact.PromptPassword); err != nil && !interact.IsQuitting(err) {
+	if err := task.PullClean(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, idx, ctx.Bool("ignore-sha"), interact.PromptPassword); err != nil && !interact.IsQuitting(err) {
 		return err
 	}
 	return nil
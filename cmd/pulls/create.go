@@ -40,12 +40,17 @@ var CmdPullsCreate = cli.Command{
 	}, flags.IssuePRCreateFlags...),
 }
 
-func runPullsCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{
+func runPullsCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{
 		LocalRepo:  true,
 		RemoteRepo: true,
-	})
+	}); err != nil {
+		return err
+	}
 
 	// no args -> interactive mode
 	if ctx.NumFlags() == 0 {
@@ -60,6 +65,19 @@ func runPullsCreate(_ stdctx.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
+	if name := ctx.String("template"); len(name) != 0 {
+		tmpl, err := task.FindPullTemplate(ctx, name)
+		if err != nil {
+			return err
+		}
+		client, err := ctx.Client()
+		if err != nil {
+			return err
+		}
+		if err := task.ApplyTemplate(client, ctx.Owner, ctx.Repo, tmpl, opts); err != nil {
+			return err
+		}
+	}
 
 	var allowMaintainerEdits *bool
 	if ctx.IsSet("allow-maintainer-edits") {
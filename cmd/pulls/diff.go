@@ -0,0 +1,113 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pulls
+
+import (
+	stdctx "context"
+	"io"
+	"slices"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/diff"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v3"
+)
+
+// pathFlag filters the changed files of a PR by path
+var pathFlag = cli.StringSliceFlag{
+	Name:    "path",
+	Aliases: []string{"P"},
+	Usage:   "Only show files matching these globs. Globs without a slash match file names in any directory, e.g. '*.go'",
+	Validator: func(globs []string) error {
+		if err := diff.ValidateGlobs(globs); err != nil {
+			return utils.NewValidationErrorf("invalid --path glob: %s", err)
+		}
+		return nil
+	},
+}
+
+// CmdPullsDiff shows the diff of a PR
+var CmdPullsDiff = cli.Command{
+	Name:        "diff",
+	Usage:       "Show the changes of a pull request",
+	Description: "Show the diff of a pull request, fetched from the server without needing a local clone",
+	ArgsUsage:   "<pull index>",
+	Action:      runPullsDiff,
+	Flags: append([]cli.Flag{
+		&pathFlag,
+		&cli.BoolFlag{
+			Name:  "stat",
+			Usage: "Only show the number of changed lines per file",
+		},
+		&cli.BoolFlag{
+			Name:  "name-only",
+			Usage: "Only show the paths of the changed files",
+		},
+		&cli.BoolFlag{
+			Name:  "word-diff",
+			Usage: "Show changed words inline instead of whole changed lines",
+		},
+		&cli.StringFlag{
+			Name:  "color",
+			Usage: "When to colorize the diff (auto, always, never)",
+			Value: "auto",
+			Validator: func(v string) error {
+				if !slices.Contains([]string{"auto", "always", "never"}, v) {
+					return utils.NewValidationErrorf("invalid --color '%s', must be one of auto, always, never", v)
+				}
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:  "no-pager",
+			Usage: "Don't pipe the diff through $PAGER",
+		},
+	}, flags.LoginRepoFlags...),
+}
+
+func runPullsDiff(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() != 1 {
+		return utils.NewValidationErrorf("must specify a PR index")
+	}
+	if ctx.Bool("stat") && ctx.Bool("name-only") {
+		return utils.NewValidationErrorf("--stat and --name-only cannot be combined")
+	}
+	idx, err := utils.ArgToIndex(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	data, err := utils.APIResult(client.GetPullRequestDiff(ctx.Owner, ctx.Repo, idx, gitea.PullRequestDiffOptions{}))
+	if err != nil {
+		return err
+	}
+	files := diff.Filter(diff.Parse(string(data)), ctx.StringSlice("path"))
+
+	color := ctx.String("color") == "always" || ctx.String("color") == "auto" && print.IsInteractive()
+	return print.WithPager(!ctx.Bool("no-pager"), ctx.Out(), func(w io.Writer) {
+		switch {
+		case ctx.Bool("stat"):
+			print.DiffStat(w, files, color)
+		case ctx.Bool("name-only"):
+			print.DiffNames(w, files)
+		default:
+			print.Diff(w, files, color, ctx.Bool("word-diff"))
+		}
+	})
+}
@@ -8,7 +8,10 @@ import (
 	"fmt"
 
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/retry"
+	"code.gitea.io/tea/modules/task"
 	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
@@ -16,9 +19,14 @@ import (
 )
 
 // editPullState abstracts the arg parsing to edit the given pull request
-func editPullState(_ stdctx.Context, cmd *cli.Command, opts gitea.EditPullRequestOption) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func editPullState(stdCtx stdctx.Context, cmd *cli.Command, opts gitea.EditPullRequestOption) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 	if ctx.Args().Len() == 0 {
 		return fmt.Errorf("Please provide a Pull Request index")
 	}
@@ -28,18 +36,30 @@ func editPullState(_ stdctx.Context, cmd *cli.Command, opts gitea.EditPullReques
 		return err
 	}
 
-	client := ctx.Login.Client()
-	for _, index := range indices {
-		pr, _, err := client.EditPullRequest(ctx.Owner, ctx.Repo, index, opts)
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	// setting the state again has no further effect
+	client.SetContext(retry.WithSafeWrites(stdCtx))
+
+	return task.ForEachItem(indices, task.IndexName, func(index int64) error {
+		pr, err := utils.APIResult(client.EditPullRequest(ctx.Owner, ctx.Repo, index, opts))
 		if err != nil {
 			return err
 		}
+		if dryrun.Enabled() {
+			// the PR returned in dry-run mode is empty
+			return nil
+		}
 
 		if len(indices) > 1 {
-			fmt.Println(pr.HTMLURL)
+			fmt.Fprintln(ctx.Out(), pr.HTMLURL)
 		} else {
-			print.PullDetails(pr, nil, nil)
+			if err := print.PullDetails(ctx.Out(), pr, nil, nil, ctx.Output); err != nil {
+				return err
+			}
 		}
-	}
-	return nil
+		return nil
+	})
 }
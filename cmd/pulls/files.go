@@ -0,0 +1,68 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pulls
+
+import (
+	stdctx "context"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/diff"
+	"code.gitea.io/tea/modules/pagination"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v3"
+)
+
+// CmdPullsFiles lists the files changed by a PR
+var CmdPullsFiles = cli.Command{
+	Name:        "files",
+	Usage:       "List the files changed by a pull request",
+	Description: "List the files changed by a pull request, with their status and number of changed lines",
+	ArgsUsage:   "<pull index>",
+	Action:      runPullsFiles,
+	Flags: append([]cli.Flag{
+		&pathFlag,
+	}, flags.AllDefaultFlags...),
+}
+
+func runPullsFiles(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() != 1 {
+		return utils.NewValidationErrorf("must specify a PR index")
+	}
+	idx, err := utils.ArgToIndex(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	files, err := pagination.All(func(opts gitea.ListOptions) ([]*gitea.ChangedFile, *gitea.Response, error) {
+		return client.ListPullRequestFiles(ctx.Owner, ctx.Repo, idx, gitea.ListPullRequestFilesOptions{ListOptions: opts})
+	}, pagination.Options{PageSize: 50})
+	if err != nil {
+		return err
+	}
+
+	globs := ctx.StringSlice("path")
+	matching := make([]*gitea.ChangedFile, 0, len(files))
+	for _, f := range files {
+		if diff.MatchPath(f.Filename, globs) || f.PreviousFilename != "" && diff.MatchPath(f.PreviousFilename, globs) {
+			matching = append(matching, f)
+		}
+	}
+
+	return print.PullFilesList(ctx.Out(), matching, ctx.Output)
+}
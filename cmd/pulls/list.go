@@ -10,6 +10,7 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
 	"github.com/urfave/cli/v3"
 )
 
@@ -29,9 +30,14 @@ var CmdPullsList = cli.Command{
 }
 
 // RunPullsList return list of pulls
-func RunPullsList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func RunPullsList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	state := gitea.StateOpen
 	switch ctx.String("state") {
@@ -43,10 +49,13 @@ func RunPullsList(_ stdctx.Context, cmd *cli.Command) error {
 		state = gitea.StateClosed
 	}
 
-	prs, _, err := ctx.Login.Client().ListRepoPullRequests(ctx.Owner, ctx.Repo, gitea.ListPullRequestsOptions{
-		ListOptions: flags.GetListOptions(),
-		State:       state,
-	})
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	prs, err := task.ListPulls(client, ctx.Owner, ctx.Repo, gitea.ListPullRequestsOptions{
+		State: state,
+	}, flags.GetPaging())
 
 	if err != nil {
 		return err
@@ -57,6 +66,5 @@ func RunPullsList(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	print.PullsList(prs, ctx.Output, fields)
-	return nil
+	return print.PullsList(ctx.Out(), prs, ctx.Output, fields)
 }
@@ -5,6 +5,8 @@ package pulls
 
 import (
 	stdctx "context"
+	"fmt"
+	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/cmd/flags"
@@ -39,10 +41,32 @@ var CmdPullsMerge = cli.Command{
 			Aliases: []string{"m"},
 			Usage:   "Merge commit message",
 		},
+		&cli.BoolFlag{
+			Name:  "auto",
+			Usage: "Let the server merge the pull request when all checks succeed",
+		},
+		&cli.BoolFlag{
+			Name:  "cancel-auto",
+			Usage: "Cancel a merge scheduled with --auto",
+		},
+		&cli.BoolFlag{
+			Name:  "wait",
+			Usage: "Wait until the pull request is mergeable, its required checks succeeded and it is approved, then merge it",
+		},
+		&intervalFlag,
+		&cli.BoolFlag{
+			Name:  "delete-branch",
+			Usage: "Delete the head branch after merging",
+		},
 	}, flags.AllDefaultFlags...),
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
-		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+			return err
+		}
 
 		if ctx.Args().Len() != 1 {
 			// If no PR index is provided, try interactive mode
@@ -56,11 +80,74 @@ var CmdPullsMerge = cli.Command{
 		if err != nil {
 			return err
 		}
-
-		return task.PullMerge(ctx.Login, ctx.Owner, ctx.Repo, idx, gitea.MergePullRequestOption{
-			Style:   gitea.MergeStyle(ctx.String("style")),
-			Title:   ctx.String("title"),
-			Message: ctx.String("message"),
-		})
+		return runPullMerge(ctx, idx)
 	},
 }
+
+func runPullMerge(ctx *context.TeaContext, idx int64) error {
+	modes := 0
+	for _, mode := range []string{"auto", "cancel-auto", "wait"} {
+		if ctx.Bool(mode) {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return utils.NewValidationErrorf("--auto, --cancel-auto and --wait cannot be combined")
+	}
+
+	opt := gitea.MergePullRequestOption{
+		Style:                  flags.GetMergeStyle(ctx),
+		Title:                  ctx.String("title"),
+		Message:                ctx.String("message"),
+		DeleteBranchAfterMerge: ctx.Bool("delete-branch"),
+	}
+
+	switch {
+	case ctx.Bool("cancel-auto"):
+		if err := task.PullCancelScheduledMerge(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, idx); err != nil {
+			return err
+		}
+		fmt.Fprintf(ctx.Out(), "Cancelled the scheduled merge of #%d\n", idx)
+		return nil
+
+	case ctx.Bool("auto"):
+		scheduled, err := task.PullScheduleMerge(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, idx, opt)
+		if err != nil {
+			return withMergeBlockers(ctx, idx, err)
+		}
+		if !scheduled {
+			fmt.Fprintf(ctx.Out(), "Merged #%d, as its checks already succeeded\n", idx)
+			return nil
+		}
+		fmt.Fprintf(ctx.Out(), "Scheduled #%d to be merged when all checks succeed\n", idx)
+		return nil
+
+	case ctx.Bool("wait"):
+		return task.PullWaitAndMerge(ctx, idx, opt, ctx.Duration("interval"))
+	}
+
+	if err := task.PullMerge(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, idx, opt); err != nil {
+		return withMergeBlockers(ctx, idx, err)
+	}
+	return nil
+}
+
+// withMergeBlockers adds the reasons why a PR can't be merged to a failed merge
+func withMergeBlockers(ctx *context.TeaContext, idx int64, err error) error {
+	client, clientErr := ctx.Client()
+	if clientErr != nil {
+		return err
+	}
+	pr, prErr := utils.APIResult(client.GetPullRequest(ctx.Owner, ctx.Repo, idx))
+	if prErr != nil {
+		return err
+	}
+	blockers, blockErr := task.PullMergeBlockers(ctx, pr)
+	if blockErr != nil {
+		return fmt.Errorf("%w\n%s", err, blockErr)
+	}
+	if len(blockers) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w\n#%d is blocked:\n  %s", err, idx, strings.Join(blockers, "\n  "))
+}
@@ -23,9 +23,14 @@ var CmdPullsReject = cli.Command{
 	Usage:       "Request changes to a pull request",
 	Description: "Request changes to a pull request",
 	ArgsUsage:   "<pull index> <reason>",
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
-		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+			return err
+		}
 
 		if ctx.Args().Len() < 2 {
 			return fmt.Errorf("Must specify a PR index and comment")
@@ -38,7 +43,8 @@ var CmdPullsReject = cli.Command{
 
 		comment := strings.Join(ctx.Args().Tail(), " ")
 
-		return task.CreatePullReview(ctx, idx, gitea.ReviewStateRequestChanges, comment, nil)
+		_, err = task.CreatePullReview(ctx, idx, gitea.ReviewStateRequestChanges, comment, nil)
+		return err
 	},
 	Flags: flags.AllDefaultFlags,
 }
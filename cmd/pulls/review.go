@@ -21,9 +21,14 @@ var CmdPullsReview = cli.Command{
 	Usage:       "Interactively review a pull request",
 	Description: "Interactively review a pull request",
 	ArgsUsage:   "<pull index>",
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
-		ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+			return err
+		}
 
 		if ctx.Args().Len() != 1 {
 			return fmt.Errorf("must specify a PR index")
@@ -0,0 +1,184 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pulls
+
+import (
+	stdctx "context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdPullsReviewComments shows the inline review threads of a PR
+var CmdPullsReviewComments = cli.Command{
+	Name:    "review-comments",
+	Aliases: []string{"threads"},
+	Usage:   "Show the inline review comments of a pull request",
+	Description: `Show the inline review comments of a pull request, grouped into threads by file and line.
+Threads started on an older commit than the head of the pull request are marked as outdated.
+Use the subcommands to reply to a thread, or to resolve it.`,
+	ArgsUsage: "<pull index>",
+	Action:    runPullsReviewComments,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  "unresolved",
+			Usage: "Only show threads that are not resolved",
+		},
+	}, flags.AllDefaultFlags...),
+	Commands: []*cli.Command{
+		&cmdPullsReviewCommentsReply,
+		&cmdPullsReviewCommentsResolve,
+		&cmdPullsReviewCommentsUnresolve,
+	},
+}
+
+var cmdPullsReviewCommentsReply = cli.Command{
+	Name:  "reply",
+	Usage: "Reply to the review thread of a comment",
+	Description: `Reply to the review thread of a comment. The Gitea API can't answer a comment directly,
+so the reply is submitted as a new review comment on the same line and commit, which joins the thread.`,
+	ArgsUsage: "<pull index> <comment id> <reply>",
+	Action:    runReplyReviewComment,
+	Flags:     flags.LoginRepoFlags,
+}
+
+var cmdPullsReviewCommentsResolve = cli.Command{
+	Name:  "resolve",
+	Usage: "Mark the review thread of a comment as resolved",
+	Description: `Mark the review thread of a comment as resolved.
+Fails with a validation error on Gitea servers whose API doesn't support resolving threads.`,
+	ArgsUsage: "<pull index> <comment id>",
+	Action: func(ctx stdctx.Context, cmd *cli.Command) error {
+		return runResolveReviewComment(ctx, cmd, true)
+	},
+	Flags: flags.LoginRepoFlags,
+}
+
+var cmdPullsReviewCommentsUnresolve = cli.Command{
+	Name:  "unresolve",
+	Usage: "Mark the review thread of a comment as unresolved",
+	Description: `Mark the review thread of a comment as unresolved.
+Fails with a validation error on Gitea servers whose API doesn't support resolving threads.`,
+	ArgsUsage: "<pull index> <comment id>",
+	Action: func(ctx stdctx.Context, cmd *cli.Command) error {
+		return runResolveReviewComment(ctx, cmd, false)
+	},
+	Flags: flags.LoginRepoFlags,
+}
+
+func runPullsReviewComments(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() != 1 {
+		return utils.NewValidationErrorf("must specify a PR index")
+	}
+	idx, err := utils.ArgToIndex(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	pr, err := utils.APIResult(client.GetPullRequest(ctx.Owner, ctx.Repo, idx))
+	if err != nil {
+		return err
+	}
+	comments, err := task.ListPullReviewComments(client, ctx.Owner, ctx.Repo, idx)
+	if err != nil {
+		return err
+	}
+
+	headSha := ""
+	if pr.Head != nil {
+		headSha = pr.Head.Sha
+	}
+	threads := print.GroupReviewThreads(comments, headSha)
+	if ctx.Bool("unresolved") {
+		unresolved := threads[:0]
+		for _, t := range threads {
+			if t.Resolver == nil {
+				unresolved = append(unresolved, t)
+			}
+		}
+		threads = unresolved
+	}
+	return print.ReviewThreads(ctx.Out(), threads, ctx.Output)
+}
+
+// reviewCommentArgs parses the PR index and comment ID arguments of the review-comments subcommands
+func reviewCommentArgs(ctx *context.TeaContext) (idx, commentID int64, err error) {
+	if ctx.Args().Len() < 2 {
+		return 0, 0, utils.NewValidationErrorf("must specify a PR index and a comment ID")
+	}
+	if idx, err = utils.ArgToIndex(ctx.Args().Get(0)); err != nil {
+		return 0, 0, err
+	}
+	if commentID, err = utils.ArgToIndex(ctx.Args().Get(1)); err != nil {
+		return 0, 0, err
+	}
+	return idx, commentID, nil
+}
+
+func runReplyReviewComment(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	idx, commentID, err := reviewCommentArgs(ctx)
+	if err != nil {
+		return err
+	}
+	body := strings.Join(ctx.Args().Slice()[2:], " ")
+	if len(strings.TrimSpace(body)) == 0 {
+		return utils.NewValidationErrorf("must specify a reply")
+	}
+
+	review, err := task.ReplyToReviewComment(ctx, idx, commentID, body)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ctx.Out(), review.HTMLURL)
+	return nil
+}
+
+func runResolveReviewComment(stdCtx stdctx.Context, cmd *cli.Command, resolve bool) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	idx, commentID, err := reviewCommentArgs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := task.ResolveReviewComment(ctx, idx, commentID, resolve); err != nil {
+		return err
+	}
+	if resolve {
+		fmt.Fprintf(ctx.Out(), "Resolved the thread of comment %d on #%d\n", commentID, idx)
+	} else {
+		fmt.Fprintf(ctx.Out(), "Unresolved the thread of comment %d on #%d\n", commentID, idx)
+	}
+	return nil
+}
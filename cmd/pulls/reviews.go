@@ -0,0 +1,111 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pulls
+
+import (
+	stdctx "context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdPullsReviews lists the reviews of a PR
+var CmdPullsReviews = cli.Command{
+	Name:        "reviews",
+	Usage:       "List the reviews of a pull request",
+	Description: "List the reviews of a pull request, and dismiss them",
+	ArgsUsage:   "<pull index>",
+	Action:      runPullsReviews,
+	Flags:       flags.AllDefaultFlags,
+	Commands: []*cli.Command{
+		&cmdPullsReviewsDismiss,
+		&cmdPullsReviewsUndismiss,
+	},
+}
+
+var cmdPullsReviewsDismiss = cli.Command{
+	Name:      "dismiss",
+	Usage:     "Dismiss a review of a pull request",
+	ArgsUsage: "<pull index> <review id> [<message>]",
+	Action: func(ctx stdctx.Context, cmd *cli.Command) error {
+		return runDismissReview(ctx, cmd, true)
+	},
+	Flags: flags.LoginRepoFlags,
+}
+
+var cmdPullsReviewsUndismiss = cli.Command{
+	Name:      "undismiss",
+	Usage:     "Restore a dismissed review of a pull request",
+	ArgsUsage: "<pull index> <review id>",
+	Action: func(ctx stdctx.Context, cmd *cli.Command) error {
+		return runDismissReview(ctx, cmd, false)
+	},
+	Flags: flags.LoginRepoFlags,
+}
+
+func runPullsReviews(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() != 1 {
+		return utils.NewValidationErrorf("must specify a PR index")
+	}
+	idx, err := utils.ArgToIndex(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	reviews, err := task.ListPullReviews(client, ctx.Owner, ctx.Repo, idx)
+	if err != nil {
+		return err
+	}
+	return print.PullReviewsList(ctx.Out(), reviews, ctx.Output)
+}
+
+func runDismissReview(stdCtx stdctx.Context, cmd *cli.Command, dismiss bool) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() < 2 {
+		return utils.NewValidationErrorf("must specify a PR index and a review ID")
+	}
+	idx, err := utils.ArgToIndex(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	reviewID, err := utils.ArgToIndex(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	message := strings.Join(ctx.Args().Slice()[2:], " ")
+
+	if err := task.DismissPullReview(ctx, idx, reviewID, message, dismiss); err != nil {
+		return err
+	}
+	if dismiss {
+		fmt.Fprintf(ctx.Out(), "Dismissed review %d of #%d\n", reviewID, idx)
+	} else {
+		fmt.Fprintf(ctx.Out(), "Restored review %d of #%d\n", reviewID, idx)
+	}
+	return nil
+}
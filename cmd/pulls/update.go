@@ -0,0 +1,77 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pulls
+
+import (
+	stdctx "context"
+	"fmt"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/interact"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdPullsUpdate updates the head branch of a PR with its base branch
+var CmdPullsUpdate = cli.Command{
+	Name:  "update",
+	Usage: "Update the branch of a pull request with its base branch",
+	Description: `Let the server merge the base branch into the head branch of a pull request,
+or rebase the head branch onto the base branch with --rebase.
+With --local, the local branch of the pull request, as created by
+'tea pulls checkout --branch', is fast-forwarded to the updated head branch.`,
+	ArgsUsage: "<pull index>",
+	Action:    runPullsUpdate,
+	Flags: append([]cli.Flag{
+		&cli.BoolFlag{
+			Name:  "rebase",
+			Usage: "Rebase the head branch onto the base branch, instead of merging",
+		},
+		&cli.BoolFlag{
+			Name:  "local",
+			Usage: "Also fast-forward the local branch of the pull request",
+		},
+	}, flags.AllDefaultFlags...),
+}
+
+func runPullsUpdate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{
+		LocalRepo:  ctx.Bool("local"),
+		RemoteRepo: true,
+	}); err != nil {
+		return err
+	}
+	if ctx.Args().Len() != 1 {
+		return utils.NewValidationErrorf("must specify a PR index")
+	}
+	idx, err := utils.ArgToIndex(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	err = task.PullUpdate(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, idx, ctx.Bool("rebase"))
+	if err != nil {
+		return err
+	}
+	if ctx.Bool("rebase") {
+		fmt.Fprintf(ctx.Out(), "Rebased the branch of #%d onto its base branch\n", idx)
+	} else {
+		fmt.Fprintf(ctx.Out(), "Merged the base branch into the branch of #%d\n", idx)
+	}
+
+	if ctx.Bool("local") {
+		err = task.PullUpdateLocal(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, idx, interact.PromptPassword)
+		if err != nil && !interact.IsQuitting(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pulls
+
+import (
+	stdctx "context"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdPullsWorktrees lists the worktrees PRs are checked out in
+var CmdPullsWorktrees = cli.Command{
+	Name:    "worktrees",
+	Aliases: []string{"wt"},
+	Usage:   "List the worktrees pull requests are checked out in",
+	Description: `List the linked git worktrees added by "tea pulls checkout --worktree", with the state of their PRs.
+Worktrees of closed PRs are removed by "tea pulls clean".`,
+	ArgsUsage: " ", // command does not accept arguments
+	Action:    runPullsWorktrees,
+	Flags:     flags.AllDefaultFlags,
+}
+
+func runPullsWorktrees(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{
+		LocalRepo:  true,
+		RemoteRepo: true,
+	}); err != nil {
+		return err
+	}
+
+	worktrees, err := task.PullWorktrees(ctx)
+	if err != nil {
+		return err
+	}
+	return print.PullWorktrees(ctx.Out(), worktrees, ctx.Output)
+}
@@ -36,9 +36,14 @@ var CmdReactionAdd = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runReactionAdd(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runReactionAdd(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify a reaction. %s", ReactionHelp)
@@ -52,26 +57,29 @@ func runReactionAdd(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("must specify --issue or --comment")
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if commentID > 0 {
 		// React to a comment
-		_, _, err := client.PostIssueCommentReaction(ctx.Owner, ctx.Repo, int64(commentID), reaction)
+		_, err := utils.APIResult(client.PostIssueCommentReaction(ctx.Owner, ctx.Repo, int64(commentID), reaction))
 		if err != nil {
 			return fmt.Errorf("failed to add reaction to comment: %w", err)
 		}
-		fmt.Printf("Added %s reaction to comment %d\n", reaction, commentID)
+		fmt.Fprintf(ctx.Out(), "Added %s reaction to comment %d\n", reaction, commentID)
 	} else {
 		// React to an issue/PR
 		index, err := utils.ArgToIndex(fmt.Sprintf("%d", issueIndex))
 		if err != nil {
 			return err
 		}
-		_, _, err = client.PostIssueReaction(ctx.Owner, ctx.Repo, index, reaction)
+		_, err = utils.APIResult(client.PostIssueReaction(ctx.Owner, ctx.Repo, index, reaction))
 		if err != nil {
 			return fmt.Errorf("failed to add reaction to issue: %w", err)
 		}
-		fmt.Printf("Added %s reaction to issue #%d\n", reaction, issueIndex)
+		fmt.Fprintf(ctx.Out(), "Added %s reaction to issue #%d\n", reaction, issueIndex)
 	}
 
 	return nil
@@ -36,9 +36,14 @@ var CmdReactionList = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runReactionList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runReactionList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	issueIndex := cmd.Int("issue")
 	commentID := cmd.Int("comment")
@@ -47,31 +52,33 @@ func runReactionList(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("must specify --issue or --comment")
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	var reactions []*gitea.Reaction
-	var err error
 
 	if commentID > 0 {
-		reactions, _, err = client.GetIssueCommentReactions(ctx.Owner, ctx.Repo, int64(commentID))
+		reactions, err = utils.APIResult(client.GetIssueCommentReactions(ctx.Owner, ctx.Repo, int64(commentID)))
 		if err != nil {
 			return fmt.Errorf("failed to get reactions for comment: %w", err)
 		}
-		fmt.Printf("Reactions on comment %d:\n", commentID)
+		fmt.Fprintf(ctx.Out(), "Reactions on comment %d:\n", commentID)
 	} else {
 		index, err2 := utils.ArgToIndex(fmt.Sprintf("%d", issueIndex))
 		if err2 != nil {
 			return err2
 		}
-		reactions, _, err = client.GetIssueReactions(ctx.Owner, ctx.Repo, index)
+		reactions, err = utils.APIResult(client.GetIssueReactions(ctx.Owner, ctx.Repo, index))
 		if err != nil {
 			return fmt.Errorf("failed to get reactions for issue: %w", err)
 		}
-		fmt.Printf("Reactions on issue #%d:\n", issueIndex)
+		fmt.Fprintf(ctx.Out(), "Reactions on issue #%d:\n", issueIndex)
 	}
 
 	if len(reactions) == 0 {
-		fmt.Println("  No reactions")
+		fmt.Fprintln(ctx.Out(), "  No reactions")
 		return nil
 	}
 
@@ -82,7 +89,7 @@ func runReactionList(_ stdctx.Context, cmd *cli.Command) error {
 	}
 
 	for reaction, users := range reactionCounts {
-		fmt.Printf("  %s (%d): %v\n", reactionToEmoji(reaction), len(users), users)
+		fmt.Fprintf(ctx.Out(), "  %s (%d): %v\n", reactionToEmoji(reaction), len(users), users)
 	}
 
 	return nil
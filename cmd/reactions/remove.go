@@ -36,9 +36,14 @@ var CmdReactionRemove = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runReactionRemove(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runReactionRemove(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if !cmd.Args().Present() {
 		return fmt.Errorf("must specify a reaction. %s", ReactionHelp)
@@ -52,26 +57,29 @@ func runReactionRemove(_ stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("must specify --issue or --comment")
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if commentID > 0 {
 		// Remove reaction from a comment
-		_, err := client.DeleteIssueCommentReaction(ctx.Owner, ctx.Repo, int64(commentID), reaction)
+		err := utils.APIError(client.DeleteIssueCommentReaction(ctx.Owner, ctx.Repo, int64(commentID), reaction))
 		if err != nil {
 			return fmt.Errorf("failed to remove reaction from comment: %w", err)
 		}
-		fmt.Printf("Removed %s reaction from comment %d\n", reaction, commentID)
+		fmt.Fprintf(ctx.Out(), "Removed %s reaction from comment %d\n", reaction, commentID)
 	} else {
 		// Remove reaction from an issue/PR
 		index, err := utils.ArgToIndex(fmt.Sprintf("%d", issueIndex))
 		if err != nil {
 			return err
 		}
-		_, err = client.DeleteIssueReaction(ctx.Owner, ctx.Repo, index, reaction)
+		err = utils.APIError(client.DeleteIssueReaction(ctx.Owner, ctx.Repo, index, reaction))
 		if err != nil {
 			return fmt.Errorf("failed to remove reaction from issue: %w", err)
 		}
-		fmt.Printf("Removed %s reaction from issue #%d\n", reaction, issueIndex)
+		fmt.Fprintf(ctx.Out(), "Removed %s reaction from issue #%d\n", reaction, issueIndex)
 	}
 
 	return nil
@@ -12,6 +12,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -67,9 +68,14 @@ var CmdReleaseCreate = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runReleaseCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runReleaseCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	tag := ctx.String("tag")
 	if cmd.Args().Present() {
@@ -89,7 +95,11 @@ func runReleaseCreate(_ stdctx.Context, cmd *cli.Command) error {
 		notestring = string(notebytes)
 	}
 
-	release, resp, err := ctx.Login.Client().CreateRelease(ctx.Owner, ctx.Repo, gitea.CreateReleaseOption{
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	release, resp, err := client.CreateRelease(ctx.Owner, ctx.Repo, gitea.CreateReleaseOption{
 		TagName:      tag,
 		Target:       ctx.String("target"),
 		Title:        ctx.String("title"),
@@ -101,7 +111,7 @@ func runReleaseCreate(_ stdctx.Context, cmd *cli.Command) error {
 		if resp != nil && resp.StatusCode == http.StatusConflict {
 			return fmt.Errorf("There already is a release for this tag")
 		}
-		return err
+		return utils.APIError(resp, err)
 	}
 
 	for _, asset := range ctx.StringSlice("asset") {
@@ -113,7 +123,7 @@ func runReleaseCreate(_ stdctx.Context, cmd *cli.Command) error {
 
 		filePath := filepath.Base(asset)
 
-		if _, _, err = ctx.Login.Client().CreateReleaseAttachment(ctx.Owner, ctx.Repo, release.ID, file, filePath); err != nil {
+		if _, err = utils.APIResult(client.CreateReleaseAttachment(ctx.Owner, ctx.Repo, release.ID, file, filePath)); err != nil {
 			file.Close()
 			return err
 		}
@@ -9,6 +9,8 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/urfave/cli/v3"
 )
@@ -34,13 +36,21 @@ var CmdReleaseDelete = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runReleaseDelete(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runReleaseDelete(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if !ctx.Args().Present() {
-		fmt.Println("Release tag needed to edit")
+		fmt.Fprintln(ctx.Out(), "Release tag needed to edit")
 		return nil
 	}
 
@@ -49,21 +59,20 @@ func runReleaseDelete(_ stdctx.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	for _, tag := range ctx.Args().Slice() {
+	return task.ForEachItem(ctx.Args().Slice(), task.ArgName, func(tag string) error {
 		release, err := getReleaseByTag(ctx.Owner, ctx.Repo, tag, client)
 		if err != nil {
 			return err
 		}
-		_, err = client.DeleteRelease(ctx.Owner, ctx.Repo, release.ID)
+		err = utils.APIError(client.DeleteRelease(ctx.Owner, ctx.Repo, release.ID))
 		if err != nil {
 			return err
 		}
 
 		if ctx.Bool("delete-tag") {
-			_, err = client.DeleteTag(ctx.Owner, ctx.Repo, tag)
+			err = utils.APIError(client.DeleteTag(ctx.Owner, ctx.Repo, tag))
 			return err
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
@@ -12,6 +12,8 @@ import (
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
 	"github.com/urfave/cli/v3"
 )
 
@@ -57,10 +59,18 @@ var CmdReleaseEdit = cli.Command{
 	}, flags.AllDefaultFlags...),
 }
 
-func runReleaseEdit(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runReleaseEdit(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	var isDraft, isPre *bool
 	if ctx.IsSet("draft") {
@@ -71,27 +81,24 @@ func runReleaseEdit(_ stdctx.Context, cmd *cli.Command) error {
 	}
 
 	if !ctx.Args().Present() {
-		fmt.Println("Release tag needed to edit")
+		fmt.Fprintln(ctx.Out(), "Release tag needed to edit")
 		return nil
 	}
 
-	for _, tag := range ctx.Args().Slice() {
+	return task.ForEachItem(ctx.Args().Slice(), task.ArgName, func(tag string) error {
 		release, err := getReleaseByTag(ctx.Owner, ctx.Repo, tag, client)
 		if err != nil {
 			return err
 		}
 
-		_, _, err = client.EditRelease(ctx.Owner, ctx.Repo, release.ID, gitea.EditReleaseOption{
+		_, err = utils.APIResult(client.EditRelease(ctx.Owner, ctx.Repo, release.ID, gitea.EditReleaseOption{
 			TagName:      ctx.String("tag"),
 			Target:       ctx.String("target"),
 			Title:        ctx.String("title"),
 			Note:         ctx.String("note"),
 			IsDraft:      isDraft,
 			IsPrerelease: isPre,
-		})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+		}))
+		return err
+	})
 }
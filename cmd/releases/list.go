@@ -10,6 +10,7 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -26,29 +27,41 @@ var CmdReleaseList = cli.Command{
 	Flags: append([]cli.Flag{
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
 // RunReleasesList list releases
-func RunReleasesList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func RunReleasesList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
-	releases, _, err := ctx.Login.Client().ListReleases(ctx.Owner, ctx.Repo, gitea.ListReleasesOptions{
-		ListOptions: flags.GetListOptions(),
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	releases, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Release, *gitea.Response, error) {
+		return client.ListReleases(ctx.Owner, ctx.Repo, gitea.ListReleasesOptions{
+			ListOptions: opts,
+		})
 	})
 	if err != nil {
 		return err
 	}
 
-	print.ReleasesList(releases, ctx.Output)
-	return nil
+	return print.ReleasesList(ctx.Out(), releases, ctx.Output)
 }
 
 func getReleaseByTag(owner, repo, tag string, client *gitea.Client) (*gitea.Release, error) {
-	rl, _, err := client.ListReleases(owner, repo, gitea.ListReleasesOptions{
+	rl, err := utils.APIResult(client.ListReleases(owner, repo, gitea.ListReleasesOptions{
 		ListOptions: gitea.ListOptions{Page: -1},
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
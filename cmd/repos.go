@@ -43,19 +43,24 @@ func runRepos(ctx stdctx.Context, cmd *cli.Command) error {
 	return repos.RunReposList(ctx, cmd)
 }
 
-func runRepoDetail(_ stdctx.Context, cmd *cli.Command, path string) error {
-	ctx := context.InitCommand(cmd)
-	client := ctx.Login.Client()
+func runRepoDetail(stdCtx stdctx.Context, cmd *cli.Command, path string) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 	repoOwner, repoName := utils.GetOwnerAndRepo(path, ctx.Owner)
-	repo, _, err := client.GetRepo(repoOwner, repoName)
+	repo, err := utils.APIResult(client.GetRepo(repoOwner, repoName))
 	if err != nil {
 		return err
 	}
-	topics, _, err := client.ListRepoTopics(repoOwner, repoName, gitea.ListRepoTopicsOptions{})
+	topics, err := utils.APIResult(client.ListRepoTopics(repoOwner, repoName, gitea.ListRepoTopicsOptions{}))
 	if err != nil {
 		return err
 	}
 
-	print.RepoDetails(repo, topics)
-	return nil
+	return print.RepoDetails(ctx.Out(), repo, topics, ctx.Output)
 }
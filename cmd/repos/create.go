@@ -9,7 +9,9 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -102,12 +104,17 @@ var CmdRepoCreate = cli.Command{
 	}, flags.LoginOutputFlags...),
 }
 
-func runRepoCreate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	client := ctx.Login.Client()
+func runRepoCreate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 	var (
 		repo       *gitea.Repository
-		err        error
 		trustmodel gitea.TrustModel
 	)
 
@@ -139,20 +146,27 @@ func runRepoCreate(_ stdctx.Context, cmd *cli.Command) error {
 		ObjectFormatName: ctx.String("object-format"),
 	}
 	if len(ctx.String("owner")) != 0 {
-		repo, _, err = client.CreateOrgRepo(ctx.String("owner"), opts)
+		repo, err = utils.APIResult(client.CreateOrgRepo(ctx.String("owner"), opts))
 	} else {
-		repo, _, err = client.CreateRepo(opts)
+		repo, err = utils.APIResult(client.CreateRepo(opts))
 	}
 	if err != nil {
 		return err
 	}
 
-	topics, _, err := client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{})
+	if dryrun.Enabled() {
+		// the repository isn't created in dry-run mode
+		return nil
+	}
+
+	topics, err := utils.APIResult(client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{}))
 	if err != nil {
 		return err
 	}
-	print.RepoDetails(repo, topics)
+	if err := print.RepoDetails(ctx.Out(), repo, topics, ctx.Output); err != nil {
+		return err
+	}
 
-	fmt.Printf("%s\n", repo.HTMLURL)
+	fmt.Fprintf(ctx.Out(), "%s\n", repo.HTMLURL)
 	return nil
 }
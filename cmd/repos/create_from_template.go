@@ -11,6 +11,7 @@ import (
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/print"
 	"code.gitea.io/tea/modules/utils"
 	"github.com/urfave/cli/v3"
@@ -82,9 +83,15 @@ var CmdRepoCreateFromTemplate = cli.Command{
 	}, flags.LoginOutputFlags...),
 }
 
-func runRepoCreateFromTemplate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	client := ctx.Login.Client()
+func runRepoCreateFromTemplate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	templateOwner, templateRepo := utils.GetOwnerAndRepo(ctx.String("template"), ctx.Login.User)
 	owner := ctx.Login.User
@@ -105,17 +112,24 @@ func runRepoCreateFromTemplate(_ stdctx.Context, cmd *cli.Command) error {
 		Webhooks:    ctx.Bool("webhooks"),
 	}
 
-	repo, _, err := client.CreateRepoFromTemplate(templateOwner, templateRepo, opts)
+	repo, err := utils.APIResult(client.CreateRepoFromTemplate(templateOwner, templateRepo, opts))
 	if err != nil {
 		return err
 	}
 
-	topics, _, err := client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{})
+	if dryrun.Enabled() {
+		// the repository isn't generated in dry-run mode
+		return nil
+	}
+
+	topics, err := utils.APIResult(client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{}))
 	if err != nil {
 		return err
 	}
-	print.RepoDetails(repo, topics)
+	if err := print.RepoDetails(ctx.Out(), repo, topics, ctx.Output); err != nil {
+		return err
+	}
 
-	fmt.Printf("%s\n", repo.HTMLURL)
+	fmt.Fprintf(ctx.Out(), "%s\n", repo.HTMLURL)
 	return nil
 }
@@ -59,7 +59,7 @@ func TestCreateRepoObjectFormat(t *testing.T) {
 	giteaUserName := os.Getenv("GITEA_TEA_TEST_USERNAME")
 	giteaUserPasword := os.Getenv("GITEA_TEA_TEST_PASSWORD")
 
-	err := task.CreateLogin("test", "", giteaUserName, giteaUserPasword, "", "", "", giteaURL, "", "", true, false, false, false)
+	err := task.CreateLogin(t.Context(), "test", "", giteaUserName, giteaUserPasword, "", "", "", giteaURL, "", "", true, false, false, false)
 	if err != nil && err.Error() != "login name 'test' has already been used" {
 		t.Fatal(err)
 	}
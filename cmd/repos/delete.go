@@ -9,6 +9,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/charmbracelet/huh"
 	"github.com/urfave/cli/v3"
@@ -45,10 +46,16 @@ var CmdRepoRm = cli.Command{
 	}, flags.LoginOutputFlags...),
 }
 
-func runRepoDelete(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
+func runRepoDelete(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	var owner string
 	if ctx.IsSet("owner") {
@@ -76,11 +83,11 @@ func runRepoDelete(_ stdctx.Context, cmd *cli.Command) error {
 		}
 	}
 
-	_, err := client.DeleteRepo(owner, repoName)
+	err = utils.APIError(client.DeleteRepo(owner, repoName))
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Successfully deleted %s/%s\n", owner, repoName)
+	fmt.Fprintf(ctx.Out(), "Successfully deleted %s/%s\n", owner, repoName)
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -32,10 +34,18 @@ var CmdRepoFork = cli.Command{
 	}, flags.LoginRepoFlags...),
 }
 
-func runRepoFork(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runRepoFork(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	opts := gitea.CreateForkOption{}
 	if ctx.IsSet("owner") {
@@ -43,17 +53,24 @@ func runRepoFork(_ stdctx.Context, cmd *cli.Command) error {
 		opts.Organization = &owner
 	}
 
-	repo, _, err := client.CreateFork(ctx.Owner, ctx.Repo, opts)
+	repo, err := utils.APIResult(client.CreateFork(ctx.Owner, ctx.Repo, opts))
 	if err != nil {
 		return err
 	}
 
-	topics, _, err := client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{})
+	if dryrun.Enabled() {
+		// the fork isn't created in dry-run mode
+		return nil
+	}
+
+	topics, err := utils.APIResult(client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{}))
 	if err != nil {
 		return err
 	}
-	print.RepoDetails(repo, topics)
+	if err := print.RepoDetails(ctx.Out(), repo, topics, ctx.Output); err != nil {
+		return err
+	}
 
-	fmt.Printf("%s\n", repo.HTMLURL)
+	fmt.Fprintf(ctx.Out(), "%s\n", repo.HTMLURL)
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -36,6 +37,8 @@ var CmdReposListFlags = append([]cli.Flag{
 	&typeFilterFlag,
 	&flags.PaginationPageFlag,
 	&flags.PaginationLimitFlag,
+	&flags.PaginationAllFlag,
+	&flags.PaginationMaxItemsFlag,
 }, flags.LoginOutputFlags...)
 
 // CmdReposList represents a sub command of repos to list them
@@ -49,9 +52,15 @@ var CmdReposList = cli.Command{
 }
 
 // RunReposList list repositories
-func RunReposList(_ stdctx.Context, cmd *cli.Command) error {
-	teaCmd := context.InitCommand(cmd)
-	client := teaCmd.Login.Client()
+func RunReposList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	teaCmd, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := teaCmd.Client()
+	if err != nil {
+		return err
+	}
 
 	typeFilter, err := getTypeFilter(cmd)
 	if err != nil {
@@ -60,19 +69,23 @@ func RunReposList(_ stdctx.Context, cmd *cli.Command) error {
 
 	var rps []*gitea.Repository
 	if teaCmd.Bool("starred") {
-		user, _, err := client.GetMyUserInfo()
+		user, err := utils.APIResult(client.GetMyUserInfo())
 		if err != nil {
 			return err
 		}
-		rps, _, err = client.SearchRepos(gitea.SearchRepoOptions{
-			ListOptions:     flags.GetListOptions(),
-			StarredByUserID: user.ID,
+		rps, err = flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Repository, *gitea.Response, error) {
+			return client.SearchRepos(gitea.SearchRepoOptions{
+				ListOptions:     opts,
+				StarredByUserID: user.ID,
+			})
 		})
 	} else if teaCmd.Bool("watched") {
-		rps, _, err = client.GetMyWatchedRepos() // TODO: this does not expose pagination..
+		rps, err = utils.APIResult(client.GetMyWatchedRepos()) // TODO: this does not expose pagination..
 	} else {
-		rps, _, err = client.ListMyRepos(gitea.ListReposOptions{
-			ListOptions: flags.GetListOptions(),
+		rps, err = flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Repository, *gitea.Response, error) {
+			return client.ListMyRepos(gitea.ListReposOptions{
+				ListOptions: opts,
+			})
 		})
 	}
 
@@ -90,8 +103,7 @@ func RunReposList(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	print.ReposList(reposFiltered, teaCmd.Output, fields)
-	return nil
+	return print.ReposList(teaCmd.Out(), reposFiltered, teaCmd.Output, fields)
 }
 
 func filterReposByType(repos []*gitea.Repository, t gitea.RepoType) []*gitea.Repository {
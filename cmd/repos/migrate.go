@@ -11,7 +11,9 @@ import (
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 	"github.com/urfave/cli/v3"
 )
 
@@ -108,12 +110,17 @@ var CmdRepoMigrate = cli.Command{
 	}, flags.LoginOutputFlags...),
 }
 
-func runRepoMigrate(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	client := ctx.Login.Client()
+func runRepoMigrate(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 	var (
 		repo    *gitea.Repository
-		err     error
 		service gitea.GitServiceType
 	)
 
@@ -156,18 +163,25 @@ func runRepoMigrate(_ stdctx.Context, cmd *cli.Command) error {
 		LFSEndpoint:    ctx.String("lfs-endpoint"),
 	}
 
-	repo, _, err = client.MigrateRepo(opts)
+	repo, err = utils.APIResult(client.MigrateRepo(opts))
 
 	if err != nil {
 		return err
 	}
 
-	topics, _, err := client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{})
+	if dryrun.Enabled() {
+		// the repository isn't migrated in dry-run mode
+		return nil
+	}
+
+	topics, err := utils.APIResult(client.ListRepoTopics(repo.Owner.UserName, repo.Name, gitea.ListRepoTopicsOptions{}))
 	if err != nil {
 		return err
 	}
-	print.RepoDetails(repo, topics)
+	if err := print.RepoDetails(ctx.Out(), repo, topics, ctx.Output); err != nil {
+		return err
+	}
 
-	fmt.Printf("%s\n", repo.HTMLURL)
+	fmt.Fprintf(ctx.Out(), "%s\n", repo.HTMLURL)
 	return nil
 }
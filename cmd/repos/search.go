@@ -5,12 +5,14 @@ package repos
 
 import (
 	stdctx "context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -53,25 +55,32 @@ var CmdReposSearch = cli.Command{
 		repoFieldsFlag,
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.LoginOutputFlags...),
 }
 
-func runReposSearch(_ stdctx.Context, cmd *cli.Command) error {
-	teaCmd := context.InitCommand(cmd)
-	client := teaCmd.Login.Client()
+func runReposSearch(stdCtx stdctx.Context, cmd *cli.Command) error {
+	teaCmd, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := teaCmd.Client()
+	if err != nil {
+		return err
+	}
 
 	var ownerID int64
 	if teaCmd.IsSet("owner") {
 		// test if owner is a organisation
-		org, _, err := client.GetOrg(teaCmd.String("owner"))
+		org, err := utils.APIResult(client.GetOrg(teaCmd.String("owner")))
 		if err != nil {
-			// HACK: the client does not return a response on 404, so we can't check res.StatusCode
-			if err.Error() != "404 Not Found" {
-				return fmt.Errorf("Could not find owner: %s", err)
+			if !errors.Is(err, utils.ErrNotFound) {
+				return fmt.Errorf("Could not find owner: %w", err)
 			}
 
 			// if owner is no org, its a user
-			user, _, err := client.GetUserInfo(teaCmd.String("owner"))
+			user, err := utils.APIResult(client.GetUserInfo(teaCmd.String("owner")))
 			if err != nil {
 				return err
 			}
@@ -103,21 +112,23 @@ func runReposSearch(_ stdctx.Context, cmd *cli.Command) error {
 		keyword = strings.Join(teaCmd.Args().Slice(), " ")
 	}
 
-	user, _, err := client.GetMyUserInfo()
+	user, err := utils.APIResult(client.GetMyUserInfo())
 	if err != nil {
 		return err
 	}
 
-	rps, _, err := client.SearchRepos(gitea.SearchRepoOptions{
-		ListOptions:          flags.GetListOptions(),
-		OwnerID:              ownerID,
-		IsPrivate:            isPrivate,
-		IsArchived:           isArchived,
-		Type:                 mode,
-		Keyword:              keyword,
-		KeywordInDescription: true,
-		KeywordIsTopic:       teaCmd.Bool("topic"),
-		PrioritizedByOwnerID: user.ID,
+	rps, err := flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Repository, *gitea.Response, error) {
+		return client.SearchRepos(gitea.SearchRepoOptions{
+			ListOptions:          opts,
+			OwnerID:              ownerID,
+			IsPrivate:            isPrivate,
+			IsArchived:           isArchived,
+			Type:                 mode,
+			Keyword:              keyword,
+			KeywordInDescription: true,
+			KeywordIsTopic:       teaCmd.Bool("topic"),
+			PrioritizedByOwnerID: user.ID,
+		})
 	})
 	if err != nil {
 		return err
@@ -127,6 +138,5 @@ func runReposSearch(_ stdctx.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	print.ReposList(rps, teaCmd.Output, fields)
-	return nil
+	return print.ReposList(teaCmd.Out(), rps, teaCmd.Output, fields)
 }
@@ -0,0 +1,59 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	stdctx "context"
+	"os"
+
+	"code.gitea.io/tea/modules/mcp"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdServe represents the command to serve tea operations to other programs
+var CmdServe = cli.Command{
+	Name:     "serve",
+	Category: catMisc,
+	Usage:    "Serve tea operations as tools for editors & agents",
+	Description: `Run a JSON-RPC 2.0 server implementing the Model Context Protocol (MCP),
+which exposes tea operations as tools returning JSON results: listing & viewing
+issues, pull requests and workflow runs, commenting, reviewing & merging pull
+requests, and reading files.
+
+Messages are read from stdin and written to stdout, one JSON object per line.
+Besides the MCP method tools/call, each tool can be called as a JSON-RPC
+method of the same name, which returns the JSON result directly.
+
+Each tool accepts the arguments login, repo & remote, which select the login
+and repository like the flags of the same name. Without them, the repository
+in the working directory of the server is used.
+
+Example configuration for MCP clients:
+  {"mcpServers": {"gitea": {"command": "tea", "args": ["serve", "--stdio"]}}}`,
+	ArgsUsage: " ", // command does not accept arguments
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "stdio",
+			Usage: "Communicate via stdin & stdout. This is the only supported transport for now",
+		},
+	},
+	Action: runServe,
+}
+
+func runServe(ctx stdctx.Context, cmd *cli.Command) error {
+	if !cmd.Bool("stdio") {
+		return utils.NewValidationErrorf("specify the transport to serve on, e.g. --stdio")
+	}
+
+	// stdout is reserved for the protocol; any other output, e.g. of the
+	// tasks used by the tools or of --debug, goes to stderr instead
+	out := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = out }()
+
+	server := mcp.NewServer("tea", Version, mcp.Tools())
+	return server.Serve(ctx, os.Stdin, out)
+}
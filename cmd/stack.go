@@ -0,0 +1,32 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"code.gitea.io/tea/cmd/stack"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdStack is the command to work with stacks of dependent pull requests
+var CmdStack = cli.Command{
+	Name:     "stack",
+	Aliases:  []string{"stacks"},
+	Category: catHelpers,
+	Usage:    "Manage stacks of dependent pull requests",
+	Description: `A stack is a linear chain of local branches, each based on the one below it,
+with one pull request per branch. The stack of the current branch is detected
+from the commits of the local branches: the base of a branch is the closest
+other branch it descends from, or the trunk.
+
+Shows the stack of the current branch when called without a subcommand.`,
+	ArgsUsage: " ", // command does not accept arguments
+	Action:    stack.RunStackShow,
+	Commands: []*cli.Command{
+		&stack.CmdStackShow,
+		&stack.CmdStackSubmit,
+		&stack.CmdStackSync,
+	},
+	Flags: stack.CmdStackShow.Flags,
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import (
+	stdctx "context"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v3"
+)
+
+// baseFlag sets the trunk a stack is based on
+var baseFlag = cli.StringFlag{
+	Name:    "base",
+	Aliases: []string{"b"},
+	Usage:   "Branch the stack is based on, defaults to the default branch of the repo",
+}
+
+// CmdStackShow shows the stack of the current branch
+var CmdStackShow = cli.Command{
+	Name:        "show",
+	Aliases:     []string{"ls", "list"},
+	Usage:       "Show the stack of the current branch",
+	Description: "Show the branches of the stack of the current branch, with their pull requests",
+	ArgsUsage:   " ", // command does not accept arguments
+	Action:      RunStackShow,
+	Flags:       append([]cli.Flag{&baseFlag}, flags.AllDefaultFlags...),
+}
+
+// RunStackShow shows the stack of the current branch
+func RunStackShow(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{LocalRepo: true}); err != nil {
+		return err
+	}
+
+	stack, err := task.LoadStack(ctx, ctx.String("base"))
+	if err != nil {
+		return err
+	}
+	return print.Stack(ctx.Out(), stack.Branches, ctx.Output)
+}
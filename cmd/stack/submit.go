@@ -0,0 +1,54 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import (
+	stdctx "context"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/interact"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdStackSubmit pushes a stack and creates or updates its pull requests
+var CmdStackSubmit = cli.Command{
+	Name:    "submit",
+	Aliases: []string{"push"},
+	Usage:   "Push the stack of the current branch and create or update its pull requests",
+	Description: `Push the branches of the stack of the current branch, and create a pull request
+for each branch that has none yet, based on the branch below it. The base of
+existing pull requests is corrected, and the description of each pull request
+gets a section linking all pull requests of the stack.
+Branches are pushed with a lease, so rebased branches can be pushed, but no
+commits pushed by others are overwritten.`,
+	ArgsUsage: " ", // command does not accept arguments
+	Action:    runStackSubmit,
+	Flags:     append([]cli.Flag{&baseFlag}, flags.AllDefaultFlags...),
+}
+
+func runStackSubmit(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{LocalRepo: true}); err != nil {
+		return err
+	}
+
+	stack, err := task.LoadStack(ctx, ctx.String("base"))
+	if err != nil {
+		return err
+	}
+	if err := task.StackSubmit(ctx, stack, interact.PromptPassword); err != nil {
+		if interact.IsQuitting(err) {
+			return nil
+		}
+		return err
+	}
+	return print.Stack(ctx.Out(), stack.Branches, ctx.Output)
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package stack
+
+import (
+	stdctx "context"
+
+	"code.gitea.io/tea/cmd/flags"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/interact"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/task"
+
+	"github.com/urfave/cli/v3"
+)
+
+// CmdStackSync restacks a stack after pull requests of it were merged
+var CmdStackSync = cli.Command{
+	Name:    "sync",
+	Aliases: []string{"restack"},
+	Usage:   "Rebase the stack of the current branch once its lowest pull requests are merged",
+	Description: `Once the pull requests at the bottom of the stack of the current branch are
+merged, rebase the branches above them onto the trunk, push them, and retarget
+their pull requests. Requires git 2.38 or newer.
+If the rebase has conflicts, resolve them, run 'git rebase --continue' and run
+this command again.`,
+	ArgsUsage: " ", // command does not accept arguments
+	Action:    runStackSync,
+	Flags:     append([]cli.Flag{&baseFlag}, flags.AllDefaultFlags...),
+}
+
+func runStackSync(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{LocalRepo: true}); err != nil {
+		return err
+	}
+
+	stack, err := task.LoadStack(ctx, ctx.String("base"))
+	if err != nil {
+		return err
+	}
+	if err := task.StackSync(ctx, stack, interact.PromptPassword); err != nil {
+		if interact.IsQuitting(err) {
+			return nil
+		}
+		return err
+	}
+	if len(stack.Branches) != 0 {
+		if err := print.Stack(ctx.Out(), stack.Branches, ctx.Output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
+)
+
+// timeoutFlag limits how long a command may take, including all its API requests & git operations
+var timeoutFlag = cli.DurationFlag{
+	Name:  "timeout",
+	Usage: "Abort the command if it takes longer than the given duration, e.g. 30s or 2m (0 for no limit)",
+}
+
+// cancelTimeout releases the context created by applyTimeout
+var cancelTimeout context.CancelFunc = func() {}
+
+// applyTimeout derives the context passed to the commands, which is cancelled
+// once the duration given via --timeout has passed
+func applyTimeout(ctx context.Context, cmd *cli.Command) (context.Context, error) {
+	timeout := cmd.Duration(timeoutFlag.Name)
+	if timeout < 0 {
+		return ctx, utils.NewValidationErrorf("--timeout must not be negative")
+	}
+	if timeout == 0 {
+		return ctx, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	// aliases run a nested app, which may apply its own timeout
+	prev := cancelTimeout
+	cancelTimeout = func() {
+		cancel()
+		prev()
+	}
+	return ctx, nil
+}
+
+func releaseTimeout(context.Context, *cli.Command) error {
+	cancelTimeout()
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestTimeout(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	var cmdCtx context.Context
+	app := &cli.Command{
+		Name:   "tea",
+		Flags:  []cli.Flag{&timeoutFlag},
+		Before: applyTimeout,
+		After:  releaseTimeout,
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			deadline, hasDeadline = ctx.Deadline()
+			cmdCtx = ctx
+			return nil
+		},
+	}
+
+	require.NoError(t, app.Run(t.Context(), []string{"tea"}))
+	assert.False(t, hasDeadline)
+
+	start := time.Now()
+	require.NoError(t, app.Run(t.Context(), []string{"tea", "--timeout", "1m"}))
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, start.Add(time.Minute), deadline, 5*time.Second)
+	// the context is released once the command completed
+	assert.ErrorIs(t, cmdCtx.Err(), context.Canceled)
+
+	assert.Error(t, app.Run(t.Context(), []string{"tea", "--timeout", "-1s"}))
+}
@@ -31,9 +31,14 @@ var CmdTrackedTimesAdd = cli.Command{
 	Flags:  flags.LoginRepoFlags,
 }
 
-func runTrackedTimesAdd(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
+func runTrackedTimesAdd(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
 
 	if ctx.Args().Len() < 2 {
 		return fmt.Errorf("No issue or duration specified.\nUsage:\t%s", ctx.Command.UsageText)
@@ -49,8 +54,12 @@ func runTrackedTimesAdd(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	_, _, err = ctx.Login.Client().AddTime(ctx.Owner, ctx.Repo, issue, gitea.AddTimeOption{
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	_, err = utils.APIResult(client.AddTime(ctx.Owner, ctx.Repo, issue, gitea.AddTimeOption{
 		Time: int64(duration.Seconds()),
-	})
+	}))
 	return err
 }
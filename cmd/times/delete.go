@@ -25,10 +25,18 @@ var CmdTrackedTimesDelete = cli.Command{
 	Flags:     flags.LoginRepoFlags,
 }
 
-func runTrackedTimesDelete(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runTrackedTimesDelete(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if ctx.Args().Len() < 2 {
 		return fmt.Errorf("No issue or time ID specified.\nUsage:\t%s", ctx.Command.UsageText)
@@ -44,6 +52,6 @@ func runTrackedTimesDelete(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	_, err = client.DeleteTime(ctx.Owner, ctx.Repo, issue, timeID)
+	err = utils.APIError(client.DeleteTime(ctx.Owner, ctx.Repo, issue, timeID))
 	return err
 }
@@ -69,13 +69,20 @@ Depending on your permissions on the repository, only your own tracked times mig
 }
 
 // RunTimesList list repositories
-func RunTimesList(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func RunTimesList(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	var times []*gitea.TrackedTime
-	var err error
 	var from, until time.Time
 	var fields []string
 
@@ -96,11 +103,11 @@ func RunTimesList(_ stdctx.Context, cmd *cli.Command) error {
 
 	user := ctx.Args().First()
 	if ctx.Bool("mine") {
-		times, _, err = client.GetMyTrackedTimes()
+		times, err = utils.APIResult(client.GetMyTrackedTimes())
 		fields = []string{"created", "repo", "issue", "duration"}
 	} else if user == "" {
 		// get all tracked times on the repo
-		times, _, err = client.ListRepoTrackedTimes(ctx.Owner, ctx.Repo, opts)
+		times, err = utils.APIResult(client.ListRepoTrackedTimes(ctx.Owner, ctx.Repo, opts))
 		fields = []string{"created", "issue", "user", "duration"}
 	} else if strings.HasPrefix(user, "#") {
 		// get all tracked times on the specified issue
@@ -108,12 +115,12 @@ func RunTimesList(_ stdctx.Context, cmd *cli.Command) error {
 		if err != nil {
 			return err
 		}
-		times, _, err = client.ListIssueTrackedTimes(ctx.Owner, ctx.Repo, issue, opts)
+		times, err = utils.APIResult(client.ListIssueTrackedTimes(ctx.Owner, ctx.Repo, issue, opts))
 		fields = []string{"created", "user", "duration"}
 	} else {
 		// get all tracked times by the specified user
 		opts.User = user
-		times, _, err = client.ListRepoTrackedTimes(ctx.Owner, ctx.Repo, opts)
+		times, err = utils.APIResult(client.ListRepoTrackedTimes(ctx.Owner, ctx.Repo, opts))
 		fields = []string{"created", "issue", "duration"}
 	}
 
@@ -127,6 +134,5 @@ func RunTimesList(_ stdctx.Context, cmd *cli.Command) error {
 		}
 	}
 
-	print.TrackedTimesList(times, ctx.Output, fields, ctx.Bool("total"))
-	return nil
+	return print.TrackedTimesList(ctx.Out(), times, ctx.Output, fields, ctx.Bool("total"))
 }
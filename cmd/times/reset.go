@@ -24,10 +24,18 @@ var CmdTrackedTimesReset = cli.Command{
 	Flags:     flags.LoginRepoFlags,
 }
 
-func runTrackedTimesReset(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	ctx.Ensure(context.CtxRequirement{RemoteRepo: true})
-	client := ctx.Login.Client()
+func runTrackedTimesReset(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Ensure(context.CtxRequirement{RemoteRepo: true}); err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	if ctx.Args().Len() != 1 {
 		return fmt.Errorf("No issue specified.\nUsage:\t%s", ctx.Command.UsageText)
@@ -38,6 +46,6 @@ func runTrackedTimesReset(_ stdctx.Context, cmd *cli.Command) error {
 		return err
 	}
 
-	_, err = client.ResetIssueTime(ctx.Owner, ctx.Repo, issue)
+	err = utils.APIError(client.ResetIssueTime(ctx.Owner, ctx.Repo, issue))
 	return err
 }
@@ -63,9 +63,15 @@ func runWebhooksDefault(ctx stdctx.Context, cmd *cli.Command) error {
 	return webhooks.RunWebhooksList(ctx, cmd)
 }
 
-func runWebhookDetail(_ stdctx.Context, cmd *cli.Command) error {
-	ctx := context.InitCommand(cmd)
-	client := ctx.Login.Client()
+func runWebhookDetail(stdCtx stdctx.Context, cmd *cli.Command) error {
+	ctx, err := context.InitCommand(stdCtx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 
 	webhookID, err := utils.ArgToIndex(cmd.Args().First())
 	if err != nil {
@@ -76,14 +82,14 @@ func runWebhookDetail(_ stdctx.Context, cmd *cli.Command) error {
 	if ctx.IsGlobal {
 		return fmt.Errorf("global webhooks not yet supported in this version")
 	} else if len(ctx.Org) > 0 {
-		hook, _, err = client.GetOrgHook(ctx.Org, int64(webhookID))
+		hook, err = utils.APIResult(client.GetOrgHook(ctx.Org, int64(webhookID)))
 	} else {
-		hook, _, err = client.GetRepoHook(ctx.Owner, ctx.Repo, int64(webhookID))
+		hook, err = utils.APIResult(client.GetRepoHook(ctx.Owner, ctx.Repo, int64(webhookID)))
 	}
 	if err != nil {
 		return err
 	}
 
-	print.WebhookDetails(hook)
+	print.WebhookDetails(ctx.Out(), hook)
 	return nil
 }
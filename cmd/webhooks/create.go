@@ -10,6 +10,7 @@ import (
 
 	"code.gitea.io/tea/cmd/flags"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/urfave/cli/v3"
@@ -59,8 +60,14 @@ func runWebhooksCreate(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("webhook URL is required")
 	}
 
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	webhookType := gitea.HookType(cmd.String("type"))
 	url := cmd.Args().First()
@@ -95,28 +102,27 @@ func runWebhooksCreate(ctx stdctx.Context, cmd *cli.Command) error {
 	}
 
 	var hook *gitea.Hook
-	var err error
 	if c.IsGlobal {
 		return fmt.Errorf("global webhooks not yet supported in this version")
 	} else if len(c.Org) > 0 {
-		hook, _, err = client.CreateOrgHook(c.Org, gitea.CreateHookOption{
+		hook, err = utils.APIResult(client.CreateOrgHook(c.Org, gitea.CreateHookOption{
 			Type:   webhookType,
 			Config: config,
 			Events: events,
 			Active: active,
-		})
+		}))
 	} else {
-		hook, _, err = client.CreateRepoHook(c.Owner, c.Repo, gitea.CreateHookOption{
+		hook, err = utils.APIResult(client.CreateRepoHook(c.Owner, c.Repo, gitea.CreateHookOption{
 			Type:   webhookType,
 			Config: config,
 			Events: events,
 			Active: active,
-		})
+		}))
 	}
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Webhook created successfully (ID: %d)\n", hook.ID)
+	fmt.Fprintf(c.Out(), "Webhook created successfully (ID: %d)\n", hook.ID)
 	return nil
 }
@@ -37,8 +37,14 @@ func runWebhooksDelete(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("webhook ID is required")
 	}
 
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	webhookID, err := utils.ArgToIndex(cmd.Args().First())
 	if err != nil {
@@ -50,9 +56,9 @@ func runWebhooksDelete(ctx stdctx.Context, cmd *cli.Command) error {
 	if c.IsGlobal {
 		return fmt.Errorf("global webhooks not yet supported in this version")
 	} else if len(c.Org) > 0 {
-		hook, _, err = client.GetOrgHook(c.Org, int64(webhookID))
+		hook, err = utils.APIResult(client.GetOrgHook(c.Org, int64(webhookID)))
 	} else {
-		hook, _, err = client.GetRepoHook(c.Owner, c.Repo, int64(webhookID))
+		hook, err = utils.APIResult(client.GetRepoHook(c.Owner, c.Repo, int64(webhookID)))
 	}
 	if err != nil {
 		return err
@@ -71,14 +77,14 @@ func runWebhooksDelete(ctx stdctx.Context, cmd *cli.Command) error {
 	if c.IsGlobal {
 		return fmt.Errorf("global webhooks not yet supported in this version")
 	} else if len(c.Org) > 0 {
-		_, err = client.DeleteOrgHook(c.Org, int64(webhookID))
+		err = utils.APIError(client.DeleteOrgHook(c.Org, int64(webhookID)))
 	} else {
-		_, err = client.DeleteRepoHook(c.Owner, c.Repo, int64(webhookID))
+		err = utils.APIError(client.DeleteRepoHook(c.Owner, c.Repo, int64(webhookID)))
 	}
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Webhook %d deleted successfully\n", webhookID)
+	fmt.Fprintf(c.Out(), "Webhook %d deleted successfully\n", webhookID)
 	return nil
 }
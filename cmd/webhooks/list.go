@@ -25,31 +25,41 @@ var CmdWebhooksList = cli.Command{
 	Flags: append([]cli.Flag{
 		&flags.PaginationPageFlag,
 		&flags.PaginationLimitFlag,
+		&flags.PaginationAllFlag,
+		&flags.PaginationMaxItemsFlag,
 	}, flags.AllDefaultFlags...),
 }
 
 // RunWebhooksList list webhooks
 func RunWebhooksList(ctx stdctx.Context, cmd *cli.Command) error {
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	var hooks []*gitea.Hook
-	var err error
 	if c.IsGlobal {
 		return fmt.Errorf("global webhooks not yet supported in this version")
 	} else if len(c.Org) > 0 {
-		hooks, _, err = client.ListOrgHooks(c.Org, gitea.ListHooksOptions{
-			ListOptions: flags.GetListOptions(),
+		hooks, err = flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Hook, *gitea.Response, error) {
+			return client.ListOrgHooks(c.Org, gitea.ListHooksOptions{
+				ListOptions: opts,
+			})
 		})
 	} else {
-		hooks, _, err = client.ListRepoHooks(c.Owner, c.Repo, gitea.ListHooksOptions{
-			ListOptions: flags.GetListOptions(),
+		hooks, err = flags.FetchList(func(opts gitea.ListOptions) ([]*gitea.Hook, *gitea.Response, error) {
+			return client.ListRepoHooks(c.Owner, c.Repo, gitea.ListHooksOptions{
+				ListOptions: opts,
+			})
 		})
 	}
 	if err != nil {
 		return err
 	}
 
-	print.WebhooksList(hooks, c.Output)
-	return nil
+	return print.WebhooksList(c.Out(), hooks, c.Output)
 }
@@ -61,8 +61,14 @@ func runWebhooksUpdate(ctx stdctx.Context, cmd *cli.Command) error {
 		return fmt.Errorf("webhook ID is required")
 	}
 
-	c := context.InitCommand(cmd)
-	client := c.Login.Client()
+	c, err := context.InitCommand(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return err
+	}
 
 	webhookID, err := utils.ArgToIndex(cmd.Args().First())
 	if err != nil {
@@ -74,9 +80,9 @@ func runWebhooksUpdate(ctx stdctx.Context, cmd *cli.Command) error {
 	if c.IsGlobal {
 		return fmt.Errorf("global webhooks not yet supported in this version")
 	} else if len(c.Org) > 0 {
-		hook, _, err = client.GetOrgHook(c.Org, int64(webhookID))
+		hook, err = utils.APIResult(client.GetOrgHook(c.Org, int64(webhookID)))
 	} else {
-		hook, _, err = client.GetRepoHook(c.Owner, c.Repo, int64(webhookID))
+		hook, err = utils.APIResult(client.GetRepoHook(c.Owner, c.Repo, int64(webhookID)))
 	}
 	if err != nil {
 		return err
@@ -122,22 +128,22 @@ func runWebhooksUpdate(ctx stdctx.Context, cmd *cli.Command) error {
 	if c.IsGlobal {
 		return fmt.Errorf("global webhooks not yet supported in this version")
 	} else if len(c.Org) > 0 {
-		_, err = client.EditOrgHook(c.Org, int64(webhookID), gitea.EditHookOption{
+		err = utils.APIError(client.EditOrgHook(c.Org, int64(webhookID), gitea.EditHookOption{
 			Config: config,
 			Events: events,
 			Active: &active,
-		})
+		}))
 	} else {
-		_, err = client.EditRepoHook(c.Owner, c.Repo, int64(webhookID), gitea.EditHookOption{
+		err = utils.APIError(client.EditRepoHook(c.Owner, c.Repo, int64(webhookID), gitea.EditHookOption{
 			Config: config,
 			Events: events,
 			Active: &active,
-		})
+		}))
 	}
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Webhook %d updated successfully\n", webhookID)
+	fmt.Fprintf(c.Out(), "Webhook %d updated successfully\n", webhookID)
 	return nil
 }
@@ -18,11 +18,17 @@ var CmdWhoami = cli.Command{
 	Description: `For debugging purposes, show the user that is currently logged in.`,
 	Usage:       "Show current logged in user",
 	ArgsUsage:   " ", // command does not accept arguments
-	Action: func(_ stdctx.Context, cmd *cli.Command) error {
-		ctx := context.InitCommand(cmd)
-		client := ctx.Login.Client()
+	Action: func(stdCtx stdctx.Context, cmd *cli.Command) error {
+		ctx, err := context.InitCommand(stdCtx, cmd)
+		if err != nil {
+			return err
+		}
+		client, err := ctx.Client()
+		if err != nil {
+			return err
+		}
 		user, _, _ := client.GetMyUserInfo()
-		print.UserDetails(user)
+		print.UserDetails(ctx.Out(), user)
 		return nil
 	},
 }
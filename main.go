@@ -6,21 +6,58 @@ package main // import "code.gitea.io/tea"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"code.gitea.io/tea/cmd"
-	"code.gitea.io/tea/modules/debug"
+	"code.gitea.io/tea/modules/dryrun"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/urfave/cli/v3"
 )
 
 func main() {
+	// the context is cancelled on interrupt, which aborts running requests & git operations.
+	// a second interrupt terminates tea immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	context.AfterFunc(ctx, stop)
+	defer stop()
+
 	app := cmd.App()
-	app.Flags = append(app.Flags, debug.CliFlag())
-	err := app.Run(context.Background(), os.Args)
+	args, err := cmd.ResolveArgs(app, os.Args)
+	if err == nil {
+		err = app.Run(ctx, args)
+	}
 	if err != nil {
+		if errors.Is(err, dryrun.ErrSkipped) {
+			// the skipped operations were printed
+			return
+		}
+		if ctx.Err() != nil {
+			fmt.Fprintln(app.ErrWriter, "Interrupted")
+			os.Exit(utils.ExitInterrupted)
+		}
 		// app.Run already exits for errors implementing ErrorCoder,
-		// so we only handle generic errors with code 1 here.
-		fmt.Fprintf(app.ErrWriter, "Error: %v\n", err)
-		os.Exit(1)
+		// so we only map our own error kinds to exit codes here.
+		print.Error(app.ErrWriter, err, outputFormat(app))
+		os.Exit(utils.ExitCode(err))
+	}
+}
+
+// outputFormat returns the output format given to the command that was run,
+// so errors raised before the command context was initialized respect it too.
+func outputFormat(app *cli.Command) string {
+	cmd := app
+	for args := cmd.Args(); args != nil && args.Present(); args = cmd.Args() {
+		sub := cmd.Command(args.First())
+		if sub == nil {
+			break
+		}
+		cmd = sub
 	}
+	return cmd.String("output")
 }
@@ -0,0 +1,140 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/utils"
+)
+
+// Client sends raw, authenticated requests to the REST API of a Gitea login.
+// It is used for endpoints the SDK doesn't support (yet), and by `tea api`.
+type Client struct {
+	ctx        context.Context
+	login      *config.Login
+	httpClient *http.Client
+}
+
+// NewClient returns a raw API client for the given login, whose requests are
+// bound to ctx
+func NewClient(ctx context.Context, login *config.Login) (*Client, error) {
+	httpClient, err := login.HTTPClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		ctx:        ctx,
+		login:      login,
+		httpClient: httpClient,
+	}, nil
+}
+
+// URL resolves path against the API root (/api/v1) of the login.
+// Absolute URLs, as found in Link headers, are returned unchanged.
+// The credentials of the login are only sent to URLs on its host, see Do.
+func (c *Client) URL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return strings.TrimSuffix(c.login.URL, "/") + "/api/v1/" + strings.TrimLeft(path, "/")
+}
+
+// Do sends a request with the given method to path, which is either relative to
+// the API root or an absolute URL. The token of the login is only added to requests
+// to its scheme & host. The caller has to close the response body.
+func (c *Client) Do(method, path string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(c.ctx, method, c.URL(path), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, values := range header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+	if req.Header.Get("Authorization") == "" && c.login.Token != "" && c.isLoginURL(req.URL) {
+		req.Header.Set("Authorization", "token "+c.login.Token)
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// isLoginURL checks if u has the scheme & host of the login URL
+func (c *Client) isLoginURL(u *url.URL) bool {
+	loginURL, err := url.Parse(c.login.URL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, loginURL.Scheme) && strings.EqualFold(u.Host, loginURL.Host)
+}
+
+// Request sends a request and returns the response body.
+// Responses with a status code >= 400 are returned as error.
+func (c *Client) Request(method, path string, body io.Reader) ([]byte, error) {
+	resp, err := c.Do(method, path, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, utils.StatusError(resp.StatusCode,
+			fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(data)))
+	}
+
+	return data, nil
+}
+
+var linkNextRegex = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// NextPageURL returns the URL of the next page, as announced in the Link header
+// of a paginated response, or an empty string if this is the last page.
+func NextPageURL(resp *http.Response) string {
+	for _, link := range resp.Header.Values("Link") {
+		for _, part := range strings.Split(link, ",") {
+			if m := linkNextRegex.FindStringSubmatch(part); m != nil {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
+
+// ExpandPlaceholders replaces {owner} and {repo} in path with the escaped values
+// of the given repository.
+func ExpandPlaceholders(path, owner, repo string) string {
+	return strings.NewReplacer(
+		"{owner}", url.PathEscape(owner),
+		"{repo}", url.PathEscape(repo),
+	).Replace(path)
+}
+
+// HasPlaceholders checks if path contains {owner} or {repo} placeholders
+func HasPlaceholders(path string) bool {
+	return strings.Contains(path, "{owner}") || strings.Contains(path, "{repo}")
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientURL(t *testing.T) {
+	c := &Client{login: &config.Login{URL: "https://gitea.example.com/"}}
+
+	assert.Equal(t, "https://gitea.example.com/api/v1/user", c.URL("/user"))
+	assert.Equal(t, "https://gitea.example.com/api/v1/repos/a/b", c.URL("repos/a/b"))
+	assert.Equal(t, "https://other.example.com/api/v1/user?page=2", c.URL("https://other.example.com/api/v1/user?page=2"))
+}
+
+func TestClientDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/repos/owner/repo/labels", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "bar", r.Header.Get("X-Foo"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"bug"}`, string(body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(t.Context(), &config.Login{URL: server.URL, Token: "secret"})
+	require.NoError(t, err)
+	resp, err := c.Do(http.MethodPost, "repos/owner/repo/labels", strings.NewReader(`{"name":"bug"}`), http.Header{"X-Foo": {"bar"}})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestClientDoOtherHost(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(t.Context(), &config.Login{URL: server.URL, Token: "secret"})
+	require.NoError(t, err)
+	for _, u := range []string{server.URL + "/api/v1/user", other.URL + "/api/v1/user"} {
+		resp, err := c.Do(http.MethodGet, u, nil, nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+}
+
+func TestClientRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(t.Context(), &config.Login{URL: server.URL})
+	require.NoError(t, err)
+	_, err = c.Request(http.MethodGet, "/repos/a/b", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
+
+func TestNextPageURL(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	assert.Empty(t, NextPageURL(resp))
+
+	resp.Header.Set("Link", `<https://gitea.example.com/api/v1/user/repos?page=3>; rel="next",<https://gitea.example.com/api/v1/user/repos?page=5>; rel="last"`)
+	assert.Equal(t, "https://gitea.example.com/api/v1/user/repos?page=3", NextPageURL(resp))
+
+	resp.Header.Set("Link", `<https://gitea.example.com/api/v1/user/repos?page=1>; rel="first",<https://gitea.example.com/api/v1/user/repos?page=4>; rel="prev"`)
+	assert.Empty(t, NextPageURL(resp))
+}
+
+func TestExpandPlaceholders(t *testing.T) {
+	assert.True(t, HasPlaceholders("repos/{owner}/{repo}/issues"))
+	assert.False(t, HasPlaceholders("user/repos"))
+	assert.Equal(t, "repos/gitea/tea/issues", ExpandPlaceholders("repos/{owner}/{repo}/issues", "gitea", "tea"))
+}
@@ -56,12 +56,12 @@ type OAuthOptions struct {
 }
 
 // OAuthLogin performs an OAuth2 PKCE login flow to authorize the CLI
-func OAuthLogin(name, giteaURL string) error {
-	return OAuthLoginWithOptions(name, giteaURL, false)
+func OAuthLogin(ctx context.Context, name, giteaURL string) error {
+	return OAuthLoginWithOptions(ctx, name, giteaURL, false)
 }
 
 // OAuthLoginWithOptions performs an OAuth2 PKCE login flow with additional options
-func OAuthLoginWithOptions(name, giteaURL string, insecure bool) error {
+func OAuthLoginWithOptions(ctx context.Context, name, giteaURL string, insecure bool) error {
 	opts := OAuthOptions{
 		Name:        name,
 		URL:         giteaURL,
@@ -70,11 +70,11 @@ func OAuthLoginWithOptions(name, giteaURL string, insecure bool) error {
 		RedirectURL: fmt.Sprintf("http://%s:%d", redirectHost, redirectPort),
 		Port:        redirectPort,
 	}
-	return OAuthLoginWithFullOptions(opts)
+	return OAuthLoginWithFullOptions(ctx, opts)
 }
 
 // OAuthLoginWithFullOptions performs an OAuth2 PKCE login flow with full options control
-func OAuthLoginWithFullOptions(opts OAuthOptions) error {
+func OAuthLoginWithFullOptions(ctx context.Context, opts OAuthOptions) error {
 	// Normalize URL
 	serverURL, err := utils.NormalizeURL(opts.URL)
 	if err != nil {
@@ -118,7 +118,6 @@ func OAuthLoginWithFullOptions(opts OAuthOptions) error {
 	codeChallenge := generateCodeChallenge(codeVerifier)
 
 	// Set up the OAuth2 config
-	ctx := context.Background()
 	ctx = context.WithValue(ctx, oauth2.HTTPClient, createHTTPClient(opts.Insecure))
 
 	// Configure the OAuth2 endpoints
@@ -184,7 +183,7 @@ func OAuthLoginWithFullOptions(opts OAuthOptions) error {
 	}
 
 	// Create login with token data
-	return createLoginFromToken(opts.Name, serverURL.String(), token, opts.Insecure)
+	return createLoginFromToken(ctx, opts.Name, serverURL.String(), token, opts.Insecure)
 }
 
 // createHTTPClient creates an HTTP client with optional insecure setting
@@ -364,7 +363,7 @@ func openBrowser(url string) error {
 }
 
 // createLoginFromToken creates a login entry using the obtained access token
-func createLoginFromToken(name, serverURL string, token *oauth2.Token, insecure bool) error {
+func createLoginFromToken(ctx context.Context, name, serverURL string, token *oauth2.Token, insecure bool) error {
 	if name == "" {
 		var err error
 		name, err = task.GenerateLoginName(serverURL, "")
@@ -390,10 +389,13 @@ func createLoginFromToken(name, serverURL string, token *oauth2.Token, insecure
 	}
 
 	// Validate token by getting user info
-	client := login.Client()
-	u, _, err := client.GetMyUserInfo()
+	client, err := login.Client(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to validate token: %s", err)
+		return err
+	}
+	u, err := utils.APIResult(client.GetMyUserInfo())
+	if err != nil {
+		return fmt.Errorf("failed to validate token: %w", err)
 	}
 
 	// Set user info
@@ -416,7 +418,7 @@ func createLoginFromToken(name, serverURL string, token *oauth2.Token, insecure
 }
 
 // RefreshAccessToken manually renews an expired access token using the refresh token
-func RefreshAccessToken(login *config.Login) error {
+func RefreshAccessToken(ctx context.Context, login *config.Login) error {
 	if login.RefreshToken == "" {
 		return fmt.Errorf("no refresh token available")
 	}
@@ -427,43 +429,44 @@ func RefreshAccessToken(login *config.Login) error {
 		return nil
 	}
 
-	// Create an expired Token object
-	expiredToken := &oauth2.Token{
-		AccessToken:  login.Token,
-		RefreshToken: login.RefreshToken,
-		// Set expiry in the past to force refresh
-		Expiry: time.Unix(login.TokenExpiry, 0),
-	}
-
-	// Set up the OAuth2 config
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, oauth2.HTTPClient, createHTTPClient(login.Insecure))
+	// The refresh is done while the config is locked, so concurrent tea
+	// processes don't use the refresh token twice
+	return config.RefreshLogin(login, func(l *config.Login) error {
+		// Create an expired Token object
+		expiredToken := &oauth2.Token{
+			AccessToken:  l.Token,
+			RefreshToken: l.RefreshToken,
+			// Set expiry in the past to force refresh
+			Expiry: time.Unix(l.TokenExpiry, 0),
+		}
 
-	// Configure the OAuth2 endpoints
-	oauth2Config := &oauth2.Config{
-		ClientID: defaultClientID,
-		Endpoint: oauth2.Endpoint{
-			TokenURL: fmt.Sprintf("%s/login/oauth/access_token", login.URL),
-		},
-	}
+		// Set up the OAuth2 config
+		ctx := context.WithValue(ctx, oauth2.HTTPClient, createHTTPClient(l.Insecure))
 
-	// Refresh the token
-	newToken, err := oauth2Config.TokenSource(ctx, expiredToken).Token()
-	if err != nil {
-		return fmt.Errorf("failed to refresh token: %s", err)
-	}
+		// Configure the OAuth2 endpoints
+		oauth2Config := &oauth2.Config{
+			ClientID: defaultClientID,
+			Endpoint: oauth2.Endpoint{
+				TokenURL: fmt.Sprintf("%s/login/oauth/access_token", l.URL),
+			},
+		}
 
-	// Update login with new token information
-	login.Token = newToken.AccessToken
+		// Refresh the token
+		newToken, err := oauth2Config.TokenSource(ctx, expiredToken).Token()
+		if err != nil {
+			return fmt.Errorf("failed to refresh token: %s", err)
+		}
 
-	if newToken.RefreshToken != "" {
-		login.RefreshToken = newToken.RefreshToken
-	}
+		// Update login with new token information
+		l.Token = newToken.AccessToken
 
-	if !newToken.Expiry.IsZero() {
-		login.TokenExpiry = newToken.Expiry.Unix()
-	}
+		if newToken.RefreshToken != "" {
+			l.RefreshToken = newToken.RefreshToken
+		}
 
-	// Save updated login to config
-	return config.UpdateLogin(login)
+		if !newToken.Expiry.IsZero() {
+			l.TokenExpiry = newToken.Expiry.Unix()
+		}
+		return nil
+	})
 }
@@ -0,0 +1,318 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package cache implements an on-disk cache for responses of the Gitea API.
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"code.gitea.io/tea/modules/debug"
+
+	"github.com/adrg/xdg"
+	"github.com/urfave/cli/v3"
+)
+
+// maxBodySize is the size limit for responses to be cached
+const maxBodySize = 10 << 20
+
+var disabled bool
+
+// IsDisabled returns true if cached responses must not be used
+func IsDisabled() bool {
+	return disabled
+}
+
+// SetDisabled disables the use of cached responses.
+// Responses are still stored, so the cache is refreshed.
+func SetDisabled(off bool) {
+	disabled = off
+}
+
+// CliFlag returns the CLI flag to bypass the cache
+func CliFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "no-cache",
+		Usage: "Don't use cached API responses",
+		Action: func(ctx context.Context, cmd *cli.Command, v bool) error {
+			SetDisabled(v)
+			return nil
+		},
+	}
+}
+
+// Dir returns the directory the cached responses are stored in
+func Dir() string {
+	return filepath.Join(xdg.CacheHome, "tea", "http")
+}
+
+// Clear removes all cached responses
+func Clear() error {
+	return os.RemoveAll(Dir())
+}
+
+// ttls defines how long responses of an endpoint are used without asking the server.
+// The paths are relative to the API root. Responses of other endpoints are
+// revalidated on every request.
+var ttls = []struct {
+	path *regexp.Regexp
+	ttl  time.Duration
+}{
+	{regexp.MustCompile(`^/version$`), time.Hour},
+	{regexp.MustCompile(`^/user$`), 10 * time.Minute},
+	{regexp.MustCompile(`^/repos/[^/]+/[^/]+$`), 10 * time.Minute},
+	{regexp.MustCompile(`^/repos/[^/]+/[^/]+/(labels|milestones|assignees)$`), 10 * time.Minute},
+	{regexp.MustCompile(`^/orgs/[^/]+/labels$`), 10 * time.Minute},
+}
+
+// TTL returns how long the response to a request to the given API path is fresh
+func TTL(apiPath string) time.Duration {
+	for _, t := range ttls {
+		if t.path.MatchString(apiPath) {
+			return t.ttl
+		}
+	}
+	return 0
+}
+
+// entry is a cached response
+type entry struct {
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	// Validated is the time the response was last received or confirmed by the server
+	Validated time.Time `json:"validated"`
+}
+
+func (e *entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// Transport is a http.RoundTripper caching successful GET responses on disk, if
+// their endpoint has a TTL or they can be revalidated by their ETag or Last-Modified
+// header. Fresh responses are returned without a request, stale ones are revalidated.
+// Other requests invalidate the cached responses of the repository, organization
+// or user they modify.
+type Transport struct {
+	// Dir is the directory to store the responses in
+	Dir string
+	// Next is the transport used for requests to the server
+	Next http.RoundTripper
+
+	now func() time.Time
+}
+
+// NewTransport returns a Transport storing responses in Dir().
+// Under go test, next is returned, so tests neither use nor fill the user's cache.
+func NewTransport(next http.RoundTripper) http.RoundTripper {
+	if testing.Testing() {
+		return next
+	}
+	return &Transport{Dir: Dir(), Next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.Next.RoundTrip(req)
+		if err == nil && resp.StatusCode < 400 {
+			t.invalidate(req)
+		}
+		return resp, err
+	}
+
+	path := t.entryPath(req)
+	cached := t.load(path)
+	if cached != nil && !disabled {
+		if t.time().Sub(cached.Validated) < TTL(apiPath(req.URL.Path)) {
+			debug.Printf("cache: using cached response for %s", req.URL.Redacted())
+			return cached.response(req), nil
+		}
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil && !disabled {
+		resp.Body.Close()
+		debug.Printf("cache: %s not modified", req.URL.Redacted())
+		cached.Validated = t.time()
+		t.store(path, cached)
+		return cached.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || !cacheable(req, resp) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxBodySize {
+		// too large to be cached, pass the response through
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.store(path, &entry{
+		URL:        req.URL.Redacted(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		Validated:  t.time(),
+	})
+	return resp, nil
+}
+
+func (t *Transport) time() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+// cacheable checks if a response is worth storing: its endpoint needs a TTL, or it
+// must have a validator. Responses with "Cache-Control: no-store" are never stored.
+func cacheable(req *http.Request, resp *http.Response) bool {
+	for _, v := range resp.Header.Values("Cache-Control") {
+		if strings.Contains(strings.ToLower(v), "no-store") {
+			return false
+		}
+	}
+	return TTL(apiPath(req.URL.Path)) > 0 || resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+// apiPath returns the path relative to the API root
+func apiPath(path string) string {
+	if i := strings.Index(path, "/api/v1/"); i >= 0 {
+		return path[i+len("/api/v1"):]
+	}
+	return path
+}
+
+// scope returns the entity a path belongs to, e.g. repos/owner/repo.
+// Responses are stored per scope, so they can be invalidated together.
+func scope(path string) string {
+	segments := strings.Split(strings.Trim(apiPath(path), "/"), "/")
+	n := 1
+	switch segments[0] {
+	case "repos":
+		n = 3
+	case "orgs", "users":
+		n = 2
+	}
+	if len(segments) < n {
+		n = len(segments)
+	}
+	return strings.Join(segments[:n], "/")
+}
+
+func hash(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (t *Transport) scopeDir(req *http.Request) string {
+	return filepath.Join(t.Dir, hash(req.URL.Host, scope(req.URL.Path))[:16])
+}
+
+// entryPath returns the file of the cached response to a request.
+// Credentials are part of the key, so responses aren't shared between logins.
+func (t *Transport) entryPath(req *http.Request) string {
+	return filepath.Join(t.scopeDir(req), hash(
+		req.URL.String(),
+		req.Header.Get("Authorization"),
+		req.Header.Get("Accept"),
+		req.Header.Get("Sudo"),
+	))
+}
+
+func (t *Transport) load(path string) *entry {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil
+	}
+	return &e
+}
+
+// store writes an entry atomically. Failing to cache a response is not fatal.
+func (t *Transport) store(path string, e *entry) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		debug.Printf("cache: %s", err)
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		debug.Printf("cache: %s", err)
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		debug.Printf("cache: %s", err)
+	}
+}
+
+// invalidate removes the cached responses of the entity modified by a request
+func (t *Transport) invalidate(req *http.Request) {
+	if err := os.RemoveAll(t.scopeDir(req)); err != nil {
+		debug.Printf("cache: %s", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	*httptest.Server
+	requests    int
+	conditional int
+	// noETag omits the ETag header, so responses can't be revalidated
+	noETag bool
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests++
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		if s.noETag {
+			_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			s.conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestClient(t *testing.T, now *time.Time) *http.Client {
+	return &http.Client{Transport: &Transport{
+		Dir:  t.TempDir(),
+		Next: http.DefaultTransport,
+		now:  func() time.Time { return *now },
+	}}
+}
+
+func get(t *testing.T, c *http.Client, url, token string) string {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token "+token)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestTransportTTL(t *testing.T) {
+	server := newTestServer(t)
+	now := time.Now()
+	c := newTestClient(t, &now)
+	url := server.URL + "/api/v1/repos/owner/repo/labels"
+
+	assert.Equal(t, `{"path":"/api/v1/repos/owner/repo/labels"}`, get(t, c, url, "a"))
+	assert.Equal(t, `{"path":"/api/v1/repos/owner/repo/labels"}`, get(t, c, url, "a"))
+	assert.Equal(t, 1, server.requests)
+
+	// other credentials don't share cached responses
+	get(t, c, url, "b")
+	assert.Equal(t, 2, server.requests)
+
+	// stale responses are revalidated
+	now = now.Add(11 * time.Minute)
+	assert.Equal(t, `{"path":"/api/v1/repos/owner/repo/labels"}`, get(t, c, url, "a"))
+	assert.Equal(t, 3, server.requests)
+	assert.Equal(t, 1, server.conditional)
+
+	// and fresh again afterwards
+	get(t, c, url, "a")
+	assert.Equal(t, 3, server.requests)
+}
+
+func TestTransportRevalidate(t *testing.T) {
+	server := newTestServer(t)
+	now := time.Now()
+	c := newTestClient(t, &now)
+	url := server.URL + "/api/v1/repos/owner/repo/issues"
+
+	get(t, c, url, "a")
+	assert.Equal(t, `{"path":"/api/v1/repos/owner/repo/issues"}`, get(t, c, url, "a"))
+	assert.Equal(t, 2, server.requests)
+	assert.Equal(t, 1, server.conditional)
+}
+
+func TestTransportWithoutETag(t *testing.T) {
+	server := newTestServer(t)
+	server.noETag = true
+	now := time.Now()
+	c := newTestClient(t, &now)
+
+	// responses which can't be revalidated are only stored for endpoints with a TTL
+	url := server.URL + "/api/v1/repos/owner/repo/issues"
+	get(t, c, url, "a")
+	assert.Equal(t, `{"path":"/api/v1/repos/owner/repo/issues"}`, get(t, c, url, "a"))
+	assert.Equal(t, 2, server.requests)
+
+	url = server.URL + "/api/v1/repos/owner/repo/labels"
+	get(t, c, url, "a")
+	get(t, c, url, "a")
+	assert.Equal(t, 3, server.requests)
+	assert.Equal(t, 0, server.conditional)
+}
+
+func TestTransportInvalidate(t *testing.T) {
+	server := newTestServer(t)
+	now := time.Now()
+	c := newTestClient(t, &now)
+	url := server.URL + "/api/v1/repos/owner/repo/labels"
+
+	get(t, c, url, "a")
+	get(t, c, server.URL+"/api/v1/repos/other/repo/labels", "a")
+
+	resp, err := c.Post(url, "application/json", strings.NewReader(`{"name":"bug"}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 3, server.requests)
+
+	get(t, c, url, "a")
+	assert.Equal(t, 4, server.requests)
+	assert.Equal(t, 0, server.conditional)
+	get(t, c, server.URL+"/api/v1/repos/other/repo/labels", "a")
+	assert.Equal(t, 4, server.requests)
+}
+
+func TestTransportDisabled(t *testing.T) {
+	server := newTestServer(t)
+	now := time.Now()
+	c := newTestClient(t, &now)
+	url := server.URL + "/api/v1/version"
+
+	get(t, c, url, "a")
+	SetDisabled(true)
+	defer SetDisabled(false)
+	get(t, c, url, "a")
+	assert.Equal(t, 2, server.requests)
+	assert.Equal(t, 0, server.conditional)
+}
+
+func TestScope(t *testing.T) {
+	assert.Equal(t, "repos/owner/repo", scope("/api/v1/repos/owner/repo/issues/1"))
+	assert.Equal(t, "repos/search", scope("/api/v1/repos/search"))
+	assert.Equal(t, "orgs/org", scope("/api/v1/orgs/org/labels"))
+	assert.Equal(t, "user", scope("/api/v1/user/repos"))
+}
+
+func TestTTL(t *testing.T) {
+	assert.Equal(t, 10*time.Minute, TTL("/repos/owner/repo"))
+	assert.Equal(t, 10*time.Minute, TTL("/repos/owner/repo/milestones"))
+	assert.Equal(t, time.Duration(0), TTL("/repos/owner/repo/issues"))
+	assert.Equal(t, time.Hour, TTL("/version"))
+}
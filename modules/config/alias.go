@@ -0,0 +1,36 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import "fmt"
+
+// GetAliases returns the user defined command aliases, mapping alias names to their expansion
+func GetAliases() (map[string]string, error) {
+	if err := loadConfig(); err != nil {
+		return nil, err
+	}
+	return config.Aliases, nil
+}
+
+// SetAlias adds or replaces a command alias
+func SetAlias(name, expansion string) error {
+	return updateConfig(func(c *LocalConfig) error {
+		if c.Aliases == nil {
+			c.Aliases = map[string]string{}
+		}
+		c.Aliases[name] = expansion
+		return nil
+	})
+}
+
+// DeleteAlias removes a command alias
+func DeleteAlias(name string) error {
+	return updateConfig(func(c *LocalConfig) error {
+		if _, ok := c.Aliases[name]; !ok {
+			return fmt.Errorf("alias '%s' does not exist", name)
+		}
+		delete(c.Aliases, name)
+		return nil
+	})
+}
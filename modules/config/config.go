@@ -4,25 +4,86 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"code.gitea.io/tea/modules/utils"
 
 	"github.com/adrg/xdg"
+	"github.com/gofrs/flock"
 	"gopkg.in/yaml.v3"
 )
 
 // FlagDefaults defines all flags that can be overridden with a default value
-// via the config file
+// via the config file, or per repository via a .tea.yml file
 type FlagDefaults struct {
 	// Prefer a specific git remote to use for selecting a repository on gitea,
 	// instead of relying on the remote associated with main/master/trunk branch.
 	// The --remote flag still has precedence over this value.
 	Remote string `yaml:"remote"`
+	// Login to use instead of the one matching the git remote.
+	// The --login flag and a login given via GITEA_INSTANCE_URL & GITEA_TOKEN still have precedence.
+	Login string `yaml:"login,omitempty"`
+	// Output format used when --output is not given
+	Output string `yaml:"output,omitempty"`
+	// Merge style used by `tea pulls merge` when --style is not given
+	MergeStyle string `yaml:"merge_style,omitempty"`
+	// Base branch of new pull requests, instead of the default branch of the repo
+	PullBase string `yaml:"pull_base,omitempty"`
+	// Directory `tea pulls checkout --worktree` adds worktrees in. Relative paths are
+	// resolved from the main worktree of the repo, the default is ../<repo dir>.worktrees
+	WorktreeDir string `yaml:"worktree_dir,omitempty"`
+	// Labels and assignees of new issues, when not given via flags
+	IssueLabels    []string `yaml:"issue_labels,omitempty"`
+	IssueAssignees []string `yaml:"issue_assignees,omitempty"`
+	// Fields printed by list commands when --fields is not given, keyed by
+	// command name without "list", e.g. "issues", "pulls" or "repos search"
+	Fields map[string][]string `yaml:"fields,omitempty"`
+}
+
+// merge returns the defaults with all values set in o applied over them
+func (d FlagDefaults) merge(o FlagDefaults) FlagDefaults {
+	if len(o.Remote) != 0 {
+		d.Remote = o.Remote
+	}
+	if len(o.Login) != 0 {
+		d.Login = o.Login
+	}
+	if len(o.Output) != 0 {
+		d.Output = o.Output
+	}
+	if len(o.MergeStyle) != 0 {
+		d.MergeStyle = o.MergeStyle
+	}
+	if len(o.PullBase) != 0 {
+		d.PullBase = o.PullBase
+	}
+	if len(o.WorktreeDir) != 0 {
+		d.WorktreeDir = o.WorktreeDir
+	}
+	if o.IssueLabels != nil {
+		d.IssueLabels = o.IssueLabels
+	}
+	if o.IssueAssignees != nil {
+		d.IssueAssignees = o.IssueAssignees
+	}
+	if len(o.Fields) != 0 {
+		fields := make(map[string][]string, len(d.Fields)+len(o.Fields))
+		for k, v := range d.Fields {
+			fields[k] = v
+		}
+		for k, v := range o.Fields {
+			fields[k] = v
+		}
+		d.Fields = fields
+	}
+	return d
 }
 
 // Preferences that are stored in and read from the config file
@@ -30,20 +91,33 @@ type Preferences struct {
 	// Prefer using an external text editor over inline multiline prompts
 	Editor       bool         `yaml:"editor"`
 	FlagDefaults FlagDefaults `yaml:"flag_defaults"`
+	// Where tokens of new logins are stored: config (default), keyring, file or command.
+	// Use `tea login migrate-secrets` to move the tokens of existing logins.
+	SecretStore string `yaml:"secret_store,omitempty"`
+	// External command storing secrets for the "command" secret store,
+	// invoked with the argument get, store or erase
+	CredentialCommand string `yaml:"credential_command,omitempty"`
 }
 
 // LocalConfig represents local configurations
 type LocalConfig struct {
 	Logins []Login     `yaml:"logins"`
 	Prefs  Preferences `yaml:"preferences"`
+	// Aliases maps user defined command names to the tea arguments they expand to.
+	// Expansions starting with "!" are run by the shell.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
 }
 
 var (
 	// config contain if loaded local tea config
 	config         LocalConfig
 	loadConfigOnce sync.Once
+	loadConfigErr  error
 )
 
+// configLockTimeout is how long to wait for other tea processes writing the config file
+const configLockTimeout = 10 * time.Second
+
 // GetConfigPath return path to tea config file
 func GetConfigPath() string {
 	configFilePath, err := xdg.ConfigFile("tea/config.yml")
@@ -71,38 +145,116 @@ func GetConfigPath() string {
 	return configFilePath
 }
 
-// GetPreferences returns preferences based on the config file
+// GetPreferences returns preferences based on the config file,
+// with the flag defaults of a loaded .tea.yml merged over them
 func GetPreferences() Preferences {
 	_ = loadConfig()
-	return config.Prefs
+	prefs := config.Prefs
+	prefs.FlagDefaults = prefs.FlagDefaults.merge(repoConfig)
+	return prefs
 }
 
 // loadConfig load config from file
-func loadConfig() (err error) {
+func loadConfig() error {
 	loadConfigOnce.Do(func() {
-		ymlPath := GetConfigPath()
-		exist, _ := utils.FileExist(ymlPath)
-		if exist {
-			bs, err := os.ReadFile(ymlPath)
-			if err != nil {
-				err = fmt.Errorf("Failed to read config file: %s", ymlPath)
-			}
-
-			err = yaml.Unmarshal(bs, &config)
-			if err != nil {
-				err = fmt.Errorf("Failed to parse contents of config file: %s", ymlPath)
-			}
+		config, loadConfigErr = readConfigFile(GetConfigPath())
+	})
+	return loadConfigErr
+}
+
+// readConfigFile reads a config file, an empty config is returned if it doesn't exist
+func readConfigFile(ymlPath string) (LocalConfig, error) {
+	var c LocalConfig
+	bs, err := os.ReadFile(ymlPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return c, fmt.Errorf("Failed to read config file: %s", ymlPath)
+	}
+	if err := yaml.Unmarshal(bs, &c); err != nil {
+		return c, fmt.Errorf("Failed to parse contents of config file: %s", ymlPath)
+	}
+	return c, nil
+}
+
+// updateConfig applies change to the latest version of the config file, and saves it.
+// The config file is locked meanwhile and re-read before applying the change, so
+// concurrent tea processes don't overwrite or roll back each other's changes.
+func updateConfig(change func(c *LocalConfig) error) error {
+	if err := loadConfig(); err != nil {
+		return err
+	}
+	return withConfigLock(func(ymlPath string) error {
+		latest, err := readConfigFile(ymlPath)
+		if err != nil {
+			return err
+		}
+		if err := change(&latest); err != nil {
+			return err
 		}
+		return writeConfig(ymlPath, &latest)
 	})
-	return
 }
 
-// saveConfig save config to file
-func saveConfig() error {
+// withConfigLock runs fn while holding an advisory lock on the config file
+func withConfigLock(fn func(ymlPath string) error) error {
 	ymlPath := GetConfigPath()
-	bs, err := yaml.Marshal(config)
+	// write through symlinks, as used by dotfile managers
+	if resolved, err := filepath.EvalSymlinks(ymlPath); err == nil {
+		ymlPath = resolved
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), configLockTimeout)
+	defer cancel()
+	lock := flock.New(ymlPath + ".lock")
+	if locked, err := lock.TryLockContext(ctx, 50*time.Millisecond); err != nil || !locked {
+		return fmt.Errorf("Failed to lock config file %s: %v", ymlPath, err)
+	}
+	defer lock.Unlock()
+
+	return fn(ymlPath)
+}
+
+// writeConfig saves c to the config file and makes it the loaded config.
+// It must only be called while holding the config lock.
+func writeConfig(ymlPath string, c *LocalConfig) error {
+	if err := storeSecrets(c); err != nil {
+		return err
+	}
+	bs, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(ymlPath, bs, 0o600); err != nil {
+		return err
+	}
+	config = *c
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path, and renames it to path,
+// so readers never see a partially written file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(ymlPath, bs, 0o600)
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
 }
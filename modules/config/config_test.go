@@ -0,0 +1,106 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTempConfig points the config file to a temporary directory
+func useTempConfig(t *testing.T) string {
+	t.Cleanup(func() {
+		xdg.Reload()
+		config, loadConfigOnce, loadConfigErr = LocalConfig{}, sync.Once{}, nil
+	})
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	xdg.Reload()
+	config, loadConfigOnce, loadConfigErr = LocalConfig{}, sync.Once{}, nil
+	return GetConfigPath()
+}
+
+func TestUpdateConfigKeepsConcurrentChanges(t *testing.T) {
+	path := useTempConfig(t)
+	require.NoError(t, loadConfig())
+
+	// another tea process adds a login after this one loaded the config
+	require.NoError(t, os.WriteFile(path, []byte("logins:\n- name: other\n  url: https://gitea.com\n"), 0o600))
+
+	require.NoError(t, SetAlias("mine", "issues list"))
+
+	c, err := readConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, c.Logins, 1)
+	assert.Equal(t, "other", c.Logins[0].Name)
+	assert.Equal(t, map[string]string{"mine": "issues list"}, c.Aliases)
+	assert.Equal(t, c, config)
+}
+
+func TestUpdateConfigConcurrentWriters(t *testing.T) {
+	path := useTempConfig(t)
+	require.NoError(t, loadConfig())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, AddLogin(&Login{Name: fmt.Sprintf("login%d", i)}))
+		}()
+	}
+	wg.Wait()
+
+	c, err := readConfigFile(path)
+	require.NoError(t, err)
+	assert.Len(t, c.Logins, 10)
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotContains(t, e.Name(), ".tmp")
+	}
+}
+
+func TestRefreshLoginUsesConcurrentRefresh(t *testing.T) {
+	path := useTempConfig(t)
+	require.NoError(t, AddLogin(&Login{Name: "gitea", Token: "old", RefreshToken: "r1", TokenExpiry: 100}))
+
+	// another tea process refreshed the token meanwhile
+	login, err := GetLoginByName("gitea")
+	require.NoError(t, err)
+	require.NoError(t, UpdateLogin(&Login{Name: "gitea", Token: "new", RefreshToken: "r2", TokenExpiry: 200}))
+
+	refreshed := false
+	require.NoError(t, RefreshLogin(login, func(*Login) error {
+		refreshed = true
+		return nil
+	}))
+	assert.False(t, refreshed)
+	assert.Equal(t, "new", login.Token)
+	assert.Equal(t, "r2", login.RefreshToken)
+
+	// an expired token is refreshed, and only its tokens are written
+	require.NoError(t, SetDefaultLogin("gitea"))
+	login.TokenExpiry = 200
+	require.NoError(t, RefreshLogin(login, func(l *Login) error {
+		l.Token, l.RefreshToken, l.TokenExpiry = "newer", "r3", 300
+		l.Default = false
+		return nil
+	}))
+	c, err := readConfigFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "newer", c.Logins[0].Token)
+	assert.Equal(t, "r3", c.Logins[0].RefreshToken)
+	assert.True(t, c.Logins[0].Default)
+}
@@ -6,9 +6,7 @@ package config
 import (
 	"context"
 	"crypto/tls"
-	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
@@ -17,10 +15,14 @@ import (
 	"time"
 
 	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/cache"
 	"code.gitea.io/tea/modules/debug"
+	"code.gitea.io/tea/modules/dryrun"
+	"code.gitea.io/tea/modules/retry"
 	"code.gitea.io/tea/modules/theme"
 	"code.gitea.io/tea/modules/utils"
 	"github.com/charmbracelet/huh"
+	"github.com/hashicorp/go-version"
 	"golang.org/x/oauth2"
 )
 
@@ -28,7 +30,7 @@ import (
 type Login struct {
 	Name    string `yaml:"name"`
 	URL     string `yaml:"url"`
-	Token   string `yaml:"token"`
+	Token   string `yaml:"token,omitempty"`
 	Default bool   `yaml:"default"`
 	SSHHost string `yaml:"ssh_host"`
 	// optional path to the private key
@@ -44,9 +46,42 @@ type Login struct {
 	// Created is auto created unix timestamp
 	Created int64 `yaml:"created"`
 	// RefreshToken is used to renew the access token when it expires
-	RefreshToken string `yaml:"refresh_token"`
+	RefreshToken string `yaml:"refresh_token,omitempty"`
 	// TokenExpiry is when the token expires (unix timestamp)
 	TokenExpiry int64 `yaml:"token_expiry"`
+	// SecretStore is where Token and RefreshToken are kept, if not in the config file.
+	// See SecretStores for possible values.
+	SecretStore string `yaml:"secret_store,omitempty"`
+	// Retry configures retries of failed requests, see RetryConfig
+	Retry *RetryConfig `yaml:"retry,omitempty"`
+}
+
+// RetryConfig overrides the defaults for retrying failed requests of a login
+type RetryConfig struct {
+	// MaxRetries is the number of retries, 0 disables retries
+	MaxRetries *int `yaml:"max_retries,omitempty"`
+	// BaseDelay is the delay before the first retry, doubled for every further retry
+	BaseDelay time.Duration `yaml:"base_delay,omitempty"`
+	// MaxDelay caps the delay between attempts, including delays requested by the server
+	MaxDelay time.Duration `yaml:"max_delay,omitempty"`
+}
+
+// RetryPolicy returns the policy for retrying failed requests of the login
+func (l *Login) RetryPolicy() retry.Policy {
+	policy := retry.DefaultPolicy
+	if l.Retry == nil {
+		return policy
+	}
+	if l.Retry.MaxRetries != nil {
+		policy.MaxRetries = *l.Retry.MaxRetries
+	}
+	if l.Retry.BaseDelay > 0 {
+		policy.BaseDelay = l.Retry.BaseDelay
+	}
+	if l.Retry.MaxDelay > 0 {
+		policy.MaxDelay = l.Retry.MaxDelay
+	}
+	return policy
 }
 
 // GetLogins return all login available by config
@@ -64,257 +99,354 @@ func GetDefaultLogin() (*Login, error) {
 	}
 
 	if len(config.Logins) == 0 {
-		return nil, errors.New("No available login")
+		return nil, utils.NewMissingLoginErrorf("No available login")
 	}
+	login := config.Logins[0]
 	for _, l := range config.Logins {
 		if l.Default {
-			return &l, nil
+			login = l
+			break
 		}
 	}
 
-	return &config.Logins[0], nil
+	if err := login.LoadSecrets(); err != nil {
+		return nil, err
+	}
+	return &login, nil
 }
 
 // SetDefaultLogin set the default login by name (case insensitive)
 func SetDefaultLogin(name string) error {
-	if err := loadConfig(); err != nil {
-		return err
-	}
-
-	loginExist := false
-	for i := range config.Logins {
-		config.Logins[i].Default = false
-		if strings.ToLower(config.Logins[i].Name) == strings.ToLower(name) {
-			config.Logins[i].Default = true
-			loginExist = true
+	return updateConfig(func(c *LocalConfig) error {
+		loginExist := false
+		for i := range c.Logins {
+			c.Logins[i].Default = false
+			if strings.ToLower(c.Logins[i].Name) == strings.ToLower(name) {
+				c.Logins[i].Default = true
+				loginExist = true
+			}
 		}
-	}
-
-	if !loginExist {
-		return fmt.Errorf("login '%s' not found", name)
-	}
 
-	return saveConfig()
+		if !loginExist {
+			return fmt.Errorf("login '%s' not found", name)
+		}
+		return nil
+	})
 }
 
-// GetLoginByName get login by name (case insensitive)
-func GetLoginByName(name string) *Login {
-	err := loadConfig()
-	if err != nil {
-		log.Fatal(err)
+// GetLoginByName get login by name (case insensitive).
+// An error of kind utils.ErrMissingLogin is returned if it doesn't exist.
+func GetLoginByName(name string) (*Login, error) {
+	if err := loadConfig(); err != nil {
+		return nil, err
 	}
 
 	for _, l := range config.Logins {
 		if strings.ToLower(l.Name) == strings.ToLower(name) {
-			return &l
+			if err := l.LoadSecrets(); err != nil {
+				return nil, err
+			}
+			return &l, nil
 		}
 	}
-	return nil
+	return nil, utils.NewMissingLoginErrorf("Login name '%s' does not exist", name)
 }
 
-// GetLoginByToken get login by token
-func GetLoginByToken(token string) *Login {
-	err := loadConfig()
-	if err != nil {
-		log.Fatal(err)
+// GetLoginByToken get login by token.
+// An error of kind utils.ErrMissingLogin is returned if it doesn't exist.
+func GetLoginByToken(token string) (*Login, error) {
+	if err := loadConfig(); err != nil {
+		return nil, err
 	}
 
 	for _, l := range config.Logins {
-		if l.Token == token {
-			return &l
+		// logins with unreadable secrets can't match
+		if err := l.LoadSecrets(); err == nil && l.Token == token {
+			return &l, nil
 		}
 	}
-	return nil
+	return nil, utils.NewMissingLoginErrorf("No login with the given token exists")
 }
 
-// GetLoginByHost finds a login by it's server URL
-func GetLoginByHost(host string) *Login {
-	err := loadConfig()
-	if err != nil {
-		log.Fatal(err)
+// GetLoginByHost finds a login by it's server URL.
+// An error of kind utils.ErrMissingLogin is returned if it doesn't exist.
+func GetLoginByHost(host string) (*Login, error) {
+	if err := loadConfig(); err != nil {
+		return nil, err
 	}
 
 	for _, l := range config.Logins {
 		loginURL, err := url.Parse(l.URL)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 		if loginURL.Host == host {
-			return &l
+			if err := l.LoadSecrets(); err != nil {
+				return nil, err
+			}
+			return &l, nil
 		}
 	}
-	return nil
+	return nil, utils.NewMissingLoginErrorf("No login for host '%s' exists", host)
 }
 
 // DeleteLogin delete a login by name from config
 func DeleteLogin(name string) error {
-	idx := -1
-	for i, l := range config.Logins {
-		if l.Name == name {
-			idx = i
-			break
+	return updateConfig(func(c *LocalConfig) error {
+		idx := findLogin(c.Logins, name)
+		if idx == -1 {
+			return fmt.Errorf("can not delete login '%s', does not exist", name)
 		}
-	}
-	if idx == -1 {
-		return fmt.Errorf("can not delete login '%s', does not exist", name)
-	}
-
-	config.Logins = append(config.Logins[:idx], config.Logins[idx+1:]...)
 
-	return saveConfig()
+		if err := deleteSecrets(&c.Logins[idx]); err != nil {
+			return fmt.Errorf("failed to remove token of login '%s': %w", name, err)
+		}
+		c.Logins = append(c.Logins[:idx], c.Logins[idx+1:]...)
+		return nil
+	})
 }
 
 // AddLogin save a login to config
 func AddLogin(login *Login) error {
+	return updateConfig(func(c *LocalConfig) error {
+		if login.SecretStore == "" && c.Prefs.SecretStore != SecretStoreConfig {
+			login.SecretStore = c.Prefs.SecretStore
+		}
+		c.Logins = append(c.Logins, *login)
+		return nil
+	})
+}
+
+// UpdateLogin updates an existing login in the config.
+// Other logins are left as they are in the config file.
+func UpdateLogin(login *Login) error {
+	return updateConfig(func(c *LocalConfig) error {
+		idx := findLogin(c.Logins, login.Name)
+		if idx == -1 {
+			return fmt.Errorf("login %s not found", login.Name)
+		}
+		c.Logins[idx] = *login
+		return nil
+	})
+}
+
+// RefreshLogin renews the tokens of a login via refresh, and saves them.
+// The config file is locked meanwhile: if another tea process has refreshed the
+// tokens already, those are used instead, as refresh tokens may only be used once.
+func RefreshLogin(login *Login, refresh func(l *Login) error) error {
 	if err := loadConfig(); err != nil {
 		return err
 	}
+	return withConfigLock(func(ymlPath string) error {
+		latest, err := readConfigFile(ymlPath)
+		if err != nil {
+			return err
+		}
+		idx := findLogin(latest.Logins, login.Name)
+		if idx == -1 {
+			return fmt.Errorf("login %s not found", login.Name)
+		}
 
-	// save login to global var
-	config.Logins = append(config.Logins, *login)
+		current := &latest.Logins[idx]
+		if current.TokenExpiry > login.TokenExpiry {
+			if current.SecretStore == SecretStoreFile {
+				// read the tokens stored by the other process, not the ones read before
+				defaultFileStore().forget()
+			}
+			if err := current.LoadSecrets(); err != nil {
+				return err
+			}
+			login.Token = current.Token
+			login.RefreshToken = current.RefreshToken
+			login.TokenExpiry = current.TokenExpiry
+			return nil
+		}
 
-	// save login to config file
-	return saveConfig()
+		if err := refresh(login); err != nil {
+			return err
+		}
+		current.Token = login.Token
+		current.RefreshToken = login.RefreshToken
+		current.TokenExpiry = login.TokenExpiry
+		return writeConfig(ymlPath, &latest)
+	})
 }
 
-// UpdateLogin updates an existing login in the config
-func UpdateLogin(login *Login) error {
-	if err := loadConfig(); err != nil {
-		return err
+// findLogin returns the index of the login with the given name, or -1
+func findLogin(logins []Login, name string) int {
+	for i, l := range logins {
+		if l.Name == name {
+			return i
+		}
 	}
+	return -1
+}
 
-	// Find and update the login
-	found := false
-	for i, l := range config.Logins {
-		if l.Name == login.Name {
-			config.Logins[i] = *login
-			found = true
-			break
-		}
+// Client returns a client to operate Gitea API, whose requests are bound to ctx.
+// You may provide additional modifiers for the client like gitea.SetBasicAuth() for customization
+func (l *Login) Client(ctx context.Context, options ...gitea.ClientOption) (*gitea.Client, error) {
+	httpClient, err := l.HTTPClient(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if !found {
-		return fmt.Errorf("login %s not found", login.Name)
+	options = append([]gitea.ClientOption{gitea.SetContext(ctx)}, options...)
+	// versioncheck must be prepended in options to make sure we don't hit any version checks in the sdk
+	if !l.VersionCheck {
+		options = append([]gitea.ClientOption{gitea.SetGiteaVersion("")}, options...)
 	}
 
-	// Save updated config
-	return saveConfig()
-}
+	// requests are logged in debug mode by the HTTP client, with credentials redacted
+	options = append(options, gitea.SetToken(l.Token), gitea.SetHTTPClient(httpClient))
 
-// Client returns a client to operate Gitea API. You may provide additional modifiers
-// for the client like gitea.SetBasicAuth() for customization
-func (l *Login) Client(options ...gitea.ClientOption) *gitea.Client {
-	// Check if token needs refreshing (if we have a refresh token and expiry time)
-	if l.RefreshToken != "" && l.TokenExpiry > 0 && time.Now().Unix() > l.TokenExpiry {
-		// Since we can't directly call auth.RefreshAccessToken due to import cycles,
-		// we'll implement the token refresh logic here.
-		// Create an expired Token object
-		expiredToken := &oauth2.Token{
-			AccessToken:  l.Token,
-			RefreshToken: l.RefreshToken,
-			// Set expiry in the past to force refresh
-			Expiry: time.Unix(l.TokenExpiry, 0),
+	if l.SSHCertPrincipal != "" {
+		if err := l.askForSSHPassphrase(); err != nil {
+			return nil, err
 		}
+		options = append(options, gitea.UseSSHCert(l.SSHCertPrincipal, l.SSHKey, l.SSHPassphrase))
+	}
 
-		// Set up the OAuth2 config
-		ctx := context.Background()
-
-		// Create HTTP client with proper insecure settings
-		httpClient := &http.Client{}
-		if l.Insecure {
-			httpClient = &http.Client{
-				Transport: &http.Transport{
-					TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-				},
-			}
-		}
-		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
-
-		// Configure the OAuth2 endpoints
-		oauth2Config := &oauth2.Config{
-			ClientID: "d57cb8c4-630c-4168-8324-ec79935e18d4", // defaultClientID from modules/auth/oauth.go
-			Endpoint: oauth2.Endpoint{
-				TokenURL: fmt.Sprintf("%s/login/oauth/access_token", l.URL),
-			},
+	if l.SSHKeyFingerprint != "" {
+		if err := l.askForSSHPassphrase(); err != nil {
+			return nil, err
 		}
+		options = append(options, gitea.UseSSHPubkey(l.SSHKeyFingerprint, l.SSHKey, l.SSHPassphrase))
+	}
 
-		// Refresh the token
-		newToken, err := oauth2Config.TokenSource(ctx, expiredToken).Token()
+	if l.VersionCheck {
+		// the SDK checks the server version while creating the client, without exposing
+		// the response, so it is requested upfront to classify a failed request
+		serverVersion, err := requestServerVersion(l.URL, options)
 		if err != nil {
-			log.Fatalf("Failed to refresh token: %s\nPlease use 'tea login oauth-refresh %s' to manually refresh the token.\n", err, l.Name)
-		}
-		// Update login with new token information
-		l.Token = newToken.AccessToken
-
-		if newToken.RefreshToken != "" {
-			l.RefreshToken = newToken.RefreshToken
+			return nil, err
 		}
+		options = append([]gitea.ClientOption{gitea.SetGiteaVersion(serverVersion)}, options...)
+	}
+	return gitea.NewClient(l.URL, options...)
+}
 
-		if !newToken.Expiry.IsZero() {
-			l.TokenExpiry = newToken.Expiry.Unix()
-		}
+// requestServerVersion returns the version of the Gitea instance at serverURL.
+// Like the SDK, it falls back to the oldest supported version if the version is unknown.
+func requestServerVersion(serverURL string, options []gitea.ClientOption) (string, error) {
+	client, err := gitea.NewClient(serverURL, append([]gitea.ClientOption{gitea.SetGiteaVersion("")}, options...)...)
+	if err != nil {
+		return "", err
+	}
+	serverVersion, err := utils.APIResult(client.ServerVersion())
+	if err != nil {
+		return "", err
+	}
+	if _, err := version.NewVersion(serverVersion); err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: could not detect gitea version: unknown version: %s\nINFO: set gitea version: to last supported one\n", serverVersion)
+		return "1.11.0", nil
+	}
+	return serverVersion, nil
+}
 
-		// Save updated login to config
-		if err := UpdateLogin(l); err != nil {
-			log.Fatalf("Failed to save refreshed token: %s\n", err)
-		}
+// HTTPClient returns the http client used to talk to the Gitea instance of this login.
+// An expired OAuth access token is refreshed before the client is returned, so the
+// client can be used for raw API requests next to the SDK client.
+// Responses are cached on disk, and failed requests retried according to RetryPolicy.
+// In dry-run mode, requests changing anything are printed instead of sent.
+func (l *Login) HTTPClient(ctx context.Context) (*http.Client, error) {
+	if err := l.LoadSecrets(); err != nil {
+		return nil, err
+	}
+	if err := l.RefreshExpiredToken(ctx); err != nil {
+		return nil, err
 	}
 
+	var transport http.RoundTripper = http.DefaultTransport
 	httpClient := &http.Client{}
 	if l.Insecure {
 		cookieJar, _ := cookiejar.New(nil)
+		httpClient.Jar = cookieJar
+		transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+	// each attempt is traced, but responses served from the cache aren't
+	transport = debug.NewTraceTransport(transport, l.Token, l.RefreshToken, l.SSHPassphrase)
+	transport = retry.NewTransport(transport, l.RetryPolicy())
+	// requests skipped in dry-run mode must not invalidate cached responses
+	transport = dryrun.NewTransport(cache.NewTransport(transport), l.Token, l.RefreshToken, l.SSHPassphrase)
+	httpClient.Transport = transport
+
+	return httpClient, nil
+}
 
-		httpClient = &http.Client{
-			Jar: cookieJar,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-			},
+// RefreshExpiredToken renews an expired OAuth access token of the login
+func (l *Login) RefreshExpiredToken(ctx context.Context) error {
+	// Check if token needs refreshing (if we have a refresh token and expiry time)
+	if l.RefreshToken != "" && l.TokenExpiry > 0 && time.Now().Unix() > l.TokenExpiry {
+		refresh := func(l *Login) error { return refreshOAuthToken(ctx, l) }
+		if err := RefreshLogin(l, refresh); err != nil {
+			return utils.WrapError(utils.ErrUnauthorized, fmt.Errorf(
+				"Failed to refresh token: %s\nPlease use 'tea login oauth-refresh %s' to manually refresh the token", err, l.Name))
 		}
 	}
+	return nil
+}
 
-	// versioncheck must be prepended in options to make sure we don't hit any version checks in the sdk
-	if !l.VersionCheck {
-		options = append([]gitea.ClientOption{gitea.SetGiteaVersion("")}, options...)
+// refreshOAuthToken renews an expired OAuth access token using the refresh token.
+// Since we can't directly call auth.RefreshAccessToken due to import cycles,
+// we implement the token refresh logic here.
+func refreshOAuthToken(ctx context.Context, l *Login) error {
+	// Create an expired Token object
+	expiredToken := &oauth2.Token{
+		AccessToken:  l.Token,
+		RefreshToken: l.RefreshToken,
+		// Set expiry in the past to force refresh
+		Expiry: time.Unix(l.TokenExpiry, 0),
 	}
 
-	options = append(options, gitea.SetToken(l.Token), gitea.SetHTTPClient(httpClient))
-	if debug.IsDebug() {
-		options = append(options, gitea.SetDebugMode())
+	// Create HTTP client with proper insecure settings
+	var transport http.RoundTripper = http.DefaultTransport
+	if l.Insecure {
+		transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+	httpClient := &http.Client{Transport: debug.NewTraceTransport(transport, l.Token, l.RefreshToken)}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+
+	// Configure the OAuth2 endpoints
+	oauth2Config := &oauth2.Config{
+		ClientID: "d57cb8c4-630c-4168-8324-ec79935e18d4", // defaultClientID from modules/auth/oauth.go
+		Endpoint: oauth2.Endpoint{
+			TokenURL: fmt.Sprintf("%s/login/oauth/access_token", l.URL),
+		},
 	}
 
-	if l.SSHCertPrincipal != "" {
-		l.askForSSHPassphrase()
-		options = append(options, gitea.UseSSHCert(l.SSHCertPrincipal, l.SSHKey, l.SSHPassphrase))
+	// Refresh the token
+	newToken, err := oauth2Config.TokenSource(ctx, expiredToken).Token()
+	if err != nil {
+		return err
 	}
+	// Update login with new token information
+	l.Token = newToken.AccessToken
 
-	if l.SSHKeyFingerprint != "" {
-		l.askForSSHPassphrase()
-		options = append(options, gitea.UseSSHPubkey(l.SSHKeyFingerprint, l.SSHKey, l.SSHPassphrase))
+	if newToken.RefreshToken != "" {
+		l.RefreshToken = newToken.RefreshToken
 	}
 
-	client, err := gitea.NewClient(l.URL, options...)
-	if err != nil {
-		var versionError *gitea.ErrUnknownVersion
-		if !errors.As(err, &versionError) {
-			log.Fatal(err)
-		}
-		fmt.Fprintf(os.Stderr, "WARNING: could not detect gitea version: %s\nINFO: set gitea version: to last supported one\n", versionError)
+	if !newToken.Expiry.IsZero() {
+		l.TokenExpiry = newToken.Expiry.Unix()
 	}
-	return client
+	return nil
 }
 
-func (l *Login) askForSSHPassphrase() {
+func (l *Login) askForSSHPassphrase() error {
 	if ok, err := utils.IsKeyEncrypted(l.SSHKey); ok && err == nil && l.SSHPassphrase == "" {
-		if err := huh.NewInput().
+		return huh.NewInput().
 			Title("ssh-key is encrypted please enter the passphrase: ").
 			Validate(huh.ValidateNotEmpty()).
 			EchoMode(huh.EchoModePassword).
 			Value(&l.SSHPassphrase).
 			WithTheme(theme.GetTheme()).
-			Run(); err != nil {
-			log.Fatal(err)
-		}
+			Run()
 	}
+	return nil
 }
 
 // GetSSHHost returns SSH host name
@@ -0,0 +1,42 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginClientVersionCheck(t *testing.T) {
+	useTempConfig(t)
+
+	login := func(status int, body string) *Login {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/version", r.URL.Path)
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+		return &Login{Name: "test", URL: server.URL, Token: "secret", VersionCheck: true}
+	}
+
+	// a failed version check is classified by its response
+	_, err := login(http.StatusUnauthorized, `{"message":"token is required"}`).Client(t.Context())
+	assert.ErrorIs(t, err, utils.ErrUnauthorized)
+
+	client, err := login(http.StatusOK, `{"version":"1.24.0"}`).Client(t.Context())
+	require.NoError(t, err)
+	assert.NoError(t, client.CheckServerVersionConstraint(">= 1.24"))
+
+	// an unknown version falls back to the oldest supported one
+	client, err = login(http.StatusOK, `{"version":"custom"}`).Client(t.Context())
+	require.NoError(t, err)
+	assert.NoError(t, client.CheckServerVersionConstraint("= 1.11.0"))
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RepoConfigFile is the name of the per repository config file. It holds the
+// same keys as flag_defaults in the global config file, e.g.
+//
+//	login: work
+//	remote: upstream
+//	output: simple
+//	merge_style: squash
+//	pull_base: develop
+//	worktree_dir: ../worktrees
+//	issue_labels: [triage]
+//	issue_assignees: [alice]
+//	fields:
+//	  issues: [index, title, labels]
+//
+// Values are applied with this precedence, highest first: command line flags,
+// environment variables, the nearest .tea.yml, the global config file.
+const RepoConfigFile = ".tea.yml"
+
+var (
+	// repoConfig holds the flag defaults of the loaded .tea.yml, if any
+	repoConfig     FlagDefaults
+	repoConfigPath string
+)
+
+// LoadRepoConfig looks for a .tea.yml in dir and its parent directories, and merges
+// the nearest one over the flag defaults of the global config. It returns the path of
+// the loaded file, or an empty string if none was found.
+// The config loaded by a previous call is dropped in any case.
+func LoadRepoConfig(dir string) (string, error) {
+	repoConfig, repoConfigPath = FlagDefaults{}, ""
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, RepoConfigFile)
+		if exists, _ := utils.FileExist(path); exists {
+			return path, loadRepoConfigFile(path)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+func loadRepoConfigFile(path string) error {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Failed to read repo config file: %s", path)
+	}
+	var defaults FlagDefaults
+	if err := yaml.Unmarshal(bs, &defaults); err != nil {
+		return fmt.Errorf("Failed to parse contents of repo config file %s: %w", path, err)
+	}
+	repoConfig = defaults
+	repoConfigPath = path
+	return nil
+}
+
+// GetRepoConfigPath returns the path of the loaded .tea.yml, or an empty string
+func GetRepoConfigPath() string {
+	return repoConfigPath
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRepoConfig(t *testing.T) {
+	t.Cleanup(func() { repoConfig, repoConfigPath = FlagDefaults{}, "" })
+
+	root := t.TempDir()
+	sub := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(sub, 0o755))
+
+	path, err := LoadRepoConfig(sub)
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, RepoConfigFile), []byte(`
+login: work
+merge_style: squash
+issue_labels: [triage]
+fields:
+  issues: [index, title]
+`), 0o600))
+
+	path, err = LoadRepoConfig(sub)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, RepoConfigFile), path)
+	assert.Equal(t, path, GetRepoConfigPath())
+	assert.Equal(t, "work", repoConfig.Login)
+	assert.Equal(t, []string{"index", "title"}, repoConfig.Fields["issues"])
+
+	// the config of a previous load doesn't leak into a directory without one
+	path, err = LoadRepoConfig(t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, path)
+	assert.Empty(t, GetRepoConfigPath())
+	assert.Empty(t, repoConfig.Login)
+}
+
+func TestFlagDefaultsMerge(t *testing.T) {
+	global := FlagDefaults{
+		Remote:     "origin",
+		Output:     "table",
+		MergeStyle: "merge",
+		Fields:     map[string][]string{"issues": {"index"}, "pulls": {"index"}},
+	}
+	repo := FlagDefaults{
+		MergeStyle:  "squash",
+		IssueLabels: []string{"triage"},
+		Fields:      map[string][]string{"issues": {"index", "title"}},
+	}
+
+	merged := global.merge(repo)
+	assert.Equal(t, "origin", merged.Remote)
+	assert.Equal(t, "table", merged.Output)
+	assert.Equal(t, "squash", merged.MergeStyle)
+	assert.Equal(t, []string{"triage"}, merged.IssueLabels)
+	assert.Equal(t, map[string][]string{"issues": {"index", "title"}, "pulls": {"index"}}, merged.Fields)
+	// the global defaults are left untouched
+	assert.Equal(t, []string{"index"}, global.Fields["issues"])
+}
@@ -0,0 +1,456 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"code.gitea.io/tea/modules/theme"
+
+	"filippo.io/age"
+	"github.com/charmbracelet/huh"
+	"github.com/zalando/go-keyring"
+)
+
+// Names of the secret stores, which hold the token & refresh token of a login
+const (
+	// SecretStoreConfig keeps secrets in plain text in the config file (default)
+	SecretStoreConfig = "config"
+	// SecretStoreKeyring keeps secrets in the OS keyring (Secret Service, macOS keychain, Windows credential manager)
+	SecretStoreKeyring = "keyring"
+	// SecretStoreFile keeps secrets in an age encrypted file next to the config file
+	SecretStoreFile = "file"
+	// SecretStoreCommand passes secrets to the external credential_command
+	SecretStoreCommand = "command"
+)
+
+// SecretStores lists the names of all available secret stores
+var SecretStores = []string{SecretStoreConfig, SecretStoreKeyring, SecretStoreFile, SecretStoreCommand}
+
+// secretsPassphraseEnv may hold the passphrase of the encrypted secrets file
+const secretsPassphraseEnv = "TEA_SECRETS_PASSPHRASE"
+
+// keyringService is the service name under which secrets are stored in the OS keyring
+const keyringService = "tea"
+
+// loginSecrets are the values of a login that are kept in a secret store
+type loginSecrets struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// secretStore stores the secrets of logins outside of the config file.
+// Secrets are referenced by the name of the login.
+type secretStore interface {
+	get(l *Login) (*loginSecrets, error)
+	set(l *Login, s *loginSecrets) error
+	delete(l *Login) error
+}
+
+// getSecretStore returns the secret store with the given name
+func getSecretStore(name string) (secretStore, error) {
+	switch name {
+	case SecretStoreKeyring:
+		return keyringStore{}, nil
+	case SecretStoreFile:
+		return defaultFileStore(), nil
+	case SecretStoreCommand:
+		if len(config.Prefs.CredentialCommand) == 0 {
+			return nil, errors.New("secret store 'command' requires preferences.credential_command to be set")
+		}
+		return commandStore{command: config.Prefs.CredentialCommand}, nil
+	}
+	return nil, fmt.Errorf("unknown secret store '%s', available: %s", name, strings.Join(SecretStores, ", "))
+}
+
+// usesSecretStore checks if the secrets of the login are kept outside of the config file
+func (l *Login) usesSecretStore() bool {
+	return l.SecretStore != "" && l.SecretStore != SecretStoreConfig
+}
+
+// LoadSecrets loads token & refresh token of the login from its secret store,
+// if they are not kept in the config file.
+func (l *Login) LoadSecrets() error {
+	if !l.usesSecretStore() || l.Token != "" {
+		return nil
+	}
+	store, err := getSecretStore(l.SecretStore)
+	if err != nil {
+		return err
+	}
+	s, err := store.get(l)
+	if err != nil {
+		return fmt.Errorf("failed to load token of login '%s' from %s: %w", l.Name, l.SecretStore, err)
+	}
+	l.Token = s.Token
+	l.RefreshToken = s.RefreshToken
+	return nil
+}
+
+// storeSecrets moves token & refresh token of logins using a secret store
+// from the config into their store, so they are not written to the config file.
+func storeSecrets(c *LocalConfig) error {
+	for i := range c.Logins {
+		l := &c.Logins[i]
+		if !l.usesSecretStore() || (l.Token == "" && l.RefreshToken == "") {
+			continue
+		}
+		store, err := getSecretStore(l.SecretStore)
+		if err != nil {
+			return err
+		}
+		if err := store.set(l, &loginSecrets{Token: l.Token, RefreshToken: l.RefreshToken}); err != nil {
+			return fmt.Errorf("failed to store token of login '%s' in %s: %w", l.Name, l.SecretStore, err)
+		}
+		l.Token = ""
+		l.RefreshToken = ""
+	}
+	return nil
+}
+
+// deleteSecrets removes the secrets of a login from its secret store
+func deleteSecrets(l *Login) error {
+	if !l.usesSecretStore() {
+		return nil
+	}
+	store, err := getSecretStore(l.SecretStore)
+	if err != nil {
+		return err
+	}
+	return store.delete(l)
+}
+
+// MigrateSecrets moves the secrets of the named logins (all logins if none are given)
+// into the given secret store, and makes it the default store for new logins.
+// It returns the names of the migrated logins.
+func MigrateSecrets(storeName string, names ...string) ([]string, error) {
+	if err := loadConfig(); err != nil {
+		return nil, err
+	}
+	if storeName != SecretStoreConfig {
+		if _, err := getSecretStore(storeName); err != nil {
+			return nil, err
+		}
+	}
+	for _, n := range names {
+		if !containsFold(loginNames(), n) {
+			return nil, fmt.Errorf("login '%s' not found", n)
+		}
+	}
+
+	var migrated []string
+	for _, l := range slices.Clone(config.Logins) {
+		if len(names) != 0 && !containsFold(names, l.Name) {
+			continue
+		}
+		if l.SecretStore == storeName || (!l.usesSecretStore() && storeName == SecretStoreConfig) {
+			continue
+		}
+		if err := l.LoadSecrets(); err != nil {
+			return migrated, err
+		}
+		err := updateConfig(func(c *LocalConfig) error {
+			for i := range c.Logins {
+				if c.Logins[i].Name == l.Name {
+					c.Logins[i].SecretStore = storeName
+					c.Logins[i].Token = l.Token
+					c.Logins[i].RefreshToken = l.RefreshToken
+					return nil
+				}
+			}
+			return fmt.Errorf("login '%s' not found", l.Name)
+		})
+		if err != nil {
+			return migrated, err
+		}
+		if err := deleteSecrets(&l); err != nil {
+			return migrated, fmt.Errorf("failed to remove token of login '%s' from %s: %w", l.Name, l.SecretStore, err)
+		}
+		migrated = append(migrated, l.Name)
+	}
+
+	if len(names) == 0 {
+		return migrated, updateConfig(func(c *LocalConfig) error {
+			c.Prefs.SecretStore = storeName
+			return nil
+		})
+	}
+	return migrated, nil
+}
+
+func loginNames() []string {
+	names := make([]string, len(config.Logins))
+	for i, l := range config.Logins {
+		names[i] = l.Name
+	}
+	return names
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyringStore keeps secrets in the OS keyring
+type keyringStore struct{}
+
+func (keyringStore) get(l *Login) (*loginSecrets, error) {
+	data, err := keyring.Get(keyringService, l.Name)
+	if err != nil {
+		return nil, err
+	}
+	return parseSecrets([]byte(data))
+}
+
+func (keyringStore) set(l *Login, s *loginSecrets) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return keyring.Set(keyringService, l.Name, string(data))
+}
+
+func (keyringStore) delete(l *Login) error {
+	if err := keyring.Delete(keyringService, l.Name); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// fileStore keeps the secrets of all logins in a passphrase protected age file
+type fileStore struct {
+	path string
+	// passphrase returns the passphrase to encrypt & decrypt the file
+	passphrase func() (string, error)
+
+	mu      sync.Mutex
+	secrets map[string]*loginSecrets
+}
+
+var (
+	fileStoreInstance *fileStore
+	fileStoreOnce     sync.Once
+)
+
+// defaultFileStore returns the file store next to the config file.
+// The passphrase is asked for at most once per invocation.
+func defaultFileStore() *fileStore {
+	fileStoreOnce.Do(func() {
+		var passphrase string
+		fileStoreInstance = &fileStore{
+			path: filepath.Join(filepath.Dir(GetConfigPath()), "secrets.age"),
+			passphrase: func() (string, error) {
+				if passphrase != "" {
+					return passphrase, nil
+				}
+				p, err := askSecretsPassphrase()
+				passphrase = p
+				return p, err
+			},
+		}
+	})
+	return fileStoreInstance
+}
+
+// askSecretsPassphrase reads the passphrase of the secrets file from the environment, or prompts for it
+func askSecretsPassphrase() (string, error) {
+	if p := os.Getenv(secretsPassphraseEnv); p != "" {
+		return p, nil
+	}
+	var passphrase string
+	err := huh.NewInput().
+		Title("Passphrase of the tea secrets file: ").
+		Description("Set $" + secretsPassphraseEnv + " to skip this prompt").
+		Validate(huh.ValidateNotEmpty()).
+		EchoMode(huh.EchoModePassword).
+		Value(&passphrase).
+		WithTheme(theme.GetTheme()).
+		Run()
+	return passphrase, err
+}
+
+// load reads the secrets file, unless it has been read before
+func (f *fileStore) load() error {
+	if f.secrets != nil {
+		return nil
+	}
+	return f.reload()
+}
+
+// reload reads the secrets file again. Secrets are changed in the file as read
+// right before while holding the config lock, so changes of other tea processes
+// aren't overwritten with the secrets read earlier.
+func (f *fileStore) reload() error {
+	f.secrets = map[string]*loginSecrets{}
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	passphrase, err := f.passphrase()
+	if err != nil {
+		return err
+	}
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return err
+	}
+	r, err := age.Decrypt(bytes.NewReader(data), identity)
+	if err != nil {
+		f.secrets = nil
+		return fmt.Errorf("failed to decrypt %s: %w", f.path, err)
+	}
+	if err := json.NewDecoder(r).Decode(&f.secrets); err != nil {
+		f.secrets = nil
+		return fmt.Errorf("failed to parse %s: %w", f.path, err)
+	}
+	return nil
+}
+
+func (f *fileStore) save() error {
+	passphrase, err := f.passphrase()
+	if err != nil {
+		return err
+	}
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipient)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(w).Encode(f.secrets); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return writeFileAtomic(f.path, buf.Bytes(), 0o600)
+}
+
+// forget drops the secrets read, so they are read again on the next access
+func (f *fileStore) forget() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.secrets = nil
+}
+
+func (f *fileStore) get(l *Login) (*loginSecrets, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	s, ok := f.secrets[l.Name]
+	if !ok {
+		return nil, fmt.Errorf("no secrets found in %s", f.path)
+	}
+	return s, nil
+}
+
+func (f *fileStore) set(l *Login, s *loginSecrets) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.reload(); err != nil {
+		return err
+	}
+	f.secrets[l.Name] = s
+	return f.save()
+}
+
+func (f *fileStore) delete(l *Login) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.reload(); err != nil {
+		return err
+	}
+	if _, ok := f.secrets[l.Name]; !ok {
+		return nil
+	}
+	delete(f.secrets, l.Name)
+	return f.save()
+}
+
+// commandStore passes secrets to an external command, which is run through the shell as
+//
+//	<command> get|store|erase
+//
+// with the login in $TEA_LOGIN and $TEA_LOGIN_URL. "store" receives the secrets as JSON
+// object on stdin, "get" prints either such an object or just the token to stdout.
+type commandStore struct {
+	command string
+}
+
+func (c commandStore) run(l *Login, op string, stdin io.Reader) ([]byte, error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.Command(shell, flag, c.command+" "+op)
+	cmd.Env = append(os.Environ(), "TEA_LOGIN="+l.Name, "TEA_LOGIN_URL="+l.URL)
+	cmd.Stdin = stdin
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential_command %s failed: %w", op, err)
+	}
+	return out, nil
+}
+
+func (c commandStore) get(l *Login) (*loginSecrets, error) {
+	out, err := c.run(l, "get", nil)
+	if err != nil {
+		return nil, err
+	}
+	out = bytes.TrimSpace(out)
+	if bytes.HasPrefix(out, []byte("{")) {
+		return parseSecrets(out)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("credential_command returned no token")
+	}
+	return &loginSecrets{Token: string(out)}, nil
+}
+
+func (c commandStore) set(l *Login, s *loginSecrets) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = c.run(l, "store", bytes.NewReader(data))
+	return err
+}
+
+func (c commandStore) delete(l *Login) error {
+	_, err := c.run(l, "erase", nil)
+	return err
+}
+
+func parseSecrets(data []byte) (*loginSecrets, error) {
+	var s loginSecrets
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets: %w", err)
+	}
+	return &s, nil
+}
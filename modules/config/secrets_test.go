@@ -0,0 +1,106 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.age")
+	passphrase := func() (string, error) { return "correct horse", nil }
+	login := &Login{Name: "gitea"}
+
+	store := &fileStore{path: path, passphrase: passphrase}
+	_, err := store.get(login)
+	assert.Error(t, err)
+	require.NoError(t, store.set(login, &loginSecrets{Token: "abc", RefreshToken: "def"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "abc")
+
+	// a fresh store has to decrypt the file
+	store = &fileStore{path: path, passphrase: passphrase}
+	s, err := store.get(login)
+	require.NoError(t, err)
+	assert.Equal(t, &loginSecrets{Token: "abc", RefreshToken: "def"}, s)
+
+	wrong := &fileStore{path: path, passphrase: func() (string, error) { return "wrong", nil }}
+	_, err = wrong.get(login)
+	assert.Error(t, err)
+
+	require.NoError(t, store.delete(login))
+	store = &fileStore{path: path, passphrase: passphrase}
+	_, err = store.get(login)
+	assert.Error(t, err)
+}
+
+func TestFileStoreConcurrentChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.age")
+	passphrase := func() (string, error) { return "correct horse", nil }
+	gitea, codeberg := &Login{Name: "gitea"}, &Login{Name: "codeberg"}
+
+	// two stores stand for two tea processes using the same file
+	first := &fileStore{path: path, passphrase: passphrase}
+	second := &fileStore{path: path, passphrase: passphrase}
+	require.NoError(t, first.set(gitea, &loginSecrets{Token: "old"}))
+	_, err := second.get(gitea)
+	require.NoError(t, err)
+
+	require.NoError(t, first.set(gitea, &loginSecrets{Token: "rotated"}))
+	// changes of the other store are kept
+	require.NoError(t, second.set(codeberg, &loginSecrets{Token: "cb"}))
+	s, err := (&fileStore{path: path, passphrase: passphrase}).get(gitea)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", s.Token)
+
+	// cached secrets are read again after forget
+	_, err = first.get(codeberg)
+	assert.Error(t, err)
+	first.forget()
+	s, err = first.get(codeberg)
+	require.NoError(t, err)
+	assert.Equal(t, "cb", s.Token)
+}
+
+func TestCommandStore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test helper requires a posix shell")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "helper.sh")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+f="$(dirname "$0")/$TEA_LOGIN"
+case "$1" in
+  get) cat "$f" ;;
+  store) cat > "$f" ;;
+  erase) rm -f "$f" ;;
+esac
+`), 0o700))
+
+	store := commandStore{command: script}
+	login := &Login{Name: "gitea", URL: "https://gitea.com"}
+
+	require.NoError(t, store.set(login, &loginSecrets{Token: "abc", RefreshToken: "def"}))
+	s, err := store.get(login)
+	require.NoError(t, err)
+	assert.Equal(t, &loginSecrets{Token: "abc", RefreshToken: "def"}, s)
+
+	// plain tokens are accepted too
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gitea"), []byte("xyz\n"), 0o600))
+	s, err = store.get(login)
+	require.NoError(t, err)
+	assert.Equal(t, &loginSecrets{Token: "xyz"}, s)
+
+	require.NoError(t, store.delete(login))
+	_, err = store.get(login)
+	assert.Error(t, err)
+}
@@ -4,15 +4,17 @@
 package context
 
 import (
+	stdctx "context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
 	"os"
 	"path"
 	"strconv"
 	"strings"
 	"time"
 
+	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/debug"
 	"code.gitea.io/tea/modules/git"
@@ -22,6 +24,7 @@ import (
 	"github.com/charmbracelet/huh"
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
 )
 
 var errNotAGiteaRepo = errors.New("No Gitea login found. You might want to specify --repo (and --login) to work outside of a repository")
@@ -29,44 +32,77 @@ var errNotAGiteaRepo = errors.New("No Gitea login found. You might want to speci
 // TeaContext contains all context derived during command initialization and wraps cli.Context
 type TeaContext struct {
 	*cli.Command
-	Login     *config.Login // config data & client for selected login
-	RepoSlug  string        // <owner>/<repo>, optional
-	Owner     string        // repo owner as derived from context or provided in flag, optional
-	Repo      string        // repo name as derived from context or provided in flag, optional
-	Org       string        // organization name, optional
-	IsGlobal  bool          // true if operating on global level
-	Output    string        // value of output flag
-	LocalRepo *git.TeaRepo  // is set if flags specified a local repo via --repo, or if $PWD is a git repo
+	Ctx       stdctx.Context // is cancelled on interrupt or when --timeout expires
+	Login     *config.Login  // config data & client for selected login
+	RepoSlug  string         // <owner>/<repo>, optional
+	Owner     string         // repo owner as derived from context or provided in flag, optional
+	Repo      string         // repo name as derived from context or provided in flag, optional
+	Org       string         // organization name, optional
+	IsGlobal  bool           // true if operating on global level
+	Output    string         // value of output flag
+	LocalRepo *git.TeaRepo   // is set if flags specified a local repo via --repo, or if $PWD is a git repo
+
+	client *gitea.Client // shared by the contexts of a command run for multiple repositories
+	out    io.Writer     // output of a command run for one of multiple repositories, stdout if nil
 }
 
-// GetRemoteRepoHTMLURL returns the web-ui url of the remote repo,
-// after ensuring a remote repo is present in the context.
+// Out returns the writer to print the output of the command to
+func (ctx *TeaContext) Out() io.Writer {
+	if ctx.out == nil {
+		return os.Stdout
+	}
+	return ctx.out
+}
+
+// Client returns a client for the API of the selected login, bound to the context
+// of the command, so requests are aborted when it is cancelled.
+func (ctx *TeaContext) Client() (*gitea.Client, error) {
+	if ctx.client != nil {
+		return ctx.client, nil
+	}
+	return ctx.Login.Client(ctx.Ctx)
+}
+
+// repoContextKey is the key of the TeaContext of a command run for one of multiple repositories
+type repoContextKey struct{}
+
+// WithRepo returns a copy of stdCtx, for which InitCommand returns a copy of ctx targeting
+// repo, with the given client & output writer. This runs a command for multiple repositories
+// in-process, without resolving the login and asking for its secrets again for each of them.
+func WithRepo(stdCtx stdctx.Context, ctx *TeaContext, client *gitea.Client, repo string, out io.Writer) stdctx.Context {
+	c := *ctx
+	c.RepoSlug = repo
+	c.Owner, c.Repo = utils.GetOwnerAndRepo(repo, c.Login.User)
+	c.LocalRepo = nil
+	c.client = client
+	c.out = out
+	return stdctx.WithValue(stdCtx, repoContextKey{}, &c)
+}
+
+// GetRemoteRepoHTMLURL returns the web-ui url of the remote repo.
+// A remote repo must be present in the context, see Ensure().
 func (ctx *TeaContext) GetRemoteRepoHTMLURL() string {
-	ctx.Ensure(CtxRequirement{RemoteRepo: true})
 	return path.Join(ctx.Login.URL, ctx.Owner, ctx.Repo)
 }
 
-// Ensure checks if requirements on the context are set, and terminates otherwise.
-func (ctx *TeaContext) Ensure(req CtxRequirement) {
+// Ensure checks if requirements on the context are set, and returns an error otherwise.
+func (ctx *TeaContext) Ensure(req CtxRequirement) error {
 	if req.LocalRepo && ctx.LocalRepo == nil {
-		fmt.Println("Local repository required: Execute from a repo dir, or specify a path with --repo.")
-		os.Exit(1)
+		return utils.NewMissingRepoErrorf("Local repository required: Execute from a repo dir, or specify a path with --repo.")
 	}
 
 	if req.RemoteRepo && len(ctx.RepoSlug) == 0 {
-		fmt.Println("Remote repository required: Specify ID via --repo or execute from a local git repo.")
-		os.Exit(1)
+		return utils.NewMissingRepoErrorf("Remote repository required: Specify ID via --repo or execute from a local git repo.")
 	}
 
 	if req.Org && len(ctx.Org) == 0 {
-		fmt.Println("Organization required: Specify organization via --org.")
-		os.Exit(1)
+		return utils.NewMissingRepoErrorf("Organization required: Specify organization via --org.")
 	}
 
 	if req.Global && !ctx.IsGlobal {
-		fmt.Println("Global scope required: Specify --global.")
-		os.Exit(1)
+		return utils.NewValidationErrorf("Global scope required: Specify --global.")
 	}
+	return nil
 }
 
 // CtxRequirement specifies context needed for operation
@@ -85,7 +121,14 @@ type CtxRequirement struct {
 // available the repo slug. It does this by reading the config file for logins, parsing
 // the remotes of the .git repo specified in repoFlag or $PWD, and using overrides from
 // command flags. If a local git repo can't be found, repo slug values are unset.
-func InitCommand(cmd *cli.Command) *TeaContext {
+func InitCommand(ctx stdctx.Context, cmd *cli.Command) (*TeaContext, error) {
+	if repoCtx, ok := ctx.Value(repoContextKey{}).(*TeaContext); ok {
+		c := *repoCtx
+		c.Command = cmd
+		c.Ctx = ctx
+		return &c, nil
+	}
+
 	// these flags are used as overrides to the context detection via local git repo
 	repoFlag := cmd.String("repo")
 	loginFlag := cmd.String("login")
@@ -103,31 +146,51 @@ func InitCommand(cmd *cli.Command) *TeaContext {
 	// check if repoFlag can be interpreted as path to local repo.
 	if len(repoFlag) != 0 {
 		if repoFlagPathExists, err = utils.DirExists(repoFlag); err != nil {
-			log.Fatal(err.Error())
+			return nil, err
 		}
 		if repoFlagPathExists {
 			repoPath = repoFlag
 		}
 	}
 
-	if len(remoteFlag) == 0 {
-		remoteFlag = config.GetPreferences().FlagDefaults.Remote
-	}
-
 	if repoPath == "" {
 		if repoPath, err = os.Getwd(); err != nil {
-			log.Fatal(err.Error())
+			return nil, err
 		}
 	}
 
+	// a .tea.yml in the repo overrides the flag defaults of the global config.
+	// It is looked up from the worktree root of the local repo, if there is one.
+	localRepo, repoErr := git.RepoFromPath(repoPath)
+	configDir := repoPath
+	if repoErr == nil {
+		if wt, err := localRepo.Worktree(); err == nil {
+			configDir = wt.Filesystem.Root()
+		}
+	}
+	if repoConfig, err := config.LoadRepoConfig(configDir); err != nil {
+		return nil, err
+	} else if len(repoConfig) != 0 {
+		debug.Printf("Loaded repo config %s", repoConfig)
+	}
+	flagDefaults := config.GetPreferences().FlagDefaults
+
+	if len(remoteFlag) == 0 {
+		remoteFlag = flagDefaults.Remote
+	}
+
 	// try to read local git repo & extract context: if repoFlag specifies a valid path, read repo in that dir,
 	// otherwise attempt PWD. if no repo is found, continue with default login
-	if c.LocalRepo, c.Login, c.RepoSlug, err = contextFromLocalRepo(repoPath, remoteFlag); err != nil {
-		if err == errNotAGiteaRepo || err == gogit.ErrRepositoryNotExists {
-			// we can deal with that, commands needing the optional values use ctx.Ensure()
-		} else {
-			log.Fatal(err.Error())
+	if repoErr != nil {
+		if repoErr != gogit.ErrRepositoryNotExists {
+			return nil, repoErr
+		}
+		// we can deal with that, commands needing the optional values use ctx.Ensure()
+	} else if c.LocalRepo, c.Login, c.RepoSlug, err = contextFromLocalRepo(localRepo, remoteFlag); err != nil {
+		if err != errNotAGiteaRepo {
+			return nil, err
 		}
+		// we can deal with that, commands needing the optional values use ctx.Ensure()
 	}
 
 	if len(repoFlag) != 0 && !repoFlagPathExists {
@@ -140,65 +203,81 @@ func InitCommand(cmd *cli.Command) *TeaContext {
 	if envLogin != nil {
 		_, err := utils.ValidateAuthenticationMethod(envLogin.URL, envLogin.Token, "", "")
 		if err != nil {
-			log.Fatal(err.Error())
+			return nil, utils.WrapError(utils.ErrValidation, err)
 		}
 		c.Login = envLogin
 	}
 
+	// a configured login takes precedence over the one detected from the git remote
+	if len(loginFlag) == 0 && envLogin == nil {
+		loginFlag = flagDefaults.Login
+	}
+
 	// override login from flag, or use default login if repo based detection failed
 	if len(loginFlag) != 0 {
-		c.Login = config.GetLoginByName(loginFlag)
-		if c.Login == nil {
-			log.Fatalf("Login name '%s' does not exist", loginFlag)
+		if c.Login, err = config.GetLoginByName(loginFlag); err != nil {
+			return nil, err
 		}
 	} else if c.Login == nil {
 		if c.Login, err = config.GetDefaultLogin(); err != nil {
-			if err.Error() == "No available login" {
+			if errors.Is(err, utils.ErrMissingLogin) {
 				// TODO: maybe we can directly start interact.CreateLogin() (only if
 				// we're sure we can interactively!), as gh cli does.
-				fmt.Println(`No gitea login configured. To start using tea, first run
+				return nil, utils.NewMissingLoginErrorf(`No gitea login configured. To start using tea, first run
   tea login add
 and then run your command again.`)
 			}
-			os.Exit(1)
+			return nil, err
 		}
 
-		// Only prompt for confirmation if the fallback login is not explicitly set as default
+		// Only prompt for confirmation if the fallback login is not explicitly set as default.
+		// Without a terminal, e.g. in `tea serve`, we can't ask and don't fall back.
 		if !c.Login.Default {
 			fallback := false
-			if err := huh.NewConfirm().
-				Title(fmt.Sprintf("NOTE: no gitea login detected, whether falling back to login '%s'?", c.Login.Name)).
-				Value(&fallback).
-				WithTheme(theme.GetTheme()).
-				Run(); err != nil {
-				log.Fatalf("Get confirm failed: %v", err)
+			if term.IsTerminal(int(os.Stdin.Fd())) {
+				if err := huh.NewConfirm().
+					Title(fmt.Sprintf("NOTE: no gitea login detected, whether falling back to login '%s'?", c.Login.Name)).
+					Value(&fallback).
+					WithTheme(theme.GetTheme()).
+					Run(); err != nil {
+					return nil, fmt.Errorf("Get confirm failed: %v", err)
+				}
 			}
 			if !fallback {
-				os.Exit(1)
+				return nil, utils.NewMissingLoginErrorf("No gitea login detected for this repository, specify one with --login")
 			}
 		}
 	}
 
+	// logins matched from the git remote don't have their secrets loaded yet
+	if err := c.Login.LoadSecrets(); err != nil {
+		return nil, err
+	}
+
 	// parse reposlug (owner falling back to login owner if reposlug contains only repo name)
 	c.Owner, c.Repo = utils.GetOwnerAndRepo(c.RepoSlug, c.Login.User)
 	c.Org = orgFlag
 	c.IsGlobal = globalFlag
 	c.Command = cmd
+	c.Ctx = ctx
 	c.Output = cmd.String("output")
-	return &c
+	if !cmd.IsSet("output") && len(flagDefaults.Output) != 0 {
+		c.Output = flagDefaults.Output
+	}
+	if jq := cmd.String("jq"); len(jq) != 0 {
+		// the jq expression is applied by the printers, like a template output format
+		c.Output = "jq=" + jq
+	}
+	return &c, nil
 }
 
 // contextFromLocalRepo discovers login & repo slug from the default branch remote of the given local repo
-func contextFromLocalRepo(repoPath, remoteValue string) (*git.TeaRepo, *config.Login, string, error) {
-	repo, err := git.RepoFromPath(repoPath)
-	if err != nil {
-		return nil, nil, "", err
-	}
+func contextFromLocalRepo(repo *git.TeaRepo, remoteValue string) (*git.TeaRepo, *config.Login, string, error) {
 	gitConfig, err := repo.Config()
 	if err != nil {
 		return repo, nil, "", err
 	}
-	debug.Printf("Get git config %v of %s in repo %s", gitConfig, remoteValue, repoPath)
+	debug.Printf("Get git config %v of %s", gitConfig, remoteValue)
 
 	if len(gitConfig.Remotes) == 0 {
 		return repo, nil, "", errNotAGiteaRepo
@@ -240,7 +319,7 @@ func contextFromLocalRepo(repoPath, remoteValue string) (*git.TeaRepo, *config.L
 		return repo, nil, "", fmt.Errorf("remote '%s' not found in this Git repository", remoteValue)
 	}
 
-	debug.Printf("Get remote configurations %v of %s in repo %s", remoteConfig, remoteValue, repoPath)
+	debug.Printf("Get remote configurations %v of %s", remoteConfig, remoteValue)
 
 	logins, err := config.GetLogins()
 	if err != nil {
@@ -265,7 +344,7 @@ func contextFromLocalRepo(repoPath, remoteValue string) (*git.TeaRepo, *config.L
 //	git@gitea.com:owner/repo.git
 func MatchLogins(remoteURL string, logins []config.Login) (*config.Login, string, error) {
 	for _, l := range logins {
-		debug.Printf("Matching remote URL '%s' against %v login", remoteURL, l)
+		debug.Printf("Matching remote URL '%s' against login '%s' (%s)", remoteURL, l.Name, l.URL)
 		sshHost := l.GetSSHHost()
 		atIdx := strings.Index(remoteURL, "@")
 		colonIdx := strings.Index(remoteURL, ":")
@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_MatchLogins(t *testing.T) {
@@ -45,3 +48,13 @@ func Test_MatchLogins(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsure(t *testing.T) {
+	ctx := &TeaContext{}
+	assert.ErrorIs(t, ctx.Ensure(CtxRequirement{RemoteRepo: true}), utils.ErrMissingRepo)
+	assert.ErrorIs(t, ctx.Ensure(CtxRequirement{Org: true}), utils.ErrMissingRepo)
+	assert.ErrorIs(t, ctx.Ensure(CtxRequirement{Global: true}), utils.ErrValidation)
+
+	ctx = &TeaContext{RepoSlug: "owner/repo", Org: "org", IsGlobal: true}
+	assert.NoError(t, ctx.Ensure(CtxRequirement{RemoteRepo: true, Org: true, Global: true}))
+}
@@ -0,0 +1,451 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/urfave/cli/v3"
+)
+
+// redacted replaces credentials & secret values in traces
+const redacted = "REDACTED"
+
+// maxTraceBody is the number of bytes of a body included in traces
+const maxTraceBody = 64 << 10
+
+// minSecretLength is the length of secrets to be replaced wherever they appear.
+// Shorter ones would garble the trace, they are only redacted in secret fields & headers.
+const minSecretLength = 6
+
+var (
+	trace        bool
+	traceHeaders bool
+	traceBody    bool
+	traceFile    string
+)
+
+// traceOutput is where traced requests are logged
+var traceOutput io.Writer = os.Stderr
+
+// IsTracing returns true if HTTP requests are traced, either via --trace,
+// --trace-file or debug mode.
+func IsTracing() bool {
+	return trace || debug || traceFile != ""
+}
+
+// TraceFlags returns the CLI flags to trace HTTP requests
+func TraceFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "trace",
+			Usage: "Log HTTP requests to the Gitea API with status & timing on stderr. Credentials are redacted",
+			Action: func(ctx context.Context, cmd *cli.Command, v bool) error {
+				trace = v
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:  "trace-headers",
+			Usage: "Include headers in traced requests, implies --trace unless --trace-file is given",
+			Action: func(ctx context.Context, cmd *cli.Command, v bool) error {
+				traceHeaders = v
+				trace = trace || (v && !cmd.IsSet("trace-file"))
+				return nil
+			},
+		},
+		&cli.BoolFlag{
+			Name:  "trace-body",
+			Usage: "Include bodies in traced requests, implies --trace unless --trace-file is given",
+			Action: func(ctx context.Context, cmd *cli.Command, v bool) error {
+				traceBody = v
+				trace = trace || (v && !cmd.IsSet("trace-file"))
+				return nil
+			},
+		},
+		&cli.StringFlag{
+			Name:      "trace-file",
+			Usage:     "Write traced requests as HAR (HTTP archive) JSON to the given file",
+			TakesFile: true,
+			Action: func(ctx context.Context, cmd *cli.Command, v string) error {
+				traceFile = v
+				return nil
+			},
+		},
+	}
+}
+
+// TraceTransport is a http.RoundTripper logging the requests it sends and the
+// responses it receives while tracing is enabled.
+// Credentials, tokens, passwords and secret values are always redacted.
+type TraceTransport struct {
+	Next http.RoundTripper
+	// secrets are redacted wherever they appear, e.g. the token of the login
+	secrets []string
+	now     func() time.Time
+}
+
+// NewTraceTransport returns a TraceTransport sending requests via next.
+// The given secrets are redacted wherever they appear in a trace.
+func NewTraceTransport(next http.RoundTripper, secrets ...string) *TraceTransport {
+	t := &TraceTransport{Next: next, now: time.Now}
+	t.secrets = append(t.secrets, secrets...)
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *TraceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !IsTracing() {
+		return t.Next.RoundTrip(req)
+	}
+
+	r := &redactor{secrets: append(requestSecrets(req), t.secrets...)}
+	isSecretsAPI := strings.Contains(req.URL.Path, "/actions/secrets/")
+
+	entry := harEntry{
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         r.url(req.URL),
+			HTTPVersion: req.Proto,
+			Headers:     []harHeader{},
+			QueryString: []harHeader{},
+			HeadersSize: -1,
+			BodySize:    req.ContentLength,
+		},
+		Cache: struct{}{},
+	}
+	if traceHeaders {
+		entry.Request.Headers = r.headers(req.Header)
+		entry.Request.QueryString = r.query(req.URL.Query())
+	}
+	if traceBody && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			body.Close()
+			mimeType := req.Header.Get("Content-Type")
+			entry.Request.PostData = &harContent{
+				MimeType: mimeType,
+				Text:     r.body(data, mimeType, isSecretsAPI),
+				Size:     len(data),
+			}
+		}
+	}
+
+	start := t.now()
+	resp, err := t.Next.RoundTrip(req)
+	wait := t.now().Sub(start)
+
+	entry.StartedDateTime = start
+	entry.Response = harResponse{Headers: []harHeader{}, HeadersSize: -1, BodySize: -1}
+	if err != nil {
+		entry.Response.Error = r.text(err.Error())
+	} else {
+		entry.Response.Status = resp.StatusCode
+		entry.Response.StatusText = strings.TrimSpace(strings.TrimPrefix(resp.Status, fmt.Sprint(resp.StatusCode)))
+		entry.Response.HTTPVersion = resp.Proto
+		entry.Response.BodySize = resp.ContentLength
+		entry.Response.Content.MimeType = resp.Header.Get("Content-Type")
+		entry.Response.Content.Size = int(resp.ContentLength)
+		if traceHeaders {
+			entry.Response.Headers = r.headers(resp.Header)
+		}
+		if location := resp.Header.Get("Location"); location != "" {
+			if u, err := url.Parse(location); err == nil {
+				entry.Response.RedirectURL = r.url(u)
+			}
+		}
+		if traceBody {
+			data, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+			entry.Response.Content.Size = len(data)
+			entry.Response.Content.Text = r.body(data, entry.Response.Content.MimeType, isSecretsAPI)
+		}
+	}
+	// the time until the response headers arrived, bodies are mostly streamed to the caller
+	entry.Time = float64(wait.Microseconds()) / 1000
+	entry.Timings = harTimings{Send: 0, Wait: entry.Time, Receive: 0}
+
+	if trace || debug {
+		logEntry(traceOutput, &entry)
+	}
+	if traceFile != "" {
+		if err := traceLog.add(traceFile, entry); err != nil {
+			fmt.Fprintf(traceOutput, "TRACE: could not write %s: %s\n", traceFile, err)
+		}
+	}
+	return resp, err
+}
+
+// logEntry prints a traced request in a human readable form
+func logEntry(w io.Writer, e *harEntry) {
+	d := time.Duration(e.Time * float64(time.Millisecond)).Round(time.Millisecond)
+	if e.Response.Error != "" {
+		fmt.Fprintf(w, "TRACE: %s %s failed after %s: %s\n", e.Request.Method, e.Request.URL, d, e.Response.Error)
+	} else {
+		fmt.Fprintf(w, "TRACE: %s %s %d %s (%s)\n", e.Request.Method, e.Request.URL, e.Response.Status, e.Response.StatusText, d)
+	}
+	for _, h := range e.Request.Headers {
+		fmt.Fprintf(w, "TRACE: > %s: %s\n", h.Name, h.Value)
+	}
+	if e.Request.PostData != nil && e.Request.PostData.Text != "" {
+		fmt.Fprintf(w, "TRACE: > %s\n", e.Request.PostData.Text)
+	}
+	for _, h := range e.Response.Headers {
+		fmt.Fprintf(w, "TRACE: < %s: %s\n", h.Name, h.Value)
+	}
+	if e.Response.Content.Text != "" {
+		fmt.Fprintf(w, "TRACE: < %s\n", e.Response.Content.Text)
+	}
+}
+
+// harLog collects the traced requests, and writes them to a file in the
+// HTTP Archive format, see http://www.softwareishard.com/blog/har-12-spec/
+type harLog struct {
+	mu      sync.Mutex
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+var traceLog = &harLog{Version: "1.2", Creator: harCreator{Name: "tea"}}
+
+// add appends an entry, and rewrites the file, so it is complete even if tea is interrupted
+func (l *harLog) add(path string, e harEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Entries = append(l.Entries, e)
+	data, err := json.MarshalIndent(struct {
+		Log *harLog `json:"log"`
+	}{l}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	HTTPVersion string      `json:"httpVersion"`
+	Headers     []harHeader `json:"headers"`
+	QueryString []harHeader `json:"queryString"`
+	PostData    *harContent `json:"postData,omitempty"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int64       `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int         `json:"status"`
+	StatusText  string      `json:"statusText"`
+	HTTPVersion string      `json:"httpVersion"`
+	Headers     []harHeader `json:"headers"`
+	Content     harContent  `json:"content"`
+	RedirectURL string      `json:"redirectURL"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int64       `json:"bodySize"`
+	// Error is set if no response was received, e.g. due to a network error
+	Error string `json:"_error,omitempty"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Redact returns the URL and body of a request with credentials & secret values
+// redacted, like in traces. The given secrets are redacted wherever they appear.
+func Redact(req *http.Request, body []byte, secrets ...string) (string, string) {
+	r := &redactor{secrets: append(requestSecrets(req), secrets...)}
+	// the body is redacted first, so secrets found in it are redacted in the URL, too
+	redactedBody := r.body(body, req.Header.Get("Content-Type"), strings.Contains(req.URL.Path, "/actions/secrets/"))
+	return r.url(req.URL), redactedBody
+}
+
+// secretName matches names of headers, query parameters and fields holding secret values
+var secretName = regexp.MustCompile(`(?i)token|passw|secret|passphrase|authorization|cookie|signature|private|credential|^sha1$|(^|[-_])otp($|[-_])`)
+
+// requestSecrets returns the credentials sent with a request, to redact them wherever they appear
+func requestSecrets(req *http.Request) []string {
+	var secrets []string
+	for _, name := range []string{"Authorization", "Proxy-Authorization"} {
+		if v := req.Header.Get(name); v != "" {
+			_, credentials, _ := strings.Cut(v, " ")
+			secrets = append(secrets, credentials)
+		}
+	}
+	if _, password, ok := req.BasicAuth(); ok {
+		secrets = append(secrets, password)
+	}
+	if req.URL.User != nil {
+		if password, ok := req.URL.User.Password(); ok {
+			secrets = append(secrets, password)
+		}
+	}
+	return secrets
+}
+
+// redactor removes secrets from the parts of a traced request
+type redactor struct {
+	secrets []string
+}
+
+// text replaces all known secrets in s
+func (r *redactor) text(s string) string {
+	for _, secret := range r.secrets {
+		if len(secret) >= minSecretLength {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return s
+}
+
+func (r *redactor) url(u *url.URL) string {
+	c := *u
+	if c.User != nil {
+		if _, ok := c.User.Password(); ok {
+			c.User = url.UserPassword(c.User.Username(), redacted)
+		}
+	}
+	if c.RawQuery != "" {
+		q := c.Query()
+		for name := range q {
+			if secretName.MatchString(name) {
+				q.Set(name, redacted)
+			}
+		}
+		c.RawQuery = q.Encode()
+	}
+	return r.text(c.String())
+}
+
+func (r *redactor) headers(h http.Header) []harHeader {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := []harHeader{}
+	for _, name := range names {
+		for _, v := range h[name] {
+			if secretName.MatchString(name) {
+				v = redacted
+			}
+			headers = append(headers, harHeader{Name: name, Value: r.text(v)})
+		}
+	}
+	return headers
+}
+
+func (r *redactor) query(q url.Values) []harHeader {
+	return r.headers(http.Header(q))
+}
+
+// body returns the redacted body as text. Secret fields of JSON & form encoded
+// bodies are redacted, as well as the values of repository & organization secrets.
+func (r *redactor) body(data []byte, contentType string, isSecretsAPI bool) string {
+	if len(data) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(data)); err == nil {
+			for name := range values {
+				if secretName.MatchString(name) {
+					r.secrets = append(r.secrets, values[name]...)
+					values.Set(name, redacted)
+				}
+			}
+			return truncate(r.text(values.Encode()))
+		}
+	case json.Valid(data):
+		var v any
+		if err := json.Unmarshal(data, &v); err == nil {
+			r.json(v, isSecretsAPI)
+			out := &strings.Builder{}
+			enc := json.NewEncoder(out)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(v); err == nil {
+				return truncate(r.text(strings.TrimSuffix(out.String(), "\n")))
+			}
+		}
+	}
+	if !utf8.Valid(data) {
+		return fmt.Sprintf("<%d bytes of binary data>", len(data))
+	}
+	return truncate(r.text(string(data)))
+}
+
+// json replaces the string values of secret fields in a decoded JSON value, and
+// remembers them to redact them elsewhere, too.
+// The value of actions secrets is sent in the data field.
+func (r *redactor) json(v any, isSecretsAPI bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s, ok := value.(string); ok && (secretName.MatchString(key) || (isSecretsAPI && key == "data")) {
+				r.secrets = append(r.secrets, s)
+				v[key] = redacted
+				continue
+			}
+			r.json(value, isSecretsAPI)
+		}
+	case []any:
+		for _, value := range v {
+			r.json(value, isSecretsAPI)
+		}
+	}
+}
+
+func truncate(s string) string {
+	if len(s) <= maxTraceBody {
+		return s
+	}
+	return s[:maxTraceBody] + "...(truncated)"
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "8fe2e3b0c44298fc1c149afbf4c8996fb92427ae"
+
+// enableTrace enables tracing with headers & bodies for a test, and returns the log output
+func enableTrace(t *testing.T, file string) *bytes.Buffer {
+	out := &bytes.Buffer{}
+	prevOutput, prevLog := traceOutput, traceLog
+	t.Cleanup(func() {
+		trace, traceHeaders, traceBody, traceFile = false, false, false, ""
+		traceOutput, traceLog = prevOutput, prevLog
+	})
+	trace, traceHeaders, traceBody, traceFile = true, true, true, file
+	traceOutput = out
+	traceLog = &harLog{Version: "1.2", Creator: harCreator{Name: "tea"}}
+	return out
+}
+
+func newTraceServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "i_like_gitea=abc")
+		w.WriteHeader(http.StatusCreated)
+		// the token is echoed, as done by the endpoint creating tokens
+		_, _ = w.Write([]byte(`{"name":"ci","sha1":"` + testToken + `","scopes":["read:repository"],"note":"uses ` + testToken + `"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTraceRedactsSecrets(t *testing.T) {
+	out := enableTrace(t, "")
+	server := newTraceServer(t)
+	client := &http.Client{Transport: NewTraceTransport(http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/api/v1/repos/o/r/actions/secrets/DEPLOY_KEY?access_token="+testToken,
+		strings.NewReader(`{"data":"hunter2","description":"deploy key"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token "+testToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// the caller still receives the unmodified response
+	assert.Contains(t, string(body), testToken)
+
+	log := out.String()
+	assert.NotContains(t, log, testToken)
+	assert.NotContains(t, log, "hunter2")
+	assert.NotContains(t, log, "i_like_gitea")
+	assert.Contains(t, log, "TRACE: PUT "+server.URL+"/api/v1/repos/o/r/actions/secrets/DEPLOY_KEY?access_token=REDACTED 201 Created (")
+	assert.Contains(t, log, "TRACE: > Authorization: REDACTED\n")
+	assert.Contains(t, log, `TRACE: > {"data":"REDACTED","description":"deploy key"}`)
+	assert.Contains(t, log, `"note":"uses REDACTED"`)
+	assert.Contains(t, log, `"scopes":["read:repository"]`)
+}
+
+func TestTraceFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "trace.har")
+	out := enableTrace(t, file)
+	trace = false
+	server := newTraceServer(t)
+	client := &http.Client{Transport: NewTraceTransport(http.DefaultTransport, "hunter2")}
+
+	resp, err := client.Post(server.URL+"/login/oauth/access_token", "application/x-www-form-urlencoded",
+		strings.NewReader("grant_type=refresh_token&refresh_token=hunter2"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	resp, err = client.Get(server.URL + "/api/v1/version")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// only the file is written without --trace
+	assert.Empty(t, out.String())
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "hunter2")
+	assert.NotContains(t, string(data), testToken)
+
+	var har struct {
+		Log struct {
+			Version string     `json:"version"`
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+	require.NoError(t, json.Unmarshal(data, &har))
+	assert.Equal(t, "1.2", har.Log.Version)
+	require.Len(t, har.Log.Entries, 2)
+
+	e := har.Log.Entries[0]
+	assert.Equal(t, http.MethodPost, e.Request.Method)
+	require.NotNil(t, e.Request.PostData)
+	assert.Equal(t, "grant_type=refresh_token&refresh_token=REDACTED", e.Request.PostData.Text)
+	assert.Equal(t, http.StatusCreated, e.Response.Status)
+	assert.Equal(t, "Created", e.Response.StatusText)
+	assert.Contains(t, e.Response.Content.Text, `"sha1":"REDACTED"`)
+	assert.Equal(t, server.URL+"/api/v1/version", har.Log.Entries[1].Request.URL)
+}
+
+func TestTraceDisabled(t *testing.T) {
+	out := enableTrace(t, "")
+	trace = false
+	server := newTraceServer(t)
+	client := &http.Client{Transport: NewTraceTransport(http.DefaultTransport)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, out.String())
+}
+
+func TestRedactBody(t *testing.T) {
+	r := &redactor{}
+	assert.Equal(t, `{"user":{"login":"x","password":"REDACTED"}}`,
+		r.body([]byte(`{"user": {"login": "x", "password": "secret123"}}`), "application/json", false))
+	// non-string values are kept, e.g. the private flag of repos
+	assert.Equal(t, `{"private":true}`, r.body([]byte(`{"private":true}`), "application/json", false))
+	assert.Equal(t, "<3 bytes of binary data>", r.body([]byte{0xff, 0xfe, 0x00}, "application/octet-stream", false))
+	// short secrets aren't replaced in the text, as that would garble it
+	r.secrets = []string{"t", testToken}
+	assert.Equal(t, "GET https://gitea.com?t=REDACTED", r.text("GET https://gitea.com?t="+testToken))
+}
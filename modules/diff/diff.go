@@ -0,0 +1,132 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package diff splits unified diffs as returned by Gitea into files, for
+// filtering & summarizing them, and computes word diffs of changed lines.
+package diff
+
+import (
+	"path"
+	"strings"
+)
+
+// File is the diff of a single file
+type File struct {
+	// Name is the path of the file after the change, or before if it was deleted
+	Name string
+	// OldName is the path before a rename or copy, and empty otherwise
+	OldName   string
+	Additions int
+	Deletions int
+	Binary    bool
+	// Lines are the lines of the diff, from the "diff --git" header to the last hunk
+	Lines []string
+}
+
+// Parse splits a unified diff into files. Text before the first file header is ignored.
+func Parse(data string) []*File {
+	var files []*File
+	var f *File
+	inHunk := false
+	for _, line := range strings.SplitAfter(data, "\n") {
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "diff --git ") {
+			f = &File{Name: nameFromHeader(line)}
+			files = append(files, f)
+			inHunk = false
+		}
+		if f == nil {
+			continue
+		}
+		f.Lines = append(f.Lines, strings.TrimSuffix(line, "\n"))
+		line = strings.TrimRight(line, "\r\n")
+
+		if inHunk {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				f.Additions++
+			case strings.HasPrefix(line, "-"):
+				f.Deletions++
+			}
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case strings.HasPrefix(line, "+++ "):
+			if name := strings.TrimPrefix(line[4:], "b/"); name != "/dev/null" {
+				f.Name = name
+			}
+		case strings.HasPrefix(line, "--- "):
+			if name := strings.TrimPrefix(line[4:], "a/"); name != "/dev/null" && f.Name == "" {
+				f.Name = name
+			}
+		case strings.HasPrefix(line, "rename from "):
+			f.OldName = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			f.Name = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "copy from "):
+			f.OldName = strings.TrimPrefix(line, "copy from ")
+		case strings.HasPrefix(line, "copy to "):
+			f.Name = strings.TrimPrefix(line, "copy to ")
+		case strings.HasPrefix(line, "Binary files "), line == "GIT binary patch":
+			f.Binary = true
+		}
+	}
+	return files
+}
+
+// nameFromHeader extracts the new path from a "diff --git a/<old> b/<new>" line
+func nameFromHeader(line string) string {
+	line = strings.TrimRight(line, "\r\n")
+	if i := strings.LastIndex(line, " b/"); i >= 0 {
+		return line[i+3:]
+	}
+	return ""
+}
+
+// MatchPath returns true if the file path matches any of the globs, or if no
+// globs are given. Globs without a slash match the base name in any directory
+// like *.go, and a glob matching a directory matches all files in it.
+func MatchPath(file string, globs []string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	for _, g := range globs {
+		g = strings.TrimSuffix(g, "/")
+		if !strings.Contains(g, "/") {
+			if ok, _ := path.Match(g, path.Base(file)); ok {
+				return true
+			}
+		}
+		for dir := file; dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if ok, _ := path.Match(g, dir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Filter returns the files whose old or new path matches any of the globs
+func Filter(files []*File, globs []string) []*File {
+	var matching []*File
+	for _, f := range files {
+		if MatchPath(f.Name, globs) || f.OldName != "" && MatchPath(f.OldName, globs) {
+			matching = append(matching, f)
+		}
+	}
+	return matching
+}
+
+// ValidateGlobs returns an error for malformed globs
+func ValidateGlobs(globs []string) error {
+	for _, g := range globs {
+		if _, err := path.Match(g, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = `diff --git a/cmd/main.go b/cmd/main.go
+index 1111111..2222222 100644
+--- a/cmd/main.go
++++ b/cmd/main.go
+@@ -1,3 +1,4 @@
+ package main
+-var x = 1
++var x = 2
++var y = 3
+ // --- not a header
+diff --git a/docs/old.md b/docs/new.md
+similarity index 90%
+rename from docs/old.md
+rename to docs/new.md
+diff --git a/README.md b/README.md
+deleted file mode 100644
+--- a/README.md
++++ /dev/null
+@@ -1,2 +0,0 @@
+-# tea
+---- a list
+diff --git a/logo.png b/logo.png
+new file mode 100644
+Binary files /dev/null and b/logo.png differ
+`
+
+func TestParse(t *testing.T) {
+	files := Parse(sample)
+	require.Len(t, files, 4)
+
+	assert.Equal(t, "cmd/main.go", files[0].Name)
+	assert.Equal(t, 2, files[0].Additions)
+	assert.Equal(t, 1, files[0].Deletions)
+	assert.Len(t, files[0].Lines, 10)
+
+	assert.Equal(t, "docs/new.md", files[1].Name)
+	assert.Equal(t, "docs/old.md", files[1].OldName)
+
+	assert.Equal(t, "README.md", files[2].Name)
+	assert.Equal(t, 0, files[2].Additions)
+	assert.Equal(t, 2, files[2].Deletions)
+
+	assert.Equal(t, "logo.png", files[3].Name)
+	assert.True(t, files[3].Binary)
+
+	assert.Empty(t, Parse(""))
+}
+
+func TestMatchPath(t *testing.T) {
+	for _, c := range []struct {
+		file  string
+		globs []string
+		match bool
+	}{
+		{"cmd/main.go", nil, true},
+		{"cmd/main.go", []string{"*.go"}, true},
+		{"cmd/main.go", []string{"*.md"}, false},
+		{"cmd/main.go", []string{"cmd"}, true},
+		{"cmd/main.go", []string{"cmd/"}, true},
+		{"cmd/pulls/diff.go", []string{"cmd/*"}, true},
+		{"cmd/pulls/diff.go", []string{"cmd/*.go"}, false},
+		{"modules/cmd/x.go", []string{"cmd/*"}, false},
+		{"README.md", []string{"*.go", "README*"}, true},
+	} {
+		assert.Equal(t, c.match, MatchPath(c.file, c.globs), "%s %v", c.file, c.globs)
+	}
+
+	files := Filter(Parse(sample), []string{"docs/old.md", "*.png"})
+	require.Len(t, files, 2)
+	assert.Equal(t, "docs/new.md", files[0].Name)
+	assert.Equal(t, "logo.png", files[1].Name)
+
+	assert.NoError(t, ValidateGlobs([]string{"*.go", "cmd/[a-z]*"}))
+	assert.Error(t, ValidateGlobs([]string{"cmd/[a-"}))
+}
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []Segment{
+		{Equal, "var x = "},
+		{Removed, "1"},
+		{Added, "2"},
+	}, Words("var x = 1", "var x = 2"))
+
+	assert.Equal(t, []Segment{
+		{Equal, "func "},
+		{Removed, "run"},
+		{Added, "runPulls"},
+		{Equal, "(ctx"},
+		{Added, ", cmd"},
+		{Equal, ")"},
+	}, Words("func run(ctx)", "func runPulls(ctx, cmd)"))
+
+	assert.Equal(t, []Segment{{Added, "new"}}, Words("", "new"))
+	assert.Equal(t, []Segment{{Equal, "same"}}, Words("same", "same"))
+}
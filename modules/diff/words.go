@@ -0,0 +1,88 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package diff
+
+import (
+	"regexp"
+)
+
+// Op is the kind of change of a Segment
+type Op int
+
+// Kinds of changes in a word diff
+const (
+	Equal Op = iota
+	Removed
+	Added
+)
+
+// Segment is a part of a line in a word diff
+type Segment struct {
+	Op   Op
+	Text string
+}
+
+// words splits lines into words, runs of whitespace and single punctuation characters
+var words = regexp.MustCompile(`\w+|\s+|[^\w\s]`)
+
+// maxWordDiffTokens limits the size of lines compared word by word, as the
+// comparison is quadratic. Longer lines are shown as removed & added entirely.
+const maxWordDiffTokens = 1000
+
+// Words returns the word diff between a removed and an added line
+func Words(removed, added string) []Segment {
+	a := words.FindAllString(removed, -1)
+	b := words.FindAllString(added, -1)
+	if len(a) > maxWordDiffTokens || len(b) > maxWordDiffTokens {
+		return merge(nil, []Segment{{Removed, removed}, {Added, added}})
+	}
+
+	// longest common subsequence of the words, lcs[i][j] being the length for a[i:] & b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var segments []Segment
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			segments = merge(segments, []Segment{{Equal, a[i]}})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			segments = merge(segments, []Segment{{Removed, a[i]}})
+			i++
+		default:
+			segments = merge(segments, []Segment{{Added, b[j]}})
+			j++
+		}
+	}
+	return segments
+}
+
+// merge appends segments, joining adjacent segments of the same kind
+func merge(segments, more []Segment) []Segment {
+	for _, s := range more {
+		if s.Text == "" {
+			continue
+		}
+		if n := len(segments); n != 0 && segments[n-1].Op == s.Op {
+			segments[n-1].Text += s.Text
+			continue
+		}
+		segments = append(segments, s)
+	}
+	return segments
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package dryrun implements the global --dry-run mode, in which the requests
+// & git operations changing anything are printed instead of executed.
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"code.gitea.io/tea/modules/debug"
+
+	"github.com/urfave/cli/v3"
+)
+
+// ErrSkipped is returned for git operations not run in dry-run mode. It aborts
+// the remaining steps of a command, which is not considered a failure.
+var ErrSkipped = errors.New("skipped in dry-run mode")
+
+var enabled bool
+
+// output is where skipped operations are printed. It defaults to the current
+// os.Stdout, which may be redirected, e.g. by tea serve.
+var output io.Writer
+
+// Enabled returns true if operations changing anything are skipped
+func Enabled() bool {
+	return enabled
+}
+
+// SetEnabled enables or disables the dry-run mode
+func SetEnabled(on bool) {
+	enabled = on
+}
+
+// CliFlag returns the CLI flag for the dry-run mode
+func CliFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Print the API requests & git operations changing anything instead of running them",
+		Action: func(ctx context.Context, cmd *cli.Command, v bool) error {
+			SetEnabled(v)
+			return nil
+		},
+	}
+}
+
+// Printf prints a skipped operation
+func Printf(format string, args ...any) {
+	w := output
+	if w == nil {
+		w = os.Stdout
+	}
+	fmt.Fprintf(w, "DRY RUN: "+format+"\n", args...)
+}
+
+// Skip prints the operation and returns true in dry-run mode, so the caller
+// skips it. Otherwise it returns false.
+func Skip(format string, args ...any) bool {
+	if enabled {
+		Printf(format, args...)
+	}
+	return enabled
+}
+
+// Transport is a http.RoundTripper, which prints requests changing anything
+// in dry-run mode instead of sending them. Requests reading data are sent.
+// A skipped request gets a successful response without content, so commands
+// sending multiple requests continue, and print all of them.
+type Transport struct {
+	Next http.RoundTripper
+	// secrets are redacted wherever they appear, e.g. the token of the login
+	secrets []string
+}
+
+// NewTransport returns a Transport sending requests via next.
+// The given secrets are redacted wherever they appear in printed requests.
+func NewTransport(next http.RoundTripper, secrets ...string) *Transport {
+	return &Transport{Next: next, secrets: secrets}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case !enabled, req.Method == http.MethodGet, req.Method == http.MethodHead, req.Method == http.MethodOptions:
+		return t.Next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+	}
+
+	rawURL, redactedBody := debug.Redact(req, body, t.secrets...)
+	target := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		target = u.RequestURI()
+	}
+	// uploaded files are not printed
+	if mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type")); strings.HasPrefix(mediaType, "multipart/") {
+		redactedBody = fmt.Sprintf("<%d bytes of %s>", len(body), mediaType)
+	}
+
+	if len(redactedBody) == 0 {
+		Printf("%s %s", req.Method, target)
+	} else {
+		Printf("%s %s %s", req.Method, target, redactedBody)
+	}
+	return skippedResponse(req), nil
+}
+
+// skippedResponse returns the response to a request skipped in dry-run mode:
+// 204 No Content for deletions, and 200 OK otherwise. Its body is the JSON null,
+// which decodes into any result without error, be it an object or a list.
+func skippedResponse(req *http.Request) *http.Response {
+	status, body := http.StatusOK, "null"
+	if req.Method == http.MethodDelete {
+		status, body = http.StatusNoContent, ""
+	}
+	header := http.Header{}
+	if body != "" {
+		header.Set("Content-Type", "application/json")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package dryrun
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "8fe2e3b0c44298fc1c149afbf4c8996fb92427ae"
+
+func enableDryRun(t *testing.T) *bytes.Buffer {
+	out := &bytes.Buffer{}
+	t.Cleanup(func() {
+		enabled, output = false, nil
+	})
+	enabled, output = true, out
+	return out
+}
+
+func TestTransport(t *testing.T) {
+	out := enableDryRun(t)
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport, testToken)}
+
+	// reading requests are sent
+	resp, err := client.Get(server.URL + "/api/v1/repos/o/r/labels")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/repos/o/r/labels", strings.NewReader(`{"name":"bug","color":"#ee0701"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token "+testToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	// skipped requests succeed without content, so the remaining requests are printed too
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+	resp.Body.Close()
+
+	req, err = http.NewRequest(http.MethodPut, server.URL+"/api/v1/repos/o/r/actions/secrets/DEPLOY_KEY", strings.NewReader(`{"data":"hunter2"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile("attachment", "notes.txt")
+	require.NoError(t, err)
+	_, _ = part.Write([]byte("release notes"))
+	require.NoError(t, form.Close())
+	size := body.Len()
+	req, err = http.NewRequest(http.MethodPost, server.URL+"/api/v1/repos/o/r/releases/1/assets?name=notes.txt", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	req, err = http.NewRequest(http.MethodDelete, server.URL+"/api/v1/repos/o/r/labels/1?token="+testToken, nil)
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"GET /api/v1/repos/o/r/labels"}, sent)
+	assert.Equal(t, `DRY RUN: POST /api/v1/repos/o/r/labels {"color":"#ee0701","name":"bug"}
+DRY RUN: PUT /api/v1/repos/o/r/actions/secrets/DEPLOY_KEY {"data":"REDACTED"}
+DRY RUN: POST /api/v1/repos/o/r/releases/1/assets?name=notes.txt <`+strconv.Itoa(size)+` bytes of multipart/form-data>
+DRY RUN: DELETE /api/v1/repos/o/r/labels/1?token=REDACTED
+`, out.String())
+}
+
+func TestTransportDisabled(t *testing.T) {
+	out := enableDryRun(t)
+	enabled = false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodDelete, server.URL+"/api/v1/repos/o/r/labels/1", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, out.String())
+	assert.False(t, Skip("git branch -D %s", "feature"))
+}
+
+func TestSkip(t *testing.T) {
+	out := enableDryRun(t)
+	assert.True(t, Skip("git push %s --delete %s", "origin", "feature"))
+	assert.Equal(t, "DRY RUN: git push origin --delete feature\n", out.String())
+}
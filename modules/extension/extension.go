@@ -0,0 +1,238 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package extension discovers, installs & removes tea extensions: executables
+// named tea-<name>, which are run as `tea <name>`.
+package extension
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"code.gitea.io/tea/modules/git"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/adrg/xdg"
+	gogit "github.com/go-git/go-git/v5"
+)
+
+// Prefix is the prefix of the executable name of an extension
+const Prefix = "tea-"
+
+// Extension is an executable providing a tea subcommand
+type Extension struct {
+	// Name is the name of the subcommand
+	Name string
+	// Path is the path of the executable
+	Path string
+	// Managed is true if the extension is installed in Dir, and can be removed by tea
+	Managed bool
+}
+
+// Dir returns the directory extensions are installed in
+func Dir() string {
+	return filepath.Join(xdg.DataHome, "tea", "extensions")
+}
+
+// Find returns the available extensions sorted by name. Extensions installed in
+// Dir take precedence over executables with the same name on $PATH.
+func Find() []Extension {
+	found := map[string]Extension{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		for _, e := range findInDir(dir) {
+			if _, ok := found[e.Name]; !ok {
+				found[e.Name] = e
+			}
+		}
+	}
+	for _, e := range findManaged() {
+		found[e.Name] = e
+	}
+
+	extensions := make([]Extension, 0, len(found))
+	for _, e := range found {
+		extensions = append(extensions, e)
+	}
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Name < extensions[j].Name
+	})
+	return extensions
+}
+
+// findManaged returns the extensions installed in Dir: either an executable
+// tea-<name>, or a repository tea-<name> containing the executable tea-<name>.
+func findManaged() []Extension {
+	extensions := findInDir(Dir())
+	entries, _ := os.ReadDir(Dir())
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), Prefix) {
+			for _, e := range findInDir(filepath.Join(Dir(), entry.Name())) {
+				if e.Name == strings.TrimPrefix(entry.Name(), Prefix) {
+					extensions = append(extensions, e)
+				}
+			}
+		}
+	}
+	for i := range extensions {
+		extensions[i].Managed = true
+	}
+	return extensions
+}
+
+// findInDir returns the extension executables in dir
+func findInDir(dir string) []Extension {
+	if dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var extensions []Extension
+	for _, entry := range entries {
+		name, ok := extensionName(entry.Name())
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if isExecutable(path) {
+			extensions = append(extensions, Extension{Name: name, Path: path})
+		}
+	}
+	return extensions
+}
+
+// extensionName returns the subcommand name provided by an executable file name
+func extensionName(file string) (string, bool) {
+	name, ok := strings.CutPrefix(file, Prefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, ok && len(name) != 0 && !strings.HasPrefix(name, "-")
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0o111 != 0
+}
+
+// Get returns the extension with the given name
+func Get(name string) (*Extension, error) {
+	for _, e := range Find() {
+		if e.Name == name {
+			return &e, nil
+		}
+	}
+	return nil, utils.NewNotFoundErrorf("extension '%s' is not installed", name)
+}
+
+// Install installs an extension from source, which is either the path to an executable,
+// or the URL of a git repository containing an executable named like the repository.
+// It returns the installed extension.
+func Install(ctx context.Context, source string) (*Extension, error) {
+	if err := os.MkdirAll(Dir(), 0o755); err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Stat(source); err == nil && !info.IsDir() {
+		return installFile(source)
+	}
+
+	url, err := git.ParseURL(source)
+	if err != nil {
+		return nil, err
+	}
+	repoName := strings.TrimSuffix(filepath.Base(url.Path), ".git")
+	name, ok := strings.CutPrefix(repoName, Prefix)
+	if !ok || len(name) == 0 {
+		return nil, utils.NewValidationErrorf("the repository name must start with '%s', got '%s'", Prefix, repoName)
+	}
+	if err := checkNotInstalled(name); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(Dir(), repoName)
+	if _, err := gogit.PlainCloneContext(ctx, dir, false, &gogit.CloneOptions{URL: url.String(), Depth: 1}); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("could not clone %s: %w", source, err)
+	}
+	for _, e := range findInDir(dir) {
+		if e.Name == name {
+			e.Managed = true
+			return &e, nil
+		}
+	}
+	os.RemoveAll(dir)
+	return nil, utils.NewValidationErrorf("the repository does not contain an executable named '%s'", repoName)
+}
+
+// installFile copies an executable into Dir
+func installFile(source string) (*Extension, error) {
+	name, ok := extensionName(filepath.Base(source))
+	if !ok {
+		return nil, utils.NewValidationErrorf("the executable name must start with '%s', got '%s'", Prefix, filepath.Base(source))
+	}
+	if err := checkNotInstalled(name); err != nil {
+		return nil, err
+	}
+
+	in, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	path := filepath.Join(Dir(), filepath.Base(source))
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(out, in); err == nil {
+		err = out.Close()
+	} else {
+		out.Close()
+	}
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	return &Extension{Name: name, Path: path, Managed: true}, nil
+}
+
+func checkNotInstalled(name string) error {
+	for _, e := range findManaged() {
+		if e.Name == name {
+			return utils.NewValidationErrorf("extension '%s' is already installed in %s", name, e.Path)
+		}
+	}
+	return nil
+}
+
+// Remove removes an extension installed in Dir
+func Remove(name string) error {
+	e, err := Get(name)
+	if err != nil {
+		return err
+	}
+	if !e.Managed {
+		return utils.NewValidationErrorf("extension '%s' was not installed by tea, remove %s manually", name, e.Path)
+	}
+	// extensions installed from a repository are removed with the whole repository
+	if dir := filepath.Dir(e.Path); dir != Dir() {
+		return os.RemoveAll(dir)
+	}
+	return os.Remove(e.Path)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package extension
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTempDirs isolates the extension dir & $PATH of a test, and returns a dir in $PATH
+func useTempDirs(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("extensions are detected by their file extension on windows")
+	}
+	t.Cleanup(xdg.Reload)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	xdg.Reload()
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	return binDir
+}
+
+func writeExecutable(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755))
+}
+
+func TestFind(t *testing.T) {
+	binDir := useTempDirs(t)
+	writeExecutable(t, filepath.Join(binDir, "tea-deploy"))
+	writeExecutable(t, filepath.Join(binDir, "tea-lint"))
+	writeExecutable(t, filepath.Join(Dir(), "tea-lint"))
+	writeExecutable(t, filepath.Join(Dir(), "tea-tickets", "tea-tickets"))
+	// not executable, or not an extension
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "tea-readme"), nil, 0o644))
+	writeExecutable(t, filepath.Join(binDir, "tea"))
+
+	assert.Equal(t, []Extension{
+		{Name: "deploy", Path: filepath.Join(binDir, "tea-deploy")},
+		// installed extensions take precedence
+		{Name: "lint", Path: filepath.Join(Dir(), "tea-lint"), Managed: true},
+		{Name: "tickets", Path: filepath.Join(Dir(), "tea-tickets", "tea-tickets"), Managed: true},
+	}, Find())
+}
+
+func TestInstallRemove(t *testing.T) {
+	binDir := useTempDirs(t)
+	source := filepath.Join(t.TempDir(), "tea-deploy")
+	writeExecutable(t, source)
+	writeExecutable(t, filepath.Join(binDir, "tea-lint"))
+
+	ext, err := Install(t.Context(), source)
+	require.NoError(t, err)
+	assert.Equal(t, &Extension{Name: "deploy", Path: filepath.Join(Dir(), "tea-deploy"), Managed: true}, ext)
+	assert.FileExists(t, ext.Path)
+
+	_, err = Install(t.Context(), source)
+	assert.ErrorIs(t, err, utils.ErrValidation)
+	other := filepath.Join(t.TempDir(), "deploy")
+	writeExecutable(t, other)
+	_, err = Install(t.Context(), other)
+	assert.ErrorIs(t, err, utils.ErrValidation)
+
+	require.NoError(t, Remove("deploy"))
+	assert.NoFileExists(t, ext.Path)
+	assert.ErrorIs(t, Remove("deploy"), utils.ErrNotFound)
+	// extensions in $PATH are not managed by tea
+	assert.ErrorIs(t, Remove("lint"), utils.ErrValidation)
+}
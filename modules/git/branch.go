@@ -4,12 +4,14 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
 	git_config "github.com/go-git/go-git/v5/config"
 	git_plumbing "github.com/go-git/go-git/v5/plumbing"
+	git_object "github.com/go-git/go-git/v5/plumbing/object"
 	git_transport "github.com/go-git/go-git/v5/plumbing/transport"
 )
 
@@ -57,11 +59,11 @@ func (r TeaRepo) TeaDeleteLocalBranch(branch *git_config.Branch) error {
 }
 
 // TeaDeleteRemoteBranch removes the given branch on the given remote via git protocol
-func (r TeaRepo) TeaDeleteRemoteBranch(remoteName, remoteBranch string, auth git_transport.AuthMethod) error {
+func (r TeaRepo) TeaDeleteRemoteBranch(ctx context.Context, remoteName, remoteBranch string, auth git_transport.AuthMethod) error {
 	// delete remote branch via git protocol:
 	// an empty source in the refspec means remote deletion to git 🙃
 	refspec := fmt.Sprintf(":%s", git_plumbing.NewBranchReferenceName(remoteBranch))
-	return r.Push(&git.PushOptions{
+	return r.PushContext(ctx, &git.PushOptions{
 		RemoteName: remoteName,
 		RefSpecs:   []git_config.RefSpec{git_config.RefSpec(refspec)},
 		Prune:      true,
@@ -69,6 +71,27 @@ func (r TeaRepo) TeaDeleteRemoteBranch(remoteName, remoteBranch string, auth git
 	})
 }
 
+// TeaPushBranch pushes a local branch to the branch of the same name on the given remote.
+// An existing remote branch is overwritten, as long as it is still at the commit of its
+// remote tracking branch, like `git push --force-with-lease` does.
+func (r TeaRepo) TeaPushBranch(ctx context.Context, remoteName, branch string, auth git_transport.AuthMethod) error {
+	ref := git_plumbing.NewBranchReferenceName(branch)
+	opts := &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []git_config.RefSpec{git_config.RefSpec(fmt.Sprintf("%s:%s", ref, ref))},
+		Auth:       auth,
+	}
+	// the lease can only be checked against an existing remote tracking branch
+	if _, err := r.Reference(git_plumbing.NewRemoteReferenceName(remoteName, branch), true); err == nil {
+		opts.ForceWithLease = &git.ForceWithLease{}
+	}
+	err := r.PushContext(ctx, opts)
+	if err == git.NoErrAlreadyUpToDate {
+		return nil
+	}
+	return err
+}
+
 // TeaFindBranchBySha returns a branch that is at the the given SHA and syncs to the
 // given remote repo.
 func (r TeaRepo) TeaFindBranchBySha(sha, repoURL string) (b *git_config.Branch, err error) {
@@ -247,3 +270,84 @@ func (r TeaRepo) TeaGetCurrentBranchNameAndSHA() (string, string, error) {
 
 	return localHead.Name().Short(), localHead.Hash().String(), nil
 }
+
+// TeaFastForward moves the given local branch forward to the given commit. If the
+// branch is checked out, the worktree is updated as well, which must not have
+// changes. Returns false if the branch already is at that commit.
+func (r TeaRepo) TeaFastForward(branchName string, hash git_plumbing.Hash) (bool, error) {
+	ref, err := r.Reference(git_plumbing.NewBranchReferenceName(branchName), true)
+	if err != nil {
+		return false, err
+	}
+	if ref.Hash() == hash {
+		return false, nil
+	}
+
+	current, err := r.CommitObject(ref.Hash())
+	if err != nil {
+		return false, err
+	}
+	target, err := r.CommitObject(hash)
+	if err != nil {
+		return false, err
+	}
+	if ok, err := current.IsAncestor(target); err != nil {
+		return false, err
+	} else if !ok {
+		return false, fmt.Errorf("local branch '%s' has diverged, can't fast-forward it", branchName)
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return false, err
+	}
+	if head.Name() != ref.Name() {
+		return true, r.Storer.SetReference(git_plumbing.NewHashReference(ref.Name(), hash))
+	}
+
+	tree, err := r.Worktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := tree.Status()
+	if err != nil {
+		return false, err
+	}
+	for path, s := range status {
+		if s.Worktree != git.Untracked && (s.Worktree != git.Unmodified || s.Staging != git.Unmodified) {
+			return false, fmt.Errorf("local branch '%s' is checked out with uncommitted changes to '%s'", branchName, path)
+		}
+	}
+
+	// only the files changed by the fast-forward are reset, as a reset of the
+	// whole worktree would delete untracked files
+	currentTree, err := current.Tree()
+	if err != nil {
+		return false, err
+	}
+	targetTree, err := target.Tree()
+	if err != nil {
+		return false, err
+	}
+	changes, err := git_object.DiffTree(currentTree, targetTree)
+	if err != nil {
+		return false, err
+	}
+	opts := &git.ResetOptions{Commit: hash, Mode: git.HardReset}
+	for _, c := range changes {
+		for _, name := range []string{c.From.Name, c.To.Name} {
+			if name == "" {
+				continue
+			}
+			if s, ok := status[name]; ok && s.Worktree == git.Untracked {
+				return false, fmt.Errorf("untracked file '%s' would be overwritten by fast-forwarding '%s'", name, branchName)
+			}
+			opts.Files = append(opts.Files, name)
+		}
+	}
+	if len(opts.Files) == 0 {
+		// without any files, the whole worktree would be reset
+		opts.Mode = git.SoftReset
+	}
+	return true, tree.Reset(opts)
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	git_plumbing "github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeaFastForward(t *testing.T) {
+	repoPath := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-b", "main")
+	run("commit", "--allow-empty", "-m", "initial")
+	run("branch", "behind")
+	run("branch", "diverged")
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "file"), []byte("new\n"), 0644))
+	run("add", "file")
+	run("commit", "-m", "ahead")
+	target := git_plumbing.NewHash(run("rev-parse", "HEAD"))
+
+	repo, err := RepoFromPath(repoPath)
+	require.NoError(t, err)
+
+	// a branch that isn't checked out
+	updated, err := repo.TeaFastForward("behind", target)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, target.String(), run("rev-parse", "behind"))
+
+	updated, err = repo.TeaFastForward("behind", target)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+
+	run("checkout", "diverged")
+	run("commit", "--allow-empty", "-m", "local")
+	_, err = repo.TeaFastForward("diverged", target)
+	assert.ErrorContains(t, err, "has diverged")
+
+	// the checked out branch updates the worktree, if it has no changes
+	run("checkout", "-b", "checked-out", "main~1")
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "file"), []byte("untracked\n"), 0644))
+	_, err = repo.TeaFastForward("checked-out", target)
+	assert.ErrorContains(t, err, "untracked file 'file' would be overwritten")
+	require.NoError(t, os.Remove(filepath.Join(repoPath, "file")))
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "untracked"), []byte("kept\n"), 0644))
+	updated, err = repo.TeaFastForward("checked-out", target)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.FileExists(t, filepath.Join(repoPath, "file"))
+	assert.FileExists(t, filepath.Join(repoPath, "untracked"))
+
+	run("reset", "--hard", "main~1")
+	run("commit", "--allow-empty", "-m", "unrelated")
+	run("reset", "--soft", "HEAD~1")
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "staged"), []byte("change\n"), 0644))
+	run("add", "staged")
+	_, err = repo.TeaFastForward("checked-out", target)
+	assert.ErrorContains(t, err, "uncommitted changes to 'staged'")
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	git_plumbing "github.com/go-git/go-git/v5/plumbing"
+	git_object "github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// TeaStack returns the linear chain of local branches the given branch is part of,
+// ordered from the branch based on trunk to the top of the stack. The parent of a
+// branch is the closest other branch its tip descends from. Branches that are
+// contained in trunk, locally or on any remote, are not part of a stack.
+func (r TeaRepo) TeaStack(trunk, branch string) ([]string, error) {
+	inTrunk, err := r.trunkHistory(trunk)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect the own commits of each branch, which are not in trunk
+	tips := map[string]git_plumbing.Hash{}
+	own := map[string]map[git_plumbing.Hash]bool{}
+	iter, err := r.Branches()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	err = iter.ForEach(func(ref *git_plumbing.Reference) error {
+		name := ref.Name().Short()
+		if name == trunk {
+			return nil
+		}
+		tip, err := r.CommitObject(ref.Hash())
+		if err != nil {
+			return err
+		}
+		commits := map[git_plumbing.Hash]bool{}
+		err = git_object.NewCommitPreorderIter(tip, inTrunk, nil).ForEach(func(c *git_object.Commit) error {
+			commits[c.Hash] = true
+			return nil
+		})
+		if err != nil || len(commits) == 0 {
+			return err
+		}
+		tips[name] = tip.Hash
+		own[name] = commits
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := own[branch]; !ok {
+		return nil, fmt.Errorf("branch '%s' has no commits that are not in '%s'", branch, trunk)
+	}
+
+	// the closest ancestor of a branch is the one with the most own commits
+	parents := map[string]string{}
+	for name := range own {
+		parent := trunk
+		for candidate := range own {
+			if candidate == name || tips[candidate] == tips[name] || !own[name][tips[candidate]] {
+				continue
+			}
+			if parent == trunk || len(own[candidate]) > len(own[parent]) ||
+				len(own[candidate]) == len(own[parent]) && candidate < parent {
+				parent = candidate
+			}
+		}
+		parents[name] = parent
+	}
+	return linearChain(parents, trunk, branch)
+}
+
+// TeaRebaseStack checks out branch and rebases its commits after upstream onto the
+// given ref. The local branches pointing to rebased commits are moved along.
+func (r TeaRepo) TeaRebaseStack(ctx context.Context, onto, upstream, branch string) error {
+	if err := r.TeaCheckRebaseStack(ctx); err != nil {
+		return err
+	}
+	_, err := r.runGit(ctx, "rebase", "--update-refs", "--onto", onto, upstream, branch)
+	return err
+}
+
+// TeaCheckRebaseStack checks that stacks can be rebased: go-git doesn't support
+// rebasing, and git supports `git rebase --update-refs` since 2.38.
+func (r TeaRepo) TeaCheckRebaseStack(ctx context.Context) error {
+	major, minor, err := r.gitVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if major < 2 || major == 2 && minor < 38 {
+		return fmt.Errorf("restacking branches needs git 2.38 or newer for `git rebase --update-refs`, found git %d.%d", major, minor)
+	}
+	return nil
+}
+
+// TeaCheckoutBranch checks out the given local branch with the git executable
+func (r TeaRepo) TeaCheckoutBranch(ctx context.Context, branch string) error {
+	_, err := r.runGit(ctx, "checkout", branch)
+	return err
+}
+
+var gitVersionRegex = regexp.MustCompile(`^git version (\d+)\.(\d+)`)
+
+// gitVersion returns the major & minor version of the git executable
+func (r TeaRepo) gitVersion(ctx context.Context) (major, minor int, err error) {
+	out, err := r.runGit(ctx, "version")
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseGitVersion(out)
+}
+
+// parseGitVersion parses the output of `git version`, e.g. "git version 2.39.3 (Apple Git-146)"
+func parseGitVersion(out string) (major, minor int, err error) {
+	m := gitVersionRegex.FindStringSubmatch(strings.TrimSpace(out))
+	if m == nil {
+		return 0, 0, fmt.Errorf("unknown git version '%s'", strings.TrimSpace(out))
+	}
+	major, _ = strconv.Atoi(m[1])
+	minor, _ = strconv.Atoi(m[2])
+	return major, minor, nil
+}
+
+// trunkHistory returns the commits of the local trunk branch and its remote tracking branches
+func (r TeaRepo) trunkHistory(trunk string) (map[git_plumbing.Hash]bool, error) {
+	cfg, err := r.Config()
+	if err != nil {
+		return nil, err
+	}
+	isTrunk := map[git_plumbing.ReferenceName]bool{git_plumbing.NewBranchReferenceName(trunk): true}
+	for remote := range cfg.Remotes {
+		isTrunk[git_plumbing.NewRemoteReferenceName(remote, trunk)] = true
+	}
+
+	var tips []git_plumbing.Hash
+	iter, err := r.References()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	err = iter.ForEach(func(ref *git_plumbing.Reference) error {
+		if isTrunk[ref.Name()] && ref.Type() == git_plumbing.HashReference {
+			tips = append(tips, ref.Hash())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(tips) == 0 {
+		return nil, fmt.Errorf("branch '%s' not found locally or on any remote", trunk)
+	}
+
+	history := map[git_plumbing.Hash]bool{}
+	for _, tip := range tips {
+		commit, err := r.CommitObject(tip)
+		if err != nil {
+			return nil, err
+		}
+		// commits seen from another tip are skipped, with their parents
+		err = git_object.NewCommitPreorderIter(commit, history, nil).ForEach(func(c *git_object.Commit) error {
+			history[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return history, nil
+}
+
+// linearChain follows the parents of branch down to trunk, and its children up to
+// the top of the stack. It fails if a branch of the chain has several children.
+func linearChain(parents map[string]string, trunk, branch string) ([]string, error) {
+	children := map[string][]string{}
+	for child, parent := range parents {
+		children[parent] = append(children[parent], child)
+	}
+
+	var chain []string
+	for b := branch; b != trunk; b = parents[b] {
+		chain = append([]string{b}, chain...)
+	}
+	for b := branch; len(children[b]) == 1; {
+		b = children[b][0]
+		chain = append(chain, b)
+	}
+	for _, b := range chain {
+		if len(children[b]) > 1 {
+			sort.Strings(children[b])
+			return nil, fmt.Errorf("branch '%s' has several branches based on it (%s), only linear stacks are supported",
+				b, strings.Join(children[b], ", "))
+		}
+	}
+	return chain, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinearChain(t *testing.T) {
+	parents := map[string]string{
+		"a":     "main",
+		"b":     "a",
+		"c":     "b",
+		"other": "main",
+	}
+	for _, branch := range []string{"a", "b", "c"} {
+		chain, err := linearChain(parents, "main", branch)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, chain)
+	}
+
+	parents["d"] = "a"
+	_, err := linearChain(parents, "main", "c")
+	assert.EqualError(t, err, "branch 'a' has several branches based on it (b, d), only linear stacks are supported")
+	chain, err := linearChain(parents, "main", "other")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other"}, chain)
+}
+
+func TestTeaStack(t *testing.T) {
+	repoPath := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	run("init", "-b", "main")
+	run("commit", "--allow-empty", "-m", "initial")
+	run("checkout", "-b", "merged")
+	run("commit", "--allow-empty", "-m", "merged")
+	run("checkout", "main")
+	run("merge", "--ff-only", "merged")
+	for _, b := range []string{"first", "second", "third"} {
+		run("checkout", "-b", b)
+		run("commit", "--allow-empty", "-m", b)
+	}
+	run("checkout", "-b", "unrelated", "main")
+	run("commit", "--allow-empty", "-m", "unrelated")
+	// only <remote>/main is trunk, not another remote branch ending in /main
+	run("remote", "add", "origin", "https://gitea.example.com/owner/repo.git")
+	run("update-ref", "refs/remotes/origin/release/main", "first")
+
+	repo, err := RepoFromPath(repoPath)
+	require.NoError(t, err)
+
+	stack, err := repo.TeaStack("main", "second")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "third"}, stack)
+
+	stack, err = repo.TeaStack("main", "unrelated")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"unrelated"}, stack)
+
+	_, err = repo.TeaStack("main", "merged")
+	assert.EqualError(t, err, "branch 'merged' has no commits that are not in 'main'")
+	_, err = repo.TeaStack("trunk", "first")
+	assert.EqualError(t, err, "branch 'trunk' not found locally or on any remote")
+}
+
+func TestParseGitVersion(t *testing.T) {
+	for out, want := range map[string][2]int{
+		"git version 2.38.0\n":                 {2, 38},
+		"git version 2.39.3 (Apple Git-146)\n": {2, 39},
+		"git version 2.45.1.windows.1\n":       {2, 45},
+	} {
+		major, minor, err := parseGitVersion(out)
+		assert.NoError(t, err)
+		assert.Equal(t, want, [2]int{major, minor}, out)
+	}
+	_, _, err := parseGitVersion("unknown")
+	assert.Error(t, err)
+}
+
+func TestTeaRebaseStack(t *testing.T) {
+	// the rebase run by tea commits too
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	repoPath := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-b", "main")
+	git("commit", "--allow-empty", "-m", "initial")
+	for _, b := range []string{"first", "second", "third"} {
+		git("checkout", "-b", b)
+		git("commit", "--allow-empty", "-m", b)
+	}
+	// first is squash merged
+	git("checkout", "main")
+	git("commit", "--allow-empty", "-m", "squashed first")
+
+	repo, err := RepoFromPath(repoPath)
+	require.NoError(t, err)
+	if err := repo.TeaCheckRebaseStack(t.Context()); err != nil {
+		t.Skip(err)
+	}
+	require.NoError(t, repo.TeaRebaseStack(t.Context(), "main", "first", "third"))
+	require.NoError(t, repo.TeaCheckoutBranch(t.Context(), "main"))
+
+	// the branches in between are moved along
+	assert.Equal(t, "main", git("branch", "--show-current"))
+	assert.Equal(t, git("rev-parse", "main"), git("rev-parse", "second~1"))
+	assert.Equal(t, git("rev-parse", "second"), git("rev-parse", "third~1"))
+}
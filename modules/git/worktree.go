@@ -0,0 +1,117 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	git_plumbing "github.com/go-git/go-git/v5/plumbing"
+)
+
+// Worktree is a working tree of the repo, as listed by `git worktree list`
+type Worktree struct {
+	Path string
+	Head string
+	// Branch is the short name of the checked out branch, empty if HEAD is detached
+	Branch string
+	Bare   bool
+	// Prunable is set, when the directory of a linked worktree no longer exists
+	Prunable bool
+	// Current is set for the worktree the repo was opened in
+	Current bool
+}
+
+// TeaWorktrees returns the working trees of the repo, the main worktree first.
+// go-git can't handle linked worktrees, so this and the other worktree operations
+// run the git command line client.
+func (r TeaRepo) TeaWorktrees(ctx context.Context) ([]*Worktree, error) {
+	out, err := r.runGit(ctx, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	worktrees := parseWorktrees(out)
+
+	tree, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	root := evalPath(tree.Filesystem.Root())
+	for _, wt := range worktrees {
+		wt.Current = evalPath(wt.Path) == root
+	}
+	return worktrees, nil
+}
+
+// TeaAddWorktree checks out the given local branch in a new linked worktree at path
+func (r TeaRepo) TeaAddWorktree(ctx context.Context, path, branch string) error {
+	_, err := r.runGit(ctx, "worktree", "add", path, branch)
+	return err
+}
+
+// TeaRemoveWorktree removes a linked worktree, which fails if it has local changes.
+// For a worktree whose directory was already deleted, only its entry is removed.
+func (r TeaRepo) TeaRemoveWorktree(ctx context.Context, wt *Worktree) error {
+	args := []string{"worktree", "remove", wt.Path}
+	if wt.Prunable {
+		// there are no local changes to lose, and older git versions refuse to remove it otherwise
+		args = []string{"worktree", "remove", "--force", wt.Path}
+	}
+	_, err := r.runGit(ctx, args...)
+	return err
+}
+
+// runGit runs git in the worktree of the repo, and returns its output.
+// git is killed when ctx is cancelled.
+func (r TeaRepo) runGit(ctx context.Context, args ...string) (string, error) {
+	tree, err := r.Worktree()
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = tree.Filesystem.Root()
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) != 0 {
+		// the last line holds the error, preceded by progress messages
+		lines := strings.Split(strings.TrimSpace(string(exitErr.Stderr)), "\n")
+		return "", fmt.Errorf("git %s failed: %s", args[0], lines[len(lines)-1])
+	}
+	return string(out), err
+}
+
+// parseWorktrees parses the output of `git worktree list --porcelain`
+func parseWorktrees(out string) []*Worktree {
+	var worktrees []*Worktree
+	var wt *Worktree
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "worktree":
+			wt = &Worktree{Path: value}
+			worktrees = append(worktrees, wt)
+		case "HEAD":
+			wt.Head = value
+		case "branch":
+			wt.Branch = git_plumbing.ReferenceName(value).Short()
+		case "bare":
+			wt.Bare = true
+		case "prunable":
+			wt.Prunable = true
+		}
+	}
+	return worktrees
+}
+
+// evalPath resolves symlinks in path, to compare it with paths reported by git
+func evalPath(path string) string {
+	if p, err := filepath.EvalSymlinks(path); err == nil {
+		return p
+	}
+	return filepath.Clean(path)
+}
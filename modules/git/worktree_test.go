@@ -0,0 +1,99 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	out := `worktree /src/tea
+HEAD 9fceb02d0ae598e95dc970b74767f19372d61af8
+branch refs/heads/main
+
+worktree /src/tea.worktrees/pull-12
+HEAD 3b18e512dba79e4c8300dd08aeb37f8e728b8dad
+branch refs/heads/pulls/12
+
+worktree /src/tea.worktrees/detached
+HEAD 3b18e512dba79e4c8300dd08aeb37f8e728b8dad
+detached
+prunable gitdir file points to non-existent location
+
+`
+	assert.Equal(t, []*Worktree{
+		{Path: "/src/tea", Head: "9fceb02d0ae598e95dc970b74767f19372d61af8", Branch: "main"},
+		{Path: "/src/tea.worktrees/pull-12", Head: "3b18e512dba79e4c8300dd08aeb37f8e728b8dad", Branch: "pulls/12"},
+		{Path: "/src/tea.worktrees/detached", Head: "3b18e512dba79e4c8300dd08aeb37f8e728b8dad", Prunable: true},
+	}, parseWorktrees(out))
+}
+
+func TestTeaWorktrees(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	require.NoError(t, os.Mkdir(repoPath, 0o755))
+	run("init", "-b", "main")
+	run("commit", "--allow-empty", "-m", "initial")
+	run("branch", "feature")
+
+	repo, err := RepoFromPath(repoPath)
+	require.NoError(t, err)
+	worktreePath := filepath.Join(dir, "feature")
+	require.NoError(t, repo.TeaAddWorktree(t.Context(), worktreePath, "feature"))
+	assert.ErrorContains(t, repo.TeaAddWorktree(t.Context(), filepath.Join(dir, "other"), "feature"), "is already checked out")
+
+	worktrees, err := repo.TeaWorktrees(t.Context())
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+	assert.Equal(t, "main", worktrees[0].Branch)
+	assert.True(t, worktrees[0].Current)
+	assert.Equal(t, "feature", worktrees[1].Branch)
+	assert.Equal(t, evalPath(worktreePath), evalPath(worktrees[1].Path))
+	assert.False(t, worktrees[1].Current)
+
+	// the worktree is current, when the repo is opened from it
+	linked, err := RepoFromPath(worktreePath)
+	require.NoError(t, err)
+	worktrees, err = linked.TeaWorktrees(t.Context())
+	require.NoError(t, err)
+	assert.False(t, worktrees[0].Current)
+	assert.True(t, worktrees[1].Current)
+
+	require.NoError(t, repo.TeaRemoveWorktree(t.Context(), worktrees[1]))
+	assert.NoDirExists(t, worktreePath)
+	worktrees, err = repo.TeaWorktrees(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, worktrees, 1)
+
+	// removing a worktree whose directory was deleted leaves other such worktrees alone
+	run("branch", "other")
+	require.NoError(t, repo.TeaAddWorktree(t.Context(), worktreePath, "feature"))
+	require.NoError(t, repo.TeaAddWorktree(t.Context(), filepath.Join(dir, "other"), "other"))
+	require.NoError(t, os.RemoveAll(worktreePath))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "other")))
+	worktrees, err = repo.TeaWorktrees(t.Context())
+	require.NoError(t, err)
+	require.Len(t, worktrees, 3)
+	assert.True(t, worktrees[1].Prunable)
+	require.NoError(t, repo.TeaRemoveWorktree(t.Context(), worktrees[1]))
+	worktrees, err = repo.TeaWorktrees(t.Context())
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+	assert.Equal(t, "other", worktrees[1].Branch)
+	assert.True(t, worktrees[1].Prunable)
+}
@@ -12,6 +12,7 @@ import (
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/print"
 	"code.gitea.io/tea/modules/theme"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/charmbracelet/huh"
 	"golang.org/x/term"
@@ -22,12 +23,15 @@ import (
 func ShowCommentsMaybeInteractive(ctx *context.TeaContext, idx int64, totalComments int) error {
 	if ctx.Bool("comments") {
 		opts := gitea.ListIssueCommentOptions{ListOptions: flags.GetListOptions()}
-		c := ctx.Login.Client()
-		comments, _, err := c.ListIssueComments(ctx.Owner, ctx.Repo, idx, opts)
+		c, err := ctx.Client()
 		if err != nil {
 			return err
 		}
-		print.Comments(comments)
+		comments, err := utils.APIResult(c.ListIssueComments(ctx.Owner, ctx.Repo, idx, opts))
+		if err != nil {
+			return err
+		}
+		print.Comments(ctx.Out(), comments)
 	} else if print.IsInteractive() && !ctx.IsSet("comments") {
 		// if we're interactive, but --comments hasn't been explicitly set to false
 		if err := ShowCommentsPaginated(ctx, idx, totalComments); err != nil {
@@ -39,7 +43,10 @@ func ShowCommentsMaybeInteractive(ctx *context.TeaContext, idx int64, totalComme
 
 // ShowCommentsPaginated prompts if issue/pr comments should be shown and continues to do so.
 func ShowCommentsPaginated(ctx *context.TeaContext, idx int64, totalComments int) error {
-	c := ctx.Login.Client()
+	c, err := ctx.Client()
+	if err != nil {
+		return err
+	}
 	opts := gitea.ListIssueCommentOptions{ListOptions: flags.GetListOptions()}
 	prompt := "show comments?"
 	commentsLoaded := 0
@@ -58,10 +65,10 @@ func ShowCommentsPaginated(ctx *context.TeaContext, idx int64, totalComments int
 		} else if !loadComments {
 			break
 		} else {
-			if comments, _, err := c.ListIssueComments(ctx.Owner, ctx.Repo, idx, opts); err != nil {
+			if comments, err := utils.APIResult(c.ListIssueComments(ctx.Owner, ctx.Repo, idx, opts)); err != nil {
 				return err
 			} else if len(comments) != 0 {
-				print.Comments(comments)
+				print.Comments(ctx.Out(), comments)
 				commentsLoaded += len(comments)
 			}
 			if commentsLoaded >= totalComments {
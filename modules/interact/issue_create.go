@@ -4,12 +4,17 @@
 package interact
 
 import (
+	stdctx "context"
+	"slices"
 	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/templates"
 	"code.gitea.io/tea/modules/theme"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/charmbracelet/huh"
 )
@@ -20,27 +25,50 @@ func IsQuitting(err error) bool {
 }
 
 // CreateIssue interactively creates an issue
-func CreateIssue(login *config.Login, owner, repo string) error {
-	owner, repo, err := promptRepoSlug(owner, repo)
+func CreateIssue(ctx *context.TeaContext) error {
+	owner, repo, err := promptRepoSlug(ctx.Owner, ctx.Repo)
 	if err != nil {
 		return err
 	}
 	printTitleAndContent("Target repo:", owner+"/"+repo)
 
-	var opts gitea.CreateIssueOption
-	if err := promptIssueProperties(login, owner, repo, &opts); err != nil {
+	tmpl, err := promptIssueTemplate(ctx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	// labels & assignees configured as flag defaults or in the template are preselected
+	defaults := config.GetPreferences().FlagDefaults
+	opts := gitea.CreateIssueOption{Assignees: defaults.IssueAssignees}
+	if err := promptIssueProperties(ctx.Ctx, ctx.Login, owner, repo, &opts, defaults.IssueLabels, tmpl); err != nil {
 		return err
 	}
 
-	return task.CreateIssue(login, owner, repo, opts)
+	return task.CreateIssue(ctx, owner, repo, opts)
 }
 
-func promptIssueProperties(login *config.Login, owner, repo string, o *gitea.CreateIssueOption) error {
+// promptIssueProperties prompts for the properties of a new issue or PR.
+// Assignees already set in o and the given labels are preselected, as are the
+// title, content, labels & assignees of the template, if not nil.
+func promptIssueProperties(ctx stdctx.Context, login *config.Login, owner, repo string, o *gitea.CreateIssueOption, labelNames []string, tmpl *templates.Template) error {
 	var milestoneName string
 	var err error
 
 	selectableChan := make(chan (issueSelectables), 1)
-	go fetchIssueSelectables(login, owner, repo, selectableChan)
+	go fetchIssueSelectables(ctx, login, owner, repo, selectableChan)
+
+	if tmpl != nil {
+		if !strings.HasPrefix(o.Title, tmpl.Title) {
+			o.Title = tmpl.Title + o.Title
+		}
+		o.Body = tmpl.Body
+		for _, a := range tmpl.Assignees {
+			if !slices.Contains(o.Assignees, a) {
+				o.Assignees = append(o.Assignees, a)
+			}
+		}
+		labelNames = append(slices.Clip(labelNames), tmpl.Labels...)
+	}
 
 	// title
 	if err := huh.NewInput().
@@ -53,8 +81,13 @@ func promptIssueProperties(login *config.Login, owner, repo string, o *gitea.Cre
 	}
 	printTitleAndContent("Issue title:", o.Title)
 
-	// description
-	if err := huh.NewForm(
+	// description: issue forms are filled in field by field
+	if tmpl != nil && tmpl.IsForm() {
+		if err := promptTemplateFields(tmpl); err != nil {
+			return err
+		}
+		o.Body = tmpl.RenderBody()
+	} else if err := huh.NewForm(
 		huh.NewGroup(
 			huh.NewText().
 				Title("Issue description(markdown):").
@@ -80,7 +113,7 @@ func promptIssueProperties(login *config.Login, owner, repo string, o *gitea.Cre
 	}
 
 	// assignees
-	if o.Assignees, err = promptMultiSelect("Assignees:", selectables.Assignees, "[other]"); err != nil {
+	if o.Assignees, err = promptMultiSelect("Assignees:", selectables.Assignees, "[other]", o.Assignees); err != nil {
 		return err
 	}
 	printTitleAndContent("Assignees:", strings.Join(o.Assignees, "\n"))
@@ -102,6 +135,11 @@ func promptIssueProperties(login *config.Login, owner, repo string, o *gitea.Cre
 			options = append(options, huh.Option[int64]{Key: l, Value: selectables.LabelMap[l]})
 			labelsMap[selectables.LabelMap[l]] = l
 		}
+		for _, name := range labelNames {
+			if id, ok := selectables.LabelMap[name]; ok {
+				o.Labels = append(o.Labels, id)
+			}
+		}
 		if err := huh.NewMultiSelect[int64]().
 			Title("Labels:").
 			Options(options...).
@@ -139,12 +177,17 @@ type issueSelectables struct {
 	Err           error
 }
 
-func fetchIssueSelectables(login *config.Login, owner, repo string, done chan issueSelectables) {
+func fetchIssueSelectables(ctx stdctx.Context, login *config.Login, owner, repo string, done chan issueSelectables) {
 	// TODO PERF make these calls concurrent
 	r := issueSelectables{}
-	c := login.Client()
+	c, err := login.Client(ctx)
+	if err != nil {
+		r.Err = err
+		done <- r
+		return
+	}
 
-	r.Repo, _, r.Err = c.GetRepo(owner, repo)
+	r.Repo, r.Err = utils.APIResult(c.GetRepo(owner, repo))
 	if r.Err != nil {
 		done <- r
 		return
@@ -156,7 +199,7 @@ func fetchIssueSelectables(login *config.Login, owner, repo string, done chan is
 		return
 	}
 
-	assignees, _, err := c.GetAssignees(owner, repo)
+	assignees, err := utils.APIResult(c.GetAssignees(owner, repo))
 	if err != nil {
 		r.Err = err
 		done <- r
@@ -167,7 +210,7 @@ func fetchIssueSelectables(login *config.Login, owner, repo string, done chan is
 		r.Assignees[i] = u.UserName
 	}
 
-	milestones, _, err := c.ListRepoMilestones(owner, repo, gitea.ListMilestoneOption{})
+	milestones, err := utils.APIResult(c.ListRepoMilestones(owner, repo, gitea.ListMilestoneOption{}))
 	if err != nil {
 		r.Err = err
 		done <- r
@@ -180,9 +223,9 @@ func fetchIssueSelectables(login *config.Login, owner, repo string, done chan is
 		r.MilestoneList[i] = m.Title
 	}
 
-	labels, _, err := c.ListRepoLabels(owner, repo, gitea.ListLabelsOptions{
+	labels, err := utils.APIResult(c.ListRepoLabels(owner, repo, gitea.ListLabelsOptions{
 		ListOptions: gitea.ListOptions{Page: -1},
-	})
+	}))
 	if err != nil {
 		r.Err = err
 		done <- r
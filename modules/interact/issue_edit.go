@@ -11,6 +11,7 @@ import (
 	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/task"
 	"code.gitea.io/tea/modules/theme"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/charmbracelet/huh"
 )
@@ -26,8 +27,11 @@ func EditIssue(ctx context.TeaContext, index int64) (*task.EditIssueOption, erro
 	}
 	printTitleAndContent("Target repo:", ctx.Owner+"/"+ctx.Repo)
 
-	c := ctx.Login.Client()
-	i, _, err := c.GetIssue(ctx.Owner, ctx.Repo, index)
+	c, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
+	i, err := utils.APIResult(c.GetIssue(ctx.Owner, ctx.Repo, index))
 	if err != nil {
 		return &opts, err
 	}
@@ -68,7 +72,7 @@ func promptIssueEditProperties(ctx *context.TeaContext, o *task.EditIssueOption)
 	var err error
 
 	selectableChan := make(chan (issueSelectables), 1)
-	go fetchIssueSelectables(ctx.Login, ctx.Owner, ctx.Repo, selectableChan)
+	go fetchIssueSelectables(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, selectableChan)
 
 	// title
 	if err := huh.NewInput().
@@ -118,7 +122,7 @@ func promptIssueEditProperties(ctx *context.TeaContext, o *task.EditIssueOption)
 	}
 
 	// assignees
-	if o.AddAssignees, err = promptMultiSelect("Add Assignees:", newAssignees, "[other]"); err != nil {
+	if o.AddAssignees, err = promptMultiSelect("Add Assignees:", newAssignees, "[other]", nil); err != nil {
 		return err
 	}
 	printTitleAndContent("Assignees:", strings.Join(o.AddAssignees, "\n"))
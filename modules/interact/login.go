@@ -4,6 +4,7 @@
 package interact
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -21,7 +22,7 @@ import (
 )
 
 // CreateLogin create an login interactive
-func CreateLogin() error {
+func CreateLogin(ctx context.Context) error {
 	var (
 		name, token, user, passwd, otp, scopes, sshKey, sshCertPrincipal, sshKeyFingerprint string
 		insecure, sshAgent, versionCheck, helper                                            bool
@@ -103,7 +104,7 @@ func CreateLogin() error {
 		}
 		printTitleAndContent("Allow Insecure connections:", strconv.FormatBool(insecure))
 
-		return auth.OAuthLoginWithOptions(name, giteaURL, insecure)
+		return auth.OAuthLoginWithOptions(ctx, name, giteaURL, insecure)
 	case "token":
 		var hasToken bool
 		if err := huh.NewConfirm().
@@ -258,7 +259,7 @@ func CreateLogin() error {
 		printTitleAndContent("Check version of Gitea instance:", strconv.FormatBool(versionCheck))
 	}
 
-	return task.CreateLogin(name, token, user, passwd, otp, scopes, sshKey, giteaURL, sshCertPrincipal, sshKeyFingerprint, insecure, sshAgent, versionCheck, helper)
+	return task.CreateLogin(ctx, name, token, user, passwd, otp, scopes, sshKey, giteaURL, sshCertPrincipal, sshKeyFingerprint, insecure, sshAgent, versionCheck, helper)
 }
 
 var tokenScopeOpts = []string{
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
 	"code.gitea.io/tea/modules/task"
 	"code.gitea.io/tea/modules/theme"
 
@@ -16,11 +17,11 @@ import (
 )
 
 // CreateMilestone interactively creates a milestone
-func CreateMilestone(login *config.Login, owner, repo string) error {
+func CreateMilestone(ctx *context.TeaContext) error {
 	var title, description, deadline string
 
 	// owner, repo
-	owner, repo, err := promptRepoSlug(owner, repo)
+	owner, repo, err := promptRepoSlug(ctx.Owner, ctx.Repo)
 	if err != nil {
 		return err
 	}
@@ -60,7 +61,7 @@ func CreateMilestone(login *config.Login, owner, repo string) error {
 	}
 
 	return task.CreateMilestone(
-		login,
+		ctx,
 		owner,
 		repo,
 		title,
@@ -90,8 +90,8 @@ func promptDatetime(prompt string) (val *time.Time, err error) {
 }
 
 // promptSelect creates a generic multiselect prompt, with processing of custom values.
-func promptMultiSelect(prompt string, options []string, customVal string) ([]string, error) {
-	var selection []string
+func promptMultiSelect(prompt string, options []string, customVal string, selected []string) ([]string, error) {
+	selection := selected
 	if err := huh.NewMultiSelect[string]().
 		Title(prompt).
 		Options(huh.NewOptions(makeSelectOpts(options, customVal, "")...)...).
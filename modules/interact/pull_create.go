@@ -24,7 +24,7 @@ func CreatePull(ctx *context.TeaContext) (err error) {
 	}
 
 	// base
-	if base, err = task.GetDefaultPRBase(ctx.Login, ctx.Owner, ctx.Repo); err != nil {
+	if base, err = task.GetDefaultPRBase(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo); err != nil {
 		return err
 	}
 
@@ -63,8 +63,13 @@ func CreatePull(ctx *context.TeaContext) (err error) {
 
 	head = task.GetHeadSpec(headOwner, headBranch, ctx.Owner)
 
+	tmpl, err := promptPullTemplate(ctx, ctx.Owner, ctx.Repo)
+	if err != nil {
+		return err
+	}
+
 	opts := gitea.CreateIssueOption{Title: task.GetDefaultPRTitle(head)}
-	if err = promptIssueProperties(ctx.Login, ctx.Owner, ctx.Repo, &opts); err != nil {
+	if err = promptIssueProperties(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, &opts, nil, tmpl); err != nil {
 		return err
 	}
 
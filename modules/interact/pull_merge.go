@@ -32,8 +32,8 @@ func MergePull(ctx *context.TeaContext) error {
 		return err
 	}
 
-	return task.PullMerge(ctx.Login, ctx.Owner, ctx.Repo, idx, gitea.MergePullRequestOption{
-		Style:   gitea.MergeStyle(ctx.String("style")),
+	return task.PullMerge(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, idx, gitea.MergePullRequestOption{
+		Style:   flags.GetMergeStyle(ctx),
 		Title:   ctx.String("title"),
 		Message: ctx.String("message"),
 	})
@@ -41,7 +41,10 @@ func MergePull(ctx *context.TeaContext) error {
 
 // getPullIndex interactively determines the PR index
 func getPullIndex(ctx *context.TeaContext, branch string) (int64, error) {
-	c := ctx.Login.Client()
+	c, err := ctx.Client()
+	if err != nil {
+		return 0, err
+	}
 	opts := gitea.ListPullRequestsOptions{
 		State:       gitea.StateOpen,
 		ListOptions: flags.GetListOptions(),
@@ -51,9 +54,8 @@ func getPullIndex(ctx *context.TeaContext, branch string) (int64, error) {
 
 	// paginated fetch
 	var prs []*gitea.PullRequest
-	var err error
 	for {
-		prs, _, err = c.ListRepoPullRequests(ctx.Owner, ctx.Repo, opts)
+		prs, err = utils.APIResult(c.ListRepoPullRequests(ctx.Owner, ctx.Repo, opts))
 		if len(prs) == 0 {
 			return 0, fmt.Errorf("No open PRs found")
 		}
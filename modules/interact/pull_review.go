@@ -77,7 +77,8 @@ func ReviewPull(ctx *context.TeaContext, idx int64) error {
 	}
 	printTitleAndContent("Concluding comment(markdown):", comment)
 
-	return task.CreatePullReview(ctx, idx, state, comment, codeComments)
+	_, err = task.CreatePullReview(ctx, idx, state, comment, codeComments)
+	return err
 }
 
 // DoDiffReview (1) fetches & saves diff in tempfile, (2) starts $VISUAL or $EDITOR to comment on diff,
@@ -0,0 +1,172 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package interact
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/templates"
+	"code.gitea.io/tea/modules/theme"
+
+	"github.com/charmbracelet/huh"
+)
+
+// promptIssueTemplate lets the user pick one of the issue templates of the
+// repository. It returns nil if there are none, or a blank issue was chosen.
+func promptIssueTemplate(ctx *context.TeaContext, owner, repo string) (*templates.Template, error) {
+	src, err := task.TemplateSource(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	issueTemplates, err := templates.LoadIssueTemplates(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(issueTemplates.Templates) == 0 {
+		return nil, nil
+	}
+
+	options := make([]huh.Option[*templates.Template], 0, len(issueTemplates.Templates)+1)
+	for _, t := range issueTemplates.Templates {
+		key := t.Name
+		if len(t.About) != 0 {
+			key += " - " + t.About
+		}
+		options = append(options, huh.NewOption(key, t))
+	}
+	if issueTemplates.BlankIssuesEnabled {
+		options = append(options, huh.NewOption[*templates.Template]("[blank issue]", nil))
+	}
+
+	var selected *templates.Template
+	if err := huh.NewSelect[*templates.Template]().
+		Title("Template:").
+		Options(options...).
+		Value(&selected).
+		WithTheme(theme.GetTheme()).
+		Run(); err != nil {
+		return nil, err
+	}
+	if selected == nil {
+		printTitleAndContent("Template:", "[blank issue]")
+	} else {
+		printTitleAndContent("Template:", selected.Name)
+	}
+	return selected, nil
+}
+
+// promptPullTemplate returns the pull request template of the repository, or nil if it has none
+func promptPullTemplate(ctx *context.TeaContext, owner, repo string) (*templates.Template, error) {
+	src, err := task.TemplateSource(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	t, err := templates.LoadPullTemplate(src)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	printTitleAndContent("Template:", t.File)
+	return t, nil
+}
+
+// promptTemplateFields prompts for the fields of an issue form, one group
+// per field, preceded by the descriptive markdown of the form
+func promptTemplateFields(t *templates.Template) error {
+	var groups []*huh.Group
+	var fields []huh.Field
+	// single-choice dropdowns are bound to a string, and copied to Selected after the form is done
+	choices := map[*templates.Field]*string{}
+
+	for _, f := range t.Fields {
+		switch f.Type {
+		case templates.FieldMarkdown:
+			fields = append(fields, huh.NewNote().Description(f.Value))
+			continue
+		case templates.FieldInput:
+			fields = append(fields, huh.NewInput().
+				Title(f.Label).
+				Description(f.Description).
+				Placeholder(f.Placeholder).
+				Value(&f.Value).
+				Validate(validateRequired(f.Required)))
+		case templates.FieldTextarea:
+			fields = append(fields, huh.NewText().
+				Title(f.Label).
+				Description(f.Description).
+				Placeholder(f.Placeholder).
+				ExternalEditor(config.GetPreferences().Editor).
+				EditorExtension("md").
+				Value(&f.Value).
+				Validate(validateRequired(f.Required)))
+		case templates.FieldDropdown:
+			if f.Multiple {
+				required := f.Required
+				fields = append(fields, huh.NewMultiSelect[string]().
+					Title(f.Label).
+					Description(f.Description).
+					Options(huh.NewOptions(f.Options...)...).
+					Value(&f.Selected).
+					Validate(func(s []string) error {
+						if required && len(s) == 0 {
+							return fmt.Errorf("select at least one option")
+						}
+						return nil
+					}))
+			} else {
+				choice := ""
+				if len(f.Selected) != 0 {
+					choice = f.Selected[0]
+				}
+				choices[f] = &choice
+				fields = append(fields, huh.NewSelect[string]().
+					Title(f.Label).
+					Description(f.Description).
+					Options(huh.NewOptions(f.Options...)...).
+					Value(&choice))
+			}
+		case templates.FieldCheckboxes:
+			requiredOptions := f.RequiredOptions
+			fields = append(fields, huh.NewMultiSelect[string]().
+				Title(f.Label).
+				Description(f.Description).
+				Options(huh.NewOptions(f.Options...)...).
+				Value(&f.Selected).
+				Validate(func(s []string) error {
+					for _, o := range requiredOptions {
+						if !slices.Contains(s, o) {
+							return fmt.Errorf("required: %s", o)
+						}
+					}
+					return nil
+				}))
+		}
+		groups = append(groups, huh.NewGroup(fields...))
+		fields = nil
+	}
+	if len(fields) != 0 {
+		groups = append(groups, huh.NewGroup(fields...))
+	}
+
+	if err := huh.NewForm(groups...).WithTheme(theme.GetTheme()).Run(); err != nil {
+		return err
+	}
+	for f, choice := range choices {
+		f.Selected = []string{*choice}
+	}
+	return nil
+}
+
+func validateRequired(required bool) func(string) error {
+	return func(s string) error {
+		if required && len(strings.TrimSpace(s)) == 0 {
+			return fmt.Errorf("this field is required")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"maps"
+
+	"code.gitea.io/tea/modules/utils"
+)
+
+// Schema is the subset of JSON schema used to describe tool arguments
+type Schema struct {
+	Type        string            `json:"type"`
+	Description string            `json:"description,omitempty"`
+	Enum        []string          `json:"enum,omitempty"`
+	Items       *Schema           `json:"items,omitempty"`
+	Properties  map[string]Schema `json:"properties,omitempty"`
+	Required    []string          `json:"required,omitempty"`
+}
+
+// object returns the schema of an object with the given properties
+func object(properties map[string]Schema, required ...string) Schema {
+	return Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// with returns a copy of properties extended by more
+func with(properties map[string]Schema, more map[string]Schema) map[string]Schema {
+	merged := maps.Clone(properties)
+	maps.Copy(merged, more)
+	return merged
+}
+
+func str(description string, enum ...string) Schema {
+	return Schema{Type: "string", Description: description, Enum: enum}
+}
+
+func integer(description string) Schema {
+	return Schema{Type: "integer", Description: description}
+}
+
+func boolean(description string) Schema {
+	return Schema{Type: "boolean", Description: description}
+}
+
+// newTool returns a tool, whose arguments are decoded into A before calling call
+func newTool[A any](name, description string, schema Schema, call func(context.Context, A) (any, error)) Tool {
+	return Tool{
+		Name:        name,
+		Description: description,
+		InputSchema: schema,
+		Call: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args A
+			dec := json.NewDecoder(bytes.NewReader(raw))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&args); err != nil {
+				return nil, utils.NewValidationErrorf("invalid arguments for %s: %v", name, err)
+			}
+			return call(ctx, args)
+		},
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package mcp implements a JSON-RPC 2.0 server speaking the Model Context Protocol
+// over newline delimited messages, which exposes tea operations as tools.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"code.gitea.io/tea/modules/debug"
+	"code.gitea.io/tea/modules/utils"
+)
+
+// ProtocolVersion is the latest MCP version supported by the server
+const ProtocolVersion = "2025-06-18"
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	// codeToolError is used for errors of tools called directly as JSON-RPC method
+	codeToolError = -32000
+)
+
+// Tool is an operation exposed by the server
+type Tool struct {
+	Name        string
+	Description string
+	// InputSchema is the JSON schema of the arguments
+	InputSchema Schema
+	// Call runs the tool with the raw JSON arguments, and returns a result which is marshalled to JSON
+	Call func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Server serves tools via JSON-RPC. Tools can be called via the MCP method
+// tools/call, or directly as a JSON-RPC method named like the tool.
+type Server struct {
+	Name    string
+	Version string
+	tools   []Tool
+	byName  map[string]*Tool
+	enc     *json.Encoder
+}
+
+// NewServer returns a server for the given tools
+func NewServer(name, version string, tools []Tool) *Server {
+	s := &Server{Name: name, Version: version, tools: tools, byName: map[string]*Tool{}}
+	for i := range s.tools {
+		s.byName[s.tools[i].Name] = &s.tools[i]
+	}
+	return s
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// errorData describes the kind of a failed operation, like the exit code of tea
+type errorData struct {
+	Kind     string `json:"kind"`
+	ExitCode int    `json:"exit_code"`
+}
+
+func newErrorData(err error) errorData {
+	return errorData{Kind: utils.ErrorKind(err), ExitCode: utils.ExitCode(err)}
+}
+
+// Serve reads requests from in and writes the responses to out, until in is
+// closed or ctx is cancelled. Requests are handled one after another.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.enc = json.NewEncoder(out)
+	s.enc.SetEscapeHTML(false)
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-readErr:
+			return err
+		case line := <-lines:
+			if len(line) == 0 {
+				continue
+			}
+			if err := s.handle(ctx, line); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle processes a request, and writes the response if it isn't a notification
+func (s *Server) handle(ctx context.Context, line []byte) error {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return s.write(response{Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+	}
+	debug.Printf("mcp: received %s", req.Method)
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return s.write(response{ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}})
+	}
+
+	result, rpcErr := s.dispatch(ctx, req)
+	if len(req.ID) == 0 {
+		// notifications are not answered
+		return nil
+	}
+	if rpcErr != nil {
+		return s.write(response{ID: req.ID, Error: rpcErr})
+	}
+	return s.write(response{ID: req.ID, Result: result})
+}
+
+func (s *Server) write(resp response) error {
+	resp.JSONRPC = "2.0"
+	if resp.ID == nil {
+		resp.ID = json.RawMessage("null")
+	}
+	return s.enc.Encode(resp)
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params)
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools(), nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	}
+
+	tool, ok := s.byName[req.Method]
+	if !ok {
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method '%s' not found", req.Method)}
+	}
+	result, err := tool.Call(ctx, params(req.Params))
+	if err != nil {
+		code := codeToolError
+		if errors.Is(err, utils.ErrValidation) {
+			code = codeInvalidParams
+		}
+		return nil, &rpcError{Code: code, Message: err.Error(), Data: newErrorData(err)}
+	}
+	return result, nil
+}
+
+func (s *Server) initialize(raw json.RawMessage) (any, *rpcError) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if err := json.Unmarshal(params(raw), &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	// the client version is accepted as long as it's older than ours, as the used features didn't change
+	version := ProtocolVersion
+	if p.ProtocolVersion != "" && p.ProtocolVersion < version {
+		version = p.ProtocolVersion
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]string{"name": s.Name, "version": s.Version},
+	}, nil
+}
+
+type toolInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	InputSchema Schema `json:"inputSchema"`
+}
+
+func (s *Server) listTools() any {
+	tools := make([]toolInfo, 0, len(s.tools))
+	for _, t := range s.tools {
+		tools = append(tools, toolInfo{Name: t.Name, Description: t.Description, InputSchema: t.InputSchema})
+	}
+	return map[string]any{"tools": tools}
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type callResult struct {
+	Content           []content `json:"content"`
+	StructuredContent any       `json:"structuredContent,omitempty"`
+	IsError           bool      `json:"isError,omitempty"`
+}
+
+// callTool runs a tool for tools/call. Errors of the tool are returned as result,
+// so the model calling the tool can see them.
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (any, *rpcError) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params(raw), &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	tool, ok := s.byName[p.Name]
+	if !ok {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool '%s'", p.Name)}
+	}
+
+	result, err := tool.Call(ctx, params(p.Arguments))
+	if err != nil {
+		return callResult{
+			Content:           []content{{Type: "text", Text: err.Error()}},
+			StructuredContent: map[string]any{"error": err.Error(), "kind": utils.ErrorKind(err), "exit_code": utils.ExitCode(err)},
+			IsError:           true,
+		}, nil
+	}
+
+	text, err := json.Marshal(result)
+	if err != nil {
+		return nil, &rpcError{Code: codeToolError, Message: err.Error()}
+	}
+	// structured content has to be an object, lists are wrapped
+	structured := result
+	if len(text) != 0 && text[0] != '{' {
+		structured = map[string]any{"result": result}
+	}
+	return callResult{Content: []content{{Type: "text", Text: string(text)}}, StructuredContent: structured}, nil
+}
+
+// params returns an empty object for missing params
+func params(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 || string(raw) == "null" {
+		return json.RawMessage("{}")
+	}
+	return raw
+}
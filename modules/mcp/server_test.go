@@ -0,0 +1,149 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer returns a fake Gitea instance used as login via the environment,
+// and records the requests it received
+func newTestServer(t *testing.T) *[]string {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/version":
+			_, _ = w.Write([]byte(`{"version":"1.24.0"}`))
+		case "GET /api/v1/repos/o/r/issues":
+			// two issues per page
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			w.Header().Set("X-Total-Count", "4")
+			_, _ = fmt.Fprintf(w, `[{"number":%d},{"number":%d}]`, 2*page-1, 2*page)
+		case "GET /api/v1/repos/o/r/issues/3":
+			_, _ = w.Write([]byte(`{"number":3,"title":"Broken build","state":"open"}`))
+		case "GET /api/v1/repos/o/r/issues/3/comments":
+			_, _ = w.Write([]byte(`[{"id":9,"body":"Me too"}]`))
+		case "POST /api/v1/repos/o/r/pulls/5/reviews":
+			_, _ = w.Write([]byte(`{"id":7,"state":"APPROVED","html_url":"https://gitea.com/o/r/pulls/5"}`))
+		case "GET /api/v1/repos/o/r/actions/runs":
+			_, _ = w.Write([]byte(`{"workflow_runs":[{"id":1,"status":"completed"}],"total_count":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Cleanup(xdg.Reload)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	xdg.Reload()
+	t.Setenv("GITEA_INSTANCE_URL", server.URL)
+	t.Setenv("GITEA_TOKEN", "8fe2e3b0c44298fc1c149afbf4c8996fb92427ae")
+	return &requests
+}
+
+// serve sends the requests to a server, and returns the decoded responses
+func serve(t *testing.T, requests ...string) []map[string]any {
+	out := &bytes.Buffer{}
+	server := NewServer("tea", "test", Tools())
+	require.NoError(t, server.Serve(t.Context(), strings.NewReader(strings.Join(requests, "\n")), out))
+
+	var responses []map[string]any
+	dec := json.NewDecoder(out)
+	for dec.More() {
+		var resp map[string]any
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestServeProtocol(t *testing.T) {
+	responses := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":"two","method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"unknown"}`,
+		`not json`,
+	)
+	require.Len(t, responses, 4)
+
+	assert.EqualValues(t, 1, responses[0]["id"])
+	result := responses[0]["result"].(map[string]any)
+	assert.Equal(t, "2025-03-26", result["protocolVersion"])
+	assert.Equal(t, map[string]any{"name": "tea", "version": "test"}, result["serverInfo"])
+
+	assert.Equal(t, "two", responses[1]["id"])
+	tools := responses[1]["result"].(map[string]any)["tools"].([]any)
+	assert.Len(t, tools, len(Tools()))
+	tool := tools[0].(map[string]any)
+	assert.Equal(t, "issues_list", tool["name"])
+	assert.Equal(t, "object", tool["inputSchema"].(map[string]any)["type"])
+
+	assert.EqualValues(t, -32601, responses[2]["error"].(map[string]any)["code"])
+	assert.Nil(t, responses[3]["id"])
+	assert.EqualValues(t, -32700, responses[3]["error"].(map[string]any)["code"])
+}
+
+func TestServeTools(t *testing.T) {
+	requests := newTestServer(t)
+	responses := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"issues_view","arguments":{"repo":"o/r","index":3,"comments":true}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"pulls_review","params":{"repo":"o/r","index":5,"state":"approve"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"runs_list","params":{"repo":"o/r","status":"completed"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"pulls_view","arguments":{"repo":"o/r","index":404}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"pulls_merge","params":{"repo":"o/r","index":5,"style":"octopus"}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"comment","params":{"repo":"o/r","idx":5}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"comment","params":{"repo":"o/r","index":5}}`,
+		`{"jsonrpc":"2.0","id":8,"method":"issues_list","params":{"repo":"o/r","limit":2,"all":true}}`,
+	)
+	require.Len(t, responses, 8)
+
+	// MCP results contain the JSON as text & structured content
+	result := responses[0]["result"].(map[string]any)
+	structured := result["structuredContent"].(map[string]any)
+	assert.EqualValues(t, 3, structured["issue"].(map[string]any)["number"])
+	assert.Equal(t, "Me too", structured["comments"].([]any)[0].(map[string]any)["body"])
+	text := result["content"].([]any)[0].(map[string]any)["text"].(string)
+	assert.Contains(t, text, `"title":"Broken build"`)
+
+	// direct JSON-RPC calls return the result as is
+	review := responses[1]["result"].(map[string]any)
+	assert.Equal(t, "APPROVED", review["state"])
+	assert.Contains(t, *requests, `POST /api/v1/repos/o/r/pulls/5/reviews {"event":"APPROVED","body":"","commit_id":"","comments":null}`)
+	runs := responses[2]["result"].(map[string]any)
+	assert.EqualValues(t, 1, runs["total_count"])
+
+	// failed tool calls are reported as result with the kind of error
+	result = responses[3]["result"].(map[string]any)
+	assert.Equal(t, true, result["isError"])
+	assert.Equal(t, "not_found", result["structuredContent"].(map[string]any)["kind"])
+
+	// invalid arguments are rejected before sending any request
+	for _, resp := range responses[4:7] {
+		rpcErr := resp["error"].(map[string]any)
+		assert.EqualValues(t, -32602, rpcErr["code"])
+		assert.Equal(t, "validation", rpcErr["data"].(map[string]any)["kind"])
+	}
+	assert.NotContains(t, strings.Join(*requests, "\n"), "/merge")
+
+	// all pages of a list are fetched like with --all
+	issues := responses[7]["result"].([]any)
+	assert.Len(t, issues, 4)
+}
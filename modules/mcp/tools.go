@@ -0,0 +1,518 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"unicode/utf8"
+
+	"code.gitea.io/tea/modules/api"
+	"code.gitea.io/tea/modules/config"
+	teacontext "code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/pagination"
+	"code.gitea.io/tea/modules/task"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/urfave/cli/v3"
+)
+
+// repoArgs are the arguments of all tools, selecting the login & repository
+// like the flags of the same name do for tea commands
+type repoArgs struct {
+	Login  string `json:"login"`
+	Repo   string `json:"repo"`
+	Remote string `json:"remote"`
+}
+
+var repoProperties = map[string]Schema{
+	"login":  str("Use a different Gitea login. Optional"),
+	"repo":   str("Override local repository path or gitea repository slug to interact with. Optional"),
+	"remote": str("Discover Gitea login from remote. Optional"),
+}
+
+// context resolves the login & repository via the same logic as tea commands,
+// from the arguments and the repository in the working directory of the server
+func (a repoArgs) context(ctx context.Context) (*teacontext.TeaContext, error) {
+	cmd := &cli.Command{
+		Name: "serve",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "login"},
+			&cli.StringFlag{Name: "repo"},
+			&cli.StringFlag{Name: "remote"},
+		},
+	}
+	for name, value := range map[string]string{"login": a.Login, "repo": a.Repo, "remote": a.Remote} {
+		if len(value) != 0 {
+			if err := cmd.Set(name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	c, err := teacontext.InitCommand(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Ensure(teacontext.CtxRequirement{RemoteRepo: true}); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type listArgs struct {
+	repoArgs
+	State    string `json:"state"`
+	Page     int    `json:"page"`
+	Limit    int    `json:"limit"`
+	All      bool   `json:"all"`
+	MaxItems int    `json:"max_items"`
+}
+
+var listProperties = with(repoProperties, map[string]Schema{
+	"state":     str("Filter by state, defaults to open", "open", "closed", "all"),
+	"page":      integer("Page number to return, defaults to 1"),
+	"limit":     integer("Maximum number of items per page, defaults to 30"),
+	"all":       boolean("Fetch all pages instead of a single one"),
+	"max_items": integer("Maximum number of items to fetch with all, 0 for no limit"),
+})
+
+// paging returns the items to fetch, like the pagination flags do for tea commands
+func (a listArgs) paging() (pagination.Paging, error) {
+	if a.MaxItems < 0 {
+		return pagination.Paging{}, utils.NewValidationErrorf("max_items cannot be negative")
+	}
+	p := pagination.Paging{
+		ListOptions: gitea.ListOptions{Page: a.Page, PageSize: a.Limit},
+		All:         a.All,
+		MaxItems:    a.MaxItems,
+	}
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 {
+		p.PageSize = 30
+	}
+	return p, nil
+}
+
+func (a listArgs) state() (gitea.StateType, error) {
+	switch a.State {
+	case "", "open":
+		return gitea.StateOpen, nil
+	case "closed":
+		return gitea.StateClosed, nil
+	case "all":
+		return gitea.StateAll, nil
+	}
+	return "", utils.NewValidationErrorf("unknown state '%s'", a.State)
+}
+
+type indexArgs struct {
+	repoArgs
+	Index int64 `json:"index"`
+}
+
+func (a indexArgs) validate() error {
+	if a.Index < 1 {
+		return utils.NewValidationErrorf("index must be a positive number")
+	}
+	return nil
+}
+
+// indexed returns the properties of a tool operating on an issue or pull request, with
+// the additional properties more. The index is required, as are the additional properties
+// given in required.
+func indexed(entity string, more map[string]Schema, required ...string) Schema {
+	properties := with(repoProperties, map[string]Schema{
+		"index": integer(fmt.Sprintf("Index of the %s", entity)),
+	})
+	return object(with(properties, more), append([]string{"index"}, required...)...)
+}
+
+// Tools returns the tools exposing tea operations
+func Tools() []Tool {
+	return []Tool{
+		newTool("issues_list", "List issues of a repository", object(listProperties), listIssues),
+		newTool("issues_view", "Show an issue, optionally with its comments",
+			indexed("issue", map[string]Schema{"comments": boolean("Whether to include the comments")}), viewIssue),
+		newTool("pulls_list", "List pull requests of a repository", object(listProperties), listPulls),
+		newTool("pulls_view", "Show a pull request, optionally with its reviews",
+			indexed("pull request", map[string]Schema{"reviews": boolean("Whether to include the reviews")}), viewPull),
+		newTool("comment", "Add a comment to an issue or pull request",
+			indexed("issue or pull request", map[string]Schema{"body": str("Markdown text of the comment")}, "body"), addComment),
+		newTool("pulls_review", "Review a pull request",
+			indexed("pull request", map[string]Schema{
+				"state": str("Kind of the review", "approve", "request_changes", "comment"),
+				"body":  str("Markdown text of the review, required unless approving"),
+			}, "state"), reviewPull),
+		newTool("pulls_merge", "Merge a pull request",
+			indexed("pull request", map[string]Schema{
+				"style":   str("Merge style, defaults to the configured merge style or merge", "merge", "rebase", "squash", "rebase-merge"),
+				"title":   str("Title of the merge commit"),
+				"message": str("Message of the merge commit"),
+			}), mergePull),
+		newTool("files_get", "Read a file of a repository",
+			object(with(repoProperties, map[string]Schema{
+				"path": str("Path of the file in the repository"),
+				"ref":  str("Branch, tag or commit to read the file from, defaults to the default branch"),
+			}), "path"), getFile),
+		newTool("runs_list", "List the workflow runs of a repository",
+			object(with(repoProperties, map[string]Schema{
+				"status": str("Filter by status", "queued", "in_progress", "completed"),
+				"branch": str("Filter by branch name"),
+				"event":  str("Filter by event type, e.g. push or pull_request"),
+				"limit":  integer("Maximum number of runs, defaults to 10"),
+			})), listRuns),
+		newTool("runs_view", "Show a workflow run, optionally with its jobs",
+			object(with(repoProperties, map[string]Schema{
+				"id":   integer("ID of the run"),
+				"jobs": boolean("Whether to include the jobs"),
+			}), "id"), viewRun),
+	}
+}
+
+func listIssues(ctx context.Context, args listArgs) (any, error) {
+	state, err := args.state()
+	if err != nil {
+		return nil, err
+	}
+	paging, err := args.paging()
+	if err != nil {
+		return nil, err
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return nil, err
+	}
+	return task.ListIssues(client, c.Owner, c.Repo, gitea.ListIssueOption{
+		State: state,
+		Type:  gitea.IssueTypeIssue,
+	}, paging)
+}
+
+type issueResult struct {
+	Issue    *gitea.Issue     `json:"issue"`
+	Comments []*gitea.Comment `json:"comments,omitempty"`
+}
+
+type viewIssueArgs struct {
+	indexArgs
+	Comments bool `json:"comments"`
+}
+
+func viewIssue(ctx context.Context, args viewIssueArgs) (any, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return nil, err
+	}
+	issue, err := utils.APIResult(client.GetIssue(c.Owner, c.Repo, args.Index))
+	if err != nil {
+		return nil, err
+	}
+	result := issueResult{Issue: issue}
+	if args.Comments {
+		if result.Comments, err = task.ListIssueComments(client, c.Owner, c.Repo, args.Index); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func listPulls(ctx context.Context, args listArgs) (any, error) {
+	state, err := args.state()
+	if err != nil {
+		return nil, err
+	}
+	paging, err := args.paging()
+	if err != nil {
+		return nil, err
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return nil, err
+	}
+	return task.ListPulls(client, c.Owner, c.Repo, gitea.ListPullRequestsOptions{
+		State: state,
+	}, paging)
+}
+
+type pullResult struct {
+	Pull    *gitea.PullRequest  `json:"pull"`
+	Reviews []*gitea.PullReview `json:"reviews,omitempty"`
+}
+
+type viewPullArgs struct {
+	indexArgs
+	Reviews bool `json:"reviews"`
+}
+
+func viewPull(ctx context.Context, args viewPullArgs) (any, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return nil, err
+	}
+	pull, err := utils.APIResult(client.GetPullRequest(c.Owner, c.Repo, args.Index))
+	if err != nil {
+		return nil, err
+	}
+	result := pullResult{Pull: pull}
+	if args.Reviews {
+		if result.Reviews, err = task.ListPullReviews(client, c.Owner, c.Repo, args.Index); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+type addCommentArgs struct {
+	indexArgs
+	Body string `json:"body"`
+}
+
+func addComment(ctx context.Context, args addCommentArgs) (any, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.CreateComment(c, args.Index, args.Body)
+}
+
+var reviewStates = map[string]gitea.ReviewStateType{
+	"approve":         gitea.ReviewStateApproved,
+	"request_changes": gitea.ReviewStateRequestChanges,
+	"comment":         gitea.ReviewStateComment,
+}
+
+type reviewPullArgs struct {
+	indexArgs
+	State string `json:"state"`
+	Body  string `json:"body"`
+}
+
+func reviewPull(ctx context.Context, args reviewPullArgs) (any, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+	state, ok := reviewStates[args.State]
+	if !ok {
+		return nil, utils.NewValidationErrorf("unknown review state '%s'", args.State)
+	}
+	if state != gitea.ReviewStateApproved && len(args.Body) == 0 {
+		return nil, utils.NewValidationErrorf("body must not be empty for state '%s'", args.State)
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	review, err := task.CreatePullReview(c, args.Index, state, args.Body, nil)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+type mergeResult struct {
+	Index  int64  `json:"index"`
+	Merged bool   `json:"merged"`
+	Style  string `json:"style"`
+}
+
+type mergePullArgs struct {
+	indexArgs
+	Style   string `json:"style"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+func mergePull(ctx context.Context, args mergePullArgs) (any, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// like --style, fall back to the merge style configured as flag default
+	style := gitea.MergeStyle(args.Style)
+	if len(style) == 0 {
+		style = gitea.MergeStyle(config.GetPreferences().FlagDefaults.MergeStyle)
+	}
+	switch style {
+	case "":
+		style = gitea.MergeStyleMerge
+	case gitea.MergeStyleMerge, gitea.MergeStyleRebase, gitea.MergeStyleSquash, gitea.MergeStyleRebaseMerge:
+	default:
+		return nil, utils.NewValidationErrorf("unknown merge style '%s'", style)
+	}
+	if err := task.PullMerge(c.Ctx, c.Login, c.Owner, c.Repo, args.Index, gitea.MergePullRequestOption{
+		Style:   style,
+		Title:   args.Title,
+		Message: args.Message,
+	}); err != nil {
+		return nil, err
+	}
+	return mergeResult{Index: args.Index, Merged: true, Style: string(style)}, nil
+}
+
+type fileResult struct {
+	Path    string `json:"path"`
+	Ref     string `json:"ref,omitempty"`
+	SHA     string `json:"sha"`
+	Size    int64  `json:"size"`
+	Content string `json:"content"`
+	// Encoding is base64 for binary files, and empty for text
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type getFileArgs struct {
+	repoArgs
+	Path string `json:"path"`
+	Ref  string `json:"ref"`
+}
+
+func getFile(ctx context.Context, args getFileArgs) (any, error) {
+	if len(args.Path) == 0 {
+		return nil, utils.NewValidationErrorf("path must not be empty")
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := c.Client()
+	if err != nil {
+		return nil, err
+	}
+	contents, err := utils.APIResult(client.GetContents(c.Owner, c.Repo, args.Ref, args.Path))
+	if err != nil {
+		return nil, err
+	}
+	if contents.Type != "file" || contents.Content == nil {
+		return nil, utils.NewValidationErrorf("path is a %s, not a file", contents.Type)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(*contents.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file content: %w", err)
+	}
+
+	result := fileResult{Path: contents.Path, Ref: args.Ref, SHA: contents.SHA, Size: contents.Size}
+	if utf8.Valid(decoded) && !bytes.ContainsRune(decoded, 0) {
+		result.Content = string(decoded)
+	} else {
+		result.Content, result.Encoding = *contents.Content, "base64"
+	}
+	return result, nil
+}
+
+// getRaw returns the response of an API endpoint, which isn't supported by the SDK
+func getRaw(ctx context.Context, login *config.Login, path string) (json.RawMessage, error) {
+	client, err := api.NewClient(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	body, err := client.Request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("failed to parse response of %s", path)
+	}
+	return body, nil
+}
+
+type listRunsArgs struct {
+	repoArgs
+	Status string `json:"status"`
+	Branch string `json:"branch"`
+	Event  string `json:"event"`
+	Limit  int    `json:"limit"`
+}
+
+func listRuns(ctx context.Context, args listRunsArgs) (any, error) {
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("limit", "10")
+	if args.Limit > 0 {
+		query.Set("limit", strconv.Itoa(args.Limit))
+	}
+	for key, value := range map[string]string{"status": args.Status, "branch": args.Branch, "event": args.Event} {
+		if len(value) != 0 {
+			query.Set(key, value)
+		}
+	}
+	return getRaw(ctx, c.Login, fmt.Sprintf("/repos/%s/%s/actions/runs?%s", c.Owner, c.Repo, query.Encode()))
+}
+
+type runResult struct {
+	Run  json.RawMessage `json:"run"`
+	Jobs json.RawMessage `json:"jobs,omitempty"`
+}
+
+type viewRunArgs struct {
+	repoArgs
+	ID   int64 `json:"id"`
+	Jobs bool  `json:"jobs"`
+}
+
+func viewRun(ctx context.Context, args viewRunArgs) (any, error) {
+	if args.ID < 1 {
+		return nil, utils.NewValidationErrorf("id must be a positive number")
+	}
+	c, err := args.context(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/repos/%s/%s/actions/runs/%d", c.Owner, c.Repo, args.ID)
+	var result runResult
+	if result.Run, err = getRaw(ctx, c.Login, path); err != nil {
+		return nil, err
+	}
+	if args.Jobs {
+		var jobs struct {
+			Jobs json.RawMessage `json:"jobs"`
+		}
+		raw, err := getRaw(ctx, c.Login, path+"/jobs")
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &jobs); err != nil {
+			return nil, err
+		}
+		result.Jobs = jobs.Jobs
+	}
+	return result, nil
+}
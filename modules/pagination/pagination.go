@@ -0,0 +1,181 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pagination
+
+import (
+	"strconv"
+	"sync"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// DefaultConcurrency is the number of pages requested in parallel,
+// when the total number of items is known upfront.
+const DefaultConcurrency = 4
+
+// Fetcher requests a single page of a list endpoint
+type Fetcher[T any] func(opts gitea.ListOptions) ([]T, *gitea.Response, error)
+
+// Options configure how pages of a list endpoint are walked
+type Options struct {
+	// number of items requested per page
+	PageSize int
+	// stop after this many items have been fetched, 0 means no limit
+	MaxItems int
+	// number of parallel page requests, defaults to DefaultConcurrency
+	Concurrency int
+}
+
+// Paging selects the items to fetch from a list endpoint: the single page given
+// by ListOptions, or all pages up to MaxItems items if All is set
+type Paging struct {
+	gitea.ListOptions
+	All      bool
+	MaxItems int
+}
+
+// List fetches the items of a list endpoint selected by p.
+// Errors are marked with the kind matching the HTTP status of the failed page.
+func List[T any](fetch Fetcher[T], p Paging) ([]T, error) {
+	if !p.All {
+		opts := p.ListOptions
+		// This enforces pagination (see https://github.com/go-gitea/gitea/issues/16733)
+		if opts.Page == 0 {
+			opts.Page = 1
+		}
+		return utils.APIResult(fetch(opts))
+	}
+	return All(fetch, Options{
+		PageSize: p.PageSize,
+		MaxItems: p.MaxItems,
+	})
+}
+
+// All fetches every page of a list endpoint, up to opts.MaxItems items.
+// Errors are marked with the kind matching the HTTP status of the failed page.
+// If the server announces the total number of items via the X-Total-Count
+// header, the remaining pages are requested in parallel, otherwise pages are
+// requested one after another until the server reports no next page.
+//
+// The server may cap the page size (MAX_RESPONSE_ITEMS in Gitea), so the size
+// of the first page returned is used as page size from then on.
+// Without X-Total-Count or Link header, a short page is taken as the last one.
+func All[T any](fetch Fetcher[T], opts Options) ([]T, error) {
+	if opts.PageSize <= 0 {
+		opts.PageSize = 30
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+
+	items, resp, err := fetch(gitea.ListOptions{Page: 1, PageSize: opts.PageSize})
+	if err != nil {
+		return nil, utils.APIError(resp, err)
+	}
+	if isLastPage(items, resp, len(items), opts.PageSize) || reachedMax(items, opts.MaxItems) {
+		return capItems(items, opts.MaxItems), nil
+	}
+	// a short first page with more pages announced means the page size was capped
+	if len(items) < opts.PageSize {
+		opts.PageSize = len(items)
+	}
+
+	if total := totalCount(resp); total > 0 {
+		if opts.MaxItems > 0 && opts.MaxItems < total {
+			total = opts.MaxItems
+		}
+		pages := (total + opts.PageSize - 1) / opts.PageSize
+		rest, err := fetchPages(fetch, opts, 2, pages)
+		if err != nil {
+			return nil, err
+		}
+		return capItems(append(items, rest...), opts.MaxItems), nil
+	}
+
+	for page := 2; ; page++ {
+		next, resp, err := fetch(gitea.ListOptions{Page: page, PageSize: opts.PageSize})
+		if err != nil {
+			return nil, utils.APIError(resp, err)
+		}
+		items = append(items, next...)
+		if isLastPage(next, resp, len(items), opts.PageSize) || reachedMax(items, opts.MaxItems) {
+			return capItems(items, opts.MaxItems), nil
+		}
+	}
+}
+
+// fetchPages requests the pages first to last with bounded concurrency,
+// and returns their items in page order.
+func fetchPages[T any](fetch Fetcher[T], opts Options, first, last int) ([]T, error) {
+	if last < first {
+		return nil, nil
+	}
+
+	results := make([][]T, last-first+1)
+	errs := make([]error, len(results))
+	sem := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+
+	for page := first; page <= last; page++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(page int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			i := page - first
+			results[i], errs[i] = utils.APIResult(fetch(gitea.ListOptions{Page: page, PageSize: opts.PageSize}))
+		}(page)
+	}
+	wg.Wait()
+
+	var items []T
+	for i := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		items = append(items, results[i]...)
+	}
+	return items, nil
+}
+
+// totalCount returns the value of the X-Total-Count header, or 0 if it's not present
+func totalCount(resp *gitea.Response) int {
+	if resp == nil || resp.Response == nil {
+		return 0
+	}
+	total, err := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	if err != nil {
+		return 0
+	}
+	return total
+}
+
+// isLastPage checks if there are no more pages after the given one,
+// fetched being the number of items received so far
+func isLastPage[T any](items []T, resp *gitea.Response, fetched, pageSize int) bool {
+	if len(items) == 0 {
+		return true
+	}
+	if total := totalCount(resp); total > 0 {
+		return fetched >= total
+	}
+	// the server announces a next page via Link header, if there is one
+	if resp != nil && resp.Response != nil && resp.Header.Get("Link") != "" {
+		return resp.NextPage == 0
+	}
+	return len(items) < pageSize
+}
+
+func reachedMax[T any](items []T, maxItems int) bool {
+	return maxItems > 0 && len(items) >= maxItems
+}
+
+func capItems[T any](items []T, maxItems int) []T {
+	if maxItems > 0 && len(items) > maxItems {
+		return items[:maxItems]
+	}
+	return items
+}
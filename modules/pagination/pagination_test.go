@@ -0,0 +1,148 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeList returns a fetcher serving total items, optionally announcing
+// the total via X-Total-Count
+func fakeList(total int, withCount bool, requests *int32) Fetcher[int] {
+	header := ""
+	if withCount {
+		header = "X-Total-Count"
+	}
+	return fakeCappedList(total, 0, header, requests)
+}
+
+// fakeCappedList is like fakeList, but caps the page size at maxPageSize
+// like Gitea's MAX_RESPONSE_ITEMS setting, 0 means no cap.
+// Further pages are announced with the given header, X-Total-Count or Link.
+func fakeCappedList(total, maxPageSize int, header string, requests *int32) Fetcher[int] {
+	return func(opts gitea.ListOptions) ([]int, *gitea.Response, error) {
+		atomic.AddInt32(requests, 1)
+		if maxPageSize > 0 && opts.PageSize > maxPageSize {
+			opts.PageSize = maxPageSize
+		}
+		resp := &gitea.Response{Response: &http.Response{Header: http.Header{}}}
+		switch header {
+		case "X-Total-Count":
+			resp.Header.Set("X-Total-Count", strconv.Itoa(total))
+		case "Link":
+			resp.Header.Set("Link", `<https://gitea.com>; rel="first"`)
+			if opts.Page*opts.PageSize < total {
+				resp.NextPage = opts.Page + 1
+			}
+		}
+		var items []int
+		for i := (opts.Page - 1) * opts.PageSize; i < opts.Page*opts.PageSize && i < total; i++ {
+			items = append(items, i)
+		}
+		return items, resp, nil
+	}
+}
+
+func sequence(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func TestAllWithTotalCount(t *testing.T) {
+	var requests int32
+	items, err := All(fakeList(95, true, &requests), Options{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, sequence(95), items)
+	assert.EqualValues(t, 10, requests)
+}
+
+func TestAllWithoutTotalCount(t *testing.T) {
+	var requests int32
+	items, err := All(fakeList(40, false, &requests), Options{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, sequence(40), items)
+	// the last, empty page is needed to detect the end of the list
+	assert.EqualValues(t, 5, requests)
+}
+
+func TestAllMaxItems(t *testing.T) {
+	for _, withCount := range []bool{true, false} {
+		var requests int32
+		items, err := All(fakeList(95, withCount, &requests), Options{PageSize: 10, MaxItems: 25})
+		require.NoError(t, err)
+		assert.Equal(t, sequence(25), items)
+		assert.EqualValues(t, 3, requests)
+	}
+}
+
+func TestAllCappedPageSize(t *testing.T) {
+	for _, header := range []string{"X-Total-Count", "Link"} {
+		var requests int32
+		items, err := All(fakeCappedList(230, 50, header, &requests), Options{PageSize: 100})
+		require.NoError(t, err)
+		assert.Equal(t, sequence(230), items)
+		assert.EqualValues(t, 5, requests)
+	}
+}
+
+func TestAllSinglePage(t *testing.T) {
+	var requests int32
+	items, err := All(fakeList(3, true, &requests), Options{PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, sequence(3), items)
+	assert.EqualValues(t, 1, requests)
+}
+
+func TestAllError(t *testing.T) {
+	var requests int32
+	list := fakeList(50, true, &requests)
+	failing := func(opts gitea.ListOptions) ([]int, *gitea.Response, error) {
+		if opts.Page == 3 {
+			return nil, nil, errors.New("server error")
+		}
+		return list(opts)
+	}
+	_, err := All(failing, Options{PageSize: 10})
+	assert.EqualError(t, err, "server error")
+}
+
+func TestAllErrorStatus(t *testing.T) {
+	var requests int32
+	list := fakeList(50, true, &requests)
+	failing := func(opts gitea.ListOptions) ([]int, *gitea.Response, error) {
+		if opts.Page == 3 {
+			return nil, &gitea.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}, errors.New("forbidden")
+		}
+		return list(opts)
+	}
+	_, err := All(failing, Options{PageSize: 10})
+	assert.ErrorIs(t, err, utils.ErrPermissionDenied)
+}
+
+func TestList(t *testing.T) {
+	var requests int32
+	items, err := List(fakeList(25, true, &requests), Paging{ListOptions: gitea.ListOptions{Page: 2, PageSize: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, sequence(20)[10:], items)
+	assert.EqualValues(t, 1, requests)
+
+	requests = 0
+	items, err = List(fakeList(25, true, &requests), Paging{ListOptions: gitea.ListOptions{PageSize: 10}, All: true, MaxItems: 15})
+	require.NoError(t, err)
+	assert.Equal(t, sequence(15), items)
+	assert.EqualValues(t, 2, requests)
+}
@@ -5,12 +5,15 @@ package print
 
 import (
 	"fmt"
+	"io"
+
+	"code.gitea.io/tea/modules/api"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // ActionSecretsList prints a list of action secrets
-func ActionSecretsList(secrets []*gitea.Secret, output string) {
+func ActionSecretsList(w io.Writer, secrets []*gitea.Secret, output string) error {
 	t := table{
 		headers: []string{
 			"Name",
@@ -21,29 +24,29 @@ func ActionSecretsList(secrets []*gitea.Secret, output string) {
 	for _, secret := range secrets {
 		t.addRow(
 			secret.Name,
-			FormatTime(secret.Created, output != ""),
+			secret.Created,
 		)
 	}
 
 	if len(secrets) == 0 {
-		fmt.Printf("No secrets found\n")
-		return
+		fmt.Fprintf(w, "No secrets found\n")
+		return nil
 	}
 
 	t.sort(0, true)
-	t.print(output)
+	return t.print(w, output)
 }
 
 // ActionVariableDetails prints details of a specific action variable
-func ActionVariableDetails(variable *gitea.RepoActionVariable) {
-	fmt.Printf("Name: %s\n", variable.Name)
-	fmt.Printf("Value: %s\n", variable.Value)
-	fmt.Printf("Repository ID: %d\n", variable.RepoID)
-	fmt.Printf("Owner ID: %d\n", variable.OwnerID)
+func ActionVariableDetails(w io.Writer, variable *gitea.RepoActionVariable) {
+	fmt.Fprintf(w, "Name: %s\n", variable.Name)
+	fmt.Fprintf(w, "Value: %s\n", variable.Value)
+	fmt.Fprintf(w, "Repository ID: %d\n", variable.RepoID)
+	fmt.Fprintf(w, "Owner ID: %d\n", variable.OwnerID)
 }
 
 // ActionVariablesList prints a list of action variables
-func ActionVariablesList(variables []*gitea.RepoActionVariable, output string) {
+func ActionVariablesList(w io.Writer, variables []*gitea.RepoActionVariable, output string) error {
 	t := table{
 		headers: []string{
 			"Name",
@@ -54,23 +57,86 @@ func ActionVariablesList(variables []*gitea.RepoActionVariable, output string) {
 
 	for _, variable := range variables {
 		// Truncate long values for table display
-		value := variable.Value
-		if len(value) > 50 {
-			value = value[:47] + "..."
+		value := formatted{value: variable.Value, text: variable.Value}
+		if len(value.text) > 50 {
+			value.text = value.text[:47] + "..."
 		}
 
 		t.addRow(
 			variable.Name,
 			value,
-			fmt.Sprintf("%d", variable.RepoID),
+			variable.RepoID,
 		)
 	}
 
 	if len(variables) == 0 {
-		fmt.Printf("No variables found\n")
-		return
+		fmt.Fprintf(w, "No variables found\n")
+		return nil
 	}
 
 	t.sort(0, true)
-	t.print(output)
+	return t.print(w, output)
+}
+
+// ActionRunsList prints a listing of workflow runs
+func ActionRunsList(w io.Writer, runs []*api.ActionRun, output string, fields []string) error {
+	printables := make([]printable, len(runs))
+	for i, r := range runs {
+		printables[i] = &printableActionRun{r}
+	}
+	t := tableFromItems(fields, printables)
+	return t.print(w, output)
+}
+
+// ActionRunFields are all available fields to print with ActionRunsList
+var ActionRunFields = []string{
+	"id",
+	"number",
+	"status",
+	"conclusion",
+	"event",
+	"branch",
+	"sha",
+	"title",
+	"actor",
+	"started",
+	"completed",
+	"url",
+}
+
+type printableActionRun struct {
+	*api.ActionRun
+}
+
+func (r printableActionRun) FieldValue(field string) any {
+	switch field {
+	case "id":
+		return r.ID
+	case "number":
+		return r.RunNumber
+	case "status":
+		return r.Status
+	case "conclusion":
+		return r.Conclusion
+	case "event":
+		return r.Event
+	case "branch":
+		return r.HeadBranch
+	case "sha":
+		return r.HeadSha
+	case "title":
+		return r.DisplayTitle
+	case "actor":
+		if r.Actor != nil {
+			return r.Actor.UserName
+		}
+		return nil
+	case "started":
+		return r.StartedAt
+	case "completed":
+		return r.CompletedAt
+	case "url":
+		return r.HTMLURL
+	}
+	return nil
 }
@@ -5,12 +5,17 @@ package print
 
 import (
 	"bytes"
+	"io"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"code.gitea.io/tea/modules/api"
+
 	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestActionSecretsListEmpty(t *testing.T) {
@@ -21,7 +26,7 @@ func TestActionSecretsListEmpty(t *testing.T) {
 		}
 	}()
 
-	ActionSecretsList([]*gitea.Secret{}, "")
+	ActionSecretsList(io.Discard, []*gitea.Secret{}, "")
 }
 
 func TestActionSecretsListWithData(t *testing.T) {
@@ -43,7 +48,7 @@ func TestActionSecretsListWithData(t *testing.T) {
 		}
 	}()
 
-	ActionSecretsList(secrets, "")
+	ActionSecretsList(io.Discard, secrets, "")
 
 	// Test JSON output format to verify structure
 	var buf bytes.Buffer
@@ -81,7 +86,7 @@ func TestActionVariableDetails(t *testing.T) {
 		}
 	}()
 
-	ActionVariableDetails(variable)
+	ActionVariableDetails(io.Discard, variable)
 }
 
 func TestActionVariablesListEmpty(t *testing.T) {
@@ -92,7 +97,7 @@ func TestActionVariablesListEmpty(t *testing.T) {
 		}
 	}()
 
-	ActionVariablesList([]*gitea.RepoActionVariable{}, "")
+	ActionVariablesList(io.Discard, []*gitea.RepoActionVariable{}, "")
 }
 
 func TestActionVariablesListWithData(t *testing.T) {
@@ -118,7 +123,7 @@ func TestActionVariablesListWithData(t *testing.T) {
 		}
 	}()
 
-	ActionVariablesList(variables, "")
+	ActionVariablesList(io.Discard, variables, "")
 
 	// Test JSON output format to verify structure and truncation
 	var buf bytes.Buffer
@@ -165,7 +170,7 @@ func TestActionVariablesListValueTruncation(t *testing.T) {
 		}
 	}()
 
-	ActionVariablesList([]*gitea.RepoActionVariable{variable}, "")
+	ActionVariablesList(io.Discard, []*gitea.RepoActionVariable{variable}, "")
 
 	// Test the truncation logic directly
 	value := variable.Value
@@ -212,3 +217,25 @@ func TestTableSorting(t *testing.T) {
 		t.Errorf("Expected last sorted value to be 'Z_SECRET', got '%s'", table.values[2][0])
 	}
 }
+
+func TestActionRunsList(t *testing.T) {
+	runs := []*api.ActionRun{{
+		ID:           42,
+		RunNumber:    7,
+		Status:       "completed",
+		Conclusion:   "success",
+		HeadBranch:   "main",
+		DisplayTitle: "Fix the build",
+		Actor:        &gitea.User{UserName: "alice"},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, ActionRunsList(&buf, runs, "csv", []string{"id", "number", "conclusion", "actor", "started"}))
+	assert.Equal(t, `"id","number","conclusion","actor","started"
+"42","7","success","alice",""
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, ActionRunsList(&buf, runs, "template={{.title}} on {{.branch}}", []string{"title", "branch"}))
+	assert.Equal(t, "Fix the build on main\n", buf.String())
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"io"
+	"sort"
+)
+
+// AliasesList prints a listing of command aliases
+func AliasesList(w io.Writer, aliases map[string]string, output string) error {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	t := tableWithHeader(
+		"Name",
+		"Expansion",
+	)
+	for _, name := range names {
+		t.addRow(name, aliases[name])
+	}
+	return t.print(w, output)
+}
@@ -5,6 +5,7 @@ package print
 
 import (
 	"fmt"
+	"io"
 
 	"code.gitea.io/sdk/gitea"
 )
@@ -17,7 +18,7 @@ func formatByteSize(size int64) string {
 }
 
 // ReleaseAttachmentsList prints a listing of release attachments
-func ReleaseAttachmentsList(attachments []*gitea.Attachment, output string) {
+func ReleaseAttachmentsList(w io.Writer, attachments []*gitea.Attachment, output string) error {
 	t := tableWithHeader(
 		"Name",
 		"Size",
@@ -26,9 +27,9 @@ func ReleaseAttachmentsList(attachments []*gitea.Attachment, output string) {
 	for _, attachment := range attachments {
 		t.addRow(
 			attachment.Name,
-			formatByteSize(attachment.Size),
+			formatted{value: attachment.Size, text: formatByteSize(attachment.Size)},
 		)
 	}
 
-	t.print(output)
+	return t.print(w, output)
 }
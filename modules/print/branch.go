@@ -5,13 +5,14 @@ package print
 
 import (
 	"fmt"
+	"io"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // BranchesList prints a listing of the branches
-func BranchesList(branches []*gitea.Branch, protections []*gitea.BranchProtection, output string, fields []string) {
-	fmt.Println(fields)
+func BranchesList(w io.Writer, branches []*gitea.Branch, protections []*gitea.BranchProtection, output string, fields []string) error {
+	fmt.Fprintln(w, fields)
 	printables := make([]printable, len(branches))
 
 	for i, branch := range branches {
@@ -24,8 +25,8 @@ func BranchesList(branches []*gitea.Branch, protections []*gitea.BranchProtectio
 		printables[i] = &printableBranch{branch, protection}
 	}
 
-	t := tableFromItems(fields, printables, isMachineReadable(output))
-	t.print(output)
+	t := tableFromItems(fields, printables)
+	return t.print(w, output)
 }
 
 type printableBranch struct {
@@ -33,16 +34,16 @@ type printableBranch struct {
 	protection *gitea.BranchProtection
 }
 
-func (x printableBranch) FormatField(field string, machineReadable bool) string {
+func (x printableBranch) FieldValue(field string) any {
 	switch field {
 	case "name":
 		return x.branch.Name
 	case "protected":
-		return fmt.Sprintf("%t", x.branch.Protected)
+		return x.branch.Protected
 	case "user-can-merge":
-		return fmt.Sprintf("%t", x.branch.UserCanMerge)
+		return x.branch.UserCanMerge
 	case "user-can-push":
-		return fmt.Sprintf("%t", x.branch.UserCanPush)
+		return x.branch.UserCanPush
 	case "protection":
 		if x.protection != nil {
 			approving := ""
@@ -73,7 +74,7 @@ func (x printableBranch) FormatField(field string, machineReadable bool) string
 		}
 		return "<None>"
 	}
-	return ""
+	return nil
 }
 
 // BranchFields are all available fields to print with BranchesList()
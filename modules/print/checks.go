@@ -0,0 +1,95 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// States of a PullCheck, besides the commit status states success, error, failure & warning
+const (
+	CheckPending   = "pending"
+	CheckCancelled = "cancelled"
+	CheckSkipped   = "skipped"
+)
+
+// PullCheck is a check of the head commit of a PR: a commit status, enriched
+// with the Actions job that reported it, if any
+type PullCheck struct {
+	Name        string
+	State       string
+	Description string
+	URL         string
+	Required    bool
+	Started     time.Time
+	Completed   time.Time
+}
+
+// Done returns true if the check is finished
+func (c *PullCheck) Done() bool {
+	return c.State != CheckPending
+}
+
+// Failed returns true if the check finished unsuccessfully
+func (c *PullCheck) Failed() bool {
+	switch c.State {
+	case "failure", "error", CheckCancelled:
+		return true
+	}
+	return false
+}
+
+// duration returns the run time of the check, if known
+func (c *PullCheck) duration() any {
+	if c.Started.IsZero() {
+		return nil
+	}
+	end := c.Completed
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return duration(end.Sub(c.Started) / time.Second)
+}
+
+// PullChecksList prints the checks of a PR
+func PullChecksList(w io.Writer, checks []*PullCheck, output string) error {
+	t := tableWithHeader(
+		"Name",
+		"State",
+		"Required",
+		"Duration",
+		"Description",
+		"URL",
+	)
+
+	for _, c := range checks {
+		t.addRow(
+			c.Name,
+			c.State,
+			c.Required,
+			c.duration(),
+			c.Description,
+			c.URL,
+		)
+	}
+	return t.print(w, output)
+}
+
+// PullChecksSummary returns a one line summary of the states of checks
+func PullChecksSummary(checks []*PullCheck) string {
+	var pending, failed, passed int
+	for _, c := range checks {
+		switch {
+		case !c.Done():
+			pending++
+		case c.Failed():
+			failed++
+		default:
+			passed++
+		}
+	}
+	return fmt.Sprintf("%d checks: %d passed, %d failed, %d pending", len(checks), passed, failed, pending)
+}
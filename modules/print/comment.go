@@ -5,13 +5,14 @@ package print
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // Comments renders a list of comments to stdout
-func Comments(comments []*gitea.Comment) {
+func Comments(w io.Writer, comments []*gitea.Comment) {
 	var baseURL string
 	if len(comments) != 0 {
 		baseURL = getRepoURL(comments[0].HTMLURL)
@@ -22,7 +23,7 @@ func Comments(comments []*gitea.Comment) {
 		out[i] = formatComment(c)
 	}
 
-	_ = outputMarkdown(fmt.Sprintf(
+	_ = outputMarkdown(w, fmt.Sprintf(
 		// this will become a heading by means of the first --- from a comment
 		"Comments\n%s",
 		strings.Join(out, "\n"),
@@ -30,8 +31,8 @@ func Comments(comments []*gitea.Comment) {
 }
 
 // Comment renders a comment to stdout
-func Comment(c *gitea.Comment) {
-	_ = outputMarkdown(formatComment(c), getRepoURL(c.HTMLURL))
+func Comment(w io.Writer, c *gitea.Comment) {
+	_ = outputMarkdown(w, formatComment(c), getRepoURL(c.HTMLURL))
 }
 
 func formatComment(c *gitea.Comment) string {
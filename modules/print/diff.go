@@ -0,0 +1,208 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/diff"
+
+	"github.com/muesli/termenv"
+)
+
+// diffStyle colorizes the parts of a diff like git does, or not at all
+type diffStyle struct {
+	color bool
+}
+
+func (s diffStyle) apply(text string, color termenv.Color, bold bool) string {
+	if !s.color || text == "" {
+		return text
+	}
+	styled := termenv.ANSI.String(text)
+	if color != nil {
+		styled = styled.Foreground(color)
+	}
+	if bold {
+		styled = styled.Bold()
+	}
+	return styled.String()
+}
+
+func (s diffStyle) meta(text string) string    { return s.apply(text, nil, true) }
+func (s diffStyle) hunk(text string) string    { return s.apply(text, termenv.ANSICyan, false) }
+func (s diffStyle) removed(text string) string { return s.apply(text, termenv.ANSIRed, false) }
+func (s diffStyle) added(text string) string   { return s.apply(text, termenv.ANSIGreen, false) }
+
+// Diff prints the diff of files, colorized if color is set. In word diff mode,
+// changed lines are shown once, with the removed & added words marked inline.
+func Diff(w io.Writer, files []*diff.File, color, wordDiff bool) {
+	style := diffStyle{color: color}
+	for _, f := range files {
+		inHunk := false
+		var removed, added []string
+		flush := func() {
+			printWordDiff(w, style, removed, added)
+			removed, added = nil, nil
+		}
+
+		for _, line := range f.Lines {
+			switch {
+			case strings.HasPrefix(line, "@@"):
+				flush()
+				inHunk = true
+				fmt.Fprintln(w, style.hunk(line))
+			case !inHunk:
+				fmt.Fprintln(w, style.meta(line))
+			case wordDiff && strings.HasPrefix(line, "-"):
+				if len(added) != 0 {
+					flush()
+				}
+				removed = append(removed, line[1:])
+			case wordDiff && strings.HasPrefix(line, "+"):
+				added = append(added, line[1:])
+			case wordDiff:
+				flush()
+				fmt.Fprintln(w, strings.TrimPrefix(line, " "))
+			case strings.HasPrefix(line, "-"):
+				fmt.Fprintln(w, style.removed(line))
+			case strings.HasPrefix(line, "+"):
+				fmt.Fprintln(w, style.added(line))
+			default:
+				fmt.Fprintln(w, line)
+			}
+		}
+		flush()
+	}
+}
+
+// printWordDiff prints a block of removed lines followed by added lines,
+// pairing them up line by line
+func printWordDiff(w io.Writer, style diffStyle, removed, added []string) {
+	for i := 0; i < max(len(removed), len(added)); i++ {
+		var segments []diff.Segment
+		switch {
+		case i >= len(added):
+			segments = []diff.Segment{{Op: diff.Removed, Text: removed[i]}}
+		case i >= len(removed):
+			segments = []diff.Segment{{Op: diff.Added, Text: added[i]}}
+		default:
+			segments = diff.Words(removed[i], added[i])
+		}
+
+		var line strings.Builder
+		for _, s := range segments {
+			switch {
+			case s.Op == diff.Removed && style.color:
+				line.WriteString(style.removed(s.Text))
+			case s.Op == diff.Removed:
+				line.WriteString("[-" + s.Text + "-]")
+			case s.Op == diff.Added && style.color:
+				line.WriteString(style.added(s.Text))
+			case s.Op == diff.Added:
+				line.WriteString("{+" + s.Text + "+}")
+			default:
+				line.WriteString(s.Text)
+			}
+		}
+		fmt.Fprintln(w, line.String())
+	}
+}
+
+// maxStatWidth is the width of the largest bar of changes printed by DiffStat
+const maxStatWidth = 50
+
+// DiffStat prints the number of changed lines per file, and a summary, like git diff --stat
+func DiffStat(w io.Writer, files []*diff.File, color bool) {
+	style := diffStyle{color: color}
+	nameWidth, countWidth, maxChanges := 0, 0, 0
+	additions, deletions := 0, 0
+	for _, f := range files {
+		nameWidth = max(nameWidth, len(statName(f)))
+		countWidth = max(countWidth, len(fmt.Sprint(f.Additions+f.Deletions)))
+		maxChanges = max(maxChanges, f.Additions+f.Deletions)
+		additions += f.Additions
+		deletions += f.Deletions
+	}
+
+	for _, f := range files {
+		if f.Binary {
+			fmt.Fprintf(w, " %-*s | Bin\n", nameWidth, statName(f))
+			continue
+		}
+		plus, minus := f.Additions, f.Deletions
+		if maxChanges > maxStatWidth {
+			// scale the bars, but show at least one sign for any change
+			plus = scaleStat(plus, maxChanges)
+			minus = scaleStat(minus, maxChanges)
+		}
+		line := fmt.Sprintf(" %-*s | %*d %s%s", nameWidth, statName(f), countWidth, f.Additions+f.Deletions,
+			style.added(strings.Repeat("+", plus)), style.removed(strings.Repeat("-", minus)))
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+
+	summary := fmt.Sprintf(" %d %s changed", len(files), plural(len(files), "file", "files"))
+	if additions != 0 || deletions == 0 {
+		summary += fmt.Sprintf(", %d %s(+)", additions, plural(additions, "insertion", "insertions"))
+	}
+	if deletions != 0 || additions == 0 {
+		summary += fmt.Sprintf(", %d %s(-)", deletions, plural(deletions, "deletion", "deletions"))
+	}
+	fmt.Fprintln(w, summary)
+}
+
+func statName(f *diff.File) string {
+	if f.OldName != "" && f.OldName != f.Name {
+		return f.OldName + " => " + f.Name
+	}
+	return f.Name
+}
+
+func scaleStat(n, maxChanges int) int {
+	if n == 0 {
+		return 0
+	}
+	return max(1, n*maxStatWidth/maxChanges)
+}
+
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
+
+// DiffNames prints the paths of the changed files
+func DiffNames(w io.Writer, files []*diff.File) {
+	for _, f := range files {
+		fmt.Fprintln(w, f.Name)
+	}
+}
+
+// PullFilesList prints the files changed by a pull request
+func PullFilesList(w io.Writer, files []*gitea.ChangedFile, output string) error {
+	t := tableWithHeader(
+		"Filename",
+		"Status",
+		"Additions",
+		"Deletions",
+	)
+
+	for _, f := range files {
+		name := f.Filename
+		if f.PreviousFilename != "" && f.PreviousFilename != f.Filename && !IsMachineReadable(output) {
+			name = f.PreviousFilename + " => " + f.Filename
+		}
+		t.addRow(
+			name,
+			f.Status,
+			f.Additions,
+			f.Deletions,
+		)
+	}
+	return t.print(w, output)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"code.gitea.io/tea/modules/diff"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@
+ package main
+-var x = 1
+-var y = 2
++var x = 10
++var z = 3
++var w = 4
+ func main() {}
+diff --git a/logo.png b/logo.png
+Binary files a/logo.png and b/logo.png differ
+`
+
+func TestDiff(t *testing.T) {
+	files := diff.Parse(sampleDiff)
+
+	buf := &bytes.Buffer{}
+	Diff(buf, files, false, false)
+	assert.Equal(t, sampleDiff, buf.String())
+
+	buf.Reset()
+	Diff(buf, files, false, true)
+	assert.Equal(t, `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@
+package main
+var x = [-1-]{+10+}
+var [-y-]{+z+} = [-2-]{+3+}
+{+var w = 4+}
+func main() {}
+diff --git a/logo.png b/logo.png
+Binary files a/logo.png and b/logo.png differ
+`, buf.String())
+
+	buf.Reset()
+	Diff(buf, files[:1], true, false)
+	assert.Contains(t, buf.String(), "\x1b[31m-var x = 1\x1b[0m\n")
+	assert.Contains(t, buf.String(), "\x1b[32m+var x = 10\x1b[0m\n")
+}
+
+func TestDiffStat(t *testing.T) {
+	files := diff.Parse(sampleDiff)
+
+	buf := &bytes.Buffer{}
+	DiffStat(buf, files, false)
+	assert.Equal(t, ` main.go  | 5 +++--
+ logo.png | Bin
+ 2 files changed, 3 insertions(+), 2 deletions(-)
+`, buf.String())
+
+	buf.Reset()
+	files[0].Additions = 100
+	DiffStat(buf, files[:1], false)
+	// bars are scaled down to fit, but keep at least one sign
+	assert.Equal(t, " main.go | 102 "+strings.Repeat("+", 49)+"-\n 1 file changed, 100 insertions(+), 2 deletions(-)\n", buf.String())
+
+	buf.Reset()
+	DiffNames(buf, files)
+	assert.Equal(t, "main.go\nlogo.png\n", buf.String())
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"code.gitea.io/tea/modules/utils"
+)
+
+// jsonError is the representation of an error for json output
+type jsonError struct {
+	Kind     string `json:"kind"`
+	ExitCode int    `json:"exit_code"`
+	Message  string `json:"message"`
+}
+
+// Error prints an error to f: as json object if the output format is json,
+// so scripts can handle it, and as plain message otherwise.
+func Error(f io.Writer, err error, output string) {
+	if output == "json" {
+		data, jsonErr := json.MarshalIndent(map[string]jsonError{
+			"error": {
+				Kind:     utils.ErrorKind(err),
+				ExitCode: utils.ExitCode(err),
+				Message:  err.Error(),
+			},
+		}, "", "  ")
+		if jsonErr == nil {
+			fmt.Fprintln(f, string(data))
+			return
+		}
+	}
+	fmt.Fprintf(f, "Error: %v\n", err)
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	err := utils.NewNotFoundErrorf("issue #3 does not exist")
+
+	buf := &bytes.Buffer{}
+	Error(buf, err, "table")
+	assert.Equal(t, "Error: issue #3 does not exist\n", buf.String())
+
+	buf.Reset()
+	Error(buf, err, "json")
+	var result struct {
+		Error struct {
+			Kind     string `json:"kind"`
+			ExitCode int    `json:"exit_code"`
+			Message  string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "not_found", result.Error.Kind)
+	assert.Equal(t, utils.ExitNotFound, result.Error.ExitCode)
+	assert.Equal(t, "issue #3 does not exist", result.Error.Message)
+}
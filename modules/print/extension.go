@@ -0,0 +1,26 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"code.gitea.io/tea/modules/extension"
+	"io"
+)
+
+// ExtensionsList prints a listing of extensions
+func ExtensionsList(w io.Writer, extensions []extension.Extension, output string) error {
+	t := tableWithHeader(
+		"Name",
+		"Source",
+		"Path",
+	)
+	for _, e := range extensions {
+		source := "$PATH"
+		if e.Managed {
+			source = "installed"
+		}
+		t.addRow(e.Name, source, e.Path)
+	}
+	return t.print(w, output)
+}
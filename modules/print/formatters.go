@@ -61,13 +61,6 @@ func FormatTime(t time.Time, machineReadable bool) string {
 	return t.In(location).Format("2006-01-02 15:04")
 }
 
-func formatDuration(seconds int64, outputType string) string {
-	if isMachineReadable(outputType) {
-		return fmt.Sprint(seconds)
-	}
-	return time.Duration(1e9 * seconds).String()
-}
-
 func formatLabel(label *gitea.Label, allowColor bool, text string) string {
 	colorProfile := termenv.Ascii
 	if allowColor {
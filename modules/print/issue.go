@@ -5,14 +5,20 @@ package print
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/enescakir/emoji"
 )
 
-// IssueDetails print an issue rendered to stdout
-func IssueDetails(issue *gitea.Issue, reactions []*gitea.Reaction) {
+// IssueDetails print an issue rendered to stdout, or formatted
+// via template or jq expression if given as output
+func IssueDetails(w io.Writer, issue *gitea.Issue, reactions []*gitea.Reaction, output string) error {
+	if printed, err := outputItem(w, &printableIssue{issue}, IssueFields, output); printed || err != nil {
+		return err
+	}
+
 	out := fmt.Sprintf(
 		"# #%d %s (%s)\n@%s created %s\n\n%s\n",
 		issue.Index,
@@ -27,7 +33,8 @@ func IssueDetails(issue *gitea.Issue, reactions []*gitea.Reaction) {
 		out += fmt.Sprintf("\n---\n\n%s\n", formatReactions(reactions))
 	}
 
-	_ = outputMarkdown(out, getRepoURL(issue.HTMLURL))
+	_ = outputMarkdown(w, out, getRepoURL(issue.HTMLURL))
+	return nil
 }
 
 func formatReactions(reactions []*gitea.Reaction) string {
@@ -45,8 +52,8 @@ func formatReactions(reactions []*gitea.Reaction) string {
 }
 
 // IssuesPullsList prints a listing of issues & pulls
-func IssuesPullsList(issues []*gitea.Issue, output string, fields []string) {
-	printIssues(issues, output, fields)
+func IssuesPullsList(w io.Writer, issues []*gitea.Issue, output string, fields []string) error {
+	return printIssues(w, issues, output, fields)
 }
 
 // IssueFields are all available fields to print with IssuesList()
@@ -73,35 +80,24 @@ var IssueFields = []string{
 	"repo",
 }
 
-func printIssues(issues []*gitea.Issue, output string, fields []string) {
-	labelMap := map[int64]string{}
+func printIssues(w io.Writer, issues []*gitea.Issue, output string, fields []string) error {
 	printables := make([]printable, len(issues))
-	machineReadable := isMachineReadable(output)
-
 	for i, x := range issues {
-		// pre-serialize labels for performance
-		for _, label := range x.Labels {
-			if _, ok := labelMap[label.ID]; !ok {
-				labelMap[label.ID] = formatLabel(label, !machineReadable, "")
-			}
-		}
-		// store items with printable interface
-		printables[i] = &printableIssue{x, &labelMap}
+		printables[i] = &printableIssue{x}
 	}
 
-	t := tableFromItems(fields, printables, machineReadable)
-	t.print(output)
+	t := tableFromItems(fields, printables)
+	return t.print(w, output)
 }
 
 type printableIssue struct {
 	*gitea.Issue
-	formattedLabels *map[int64]string
 }
 
-func (x printableIssue) FormatField(field string, machineReadable bool) string {
+func (x printableIssue) FieldValue(field string) any {
 	switch field {
 	case "index":
-		return fmt.Sprintf("%d", x.Index)
+		return x.Index
 	case "state":
 		return string(x.State)
 	case "kind":
@@ -120,37 +116,26 @@ func (x printableIssue) FormatField(field string, machineReadable bool) string {
 	case "body":
 		return x.Body
 	case "created":
-		return FormatTime(x.Created, machineReadable)
+		return x.Created
 	case "updated":
-		return FormatTime(x.Updated, machineReadable)
+		return x.Updated
 	case "deadline":
-		if x.Deadline == nil {
-			return ""
-		}
-		return FormatTime(*x.Deadline, machineReadable)
+		return x.Deadline
 	case "milestone":
 		if x.Milestone != nil {
 			return x.Milestone.Title
 		}
 		return ""
 	case "labels":
-		labels := make([]string, len(x.Labels))
-		for i, l := range x.Labels {
-			labels[i] = (*x.formattedLabels)[l.ID]
-		}
-		return strings.Join(labels, " ")
+		return labelList(x.Labels)
 	case "assignees":
-		assignees := make([]string, len(x.Assignees))
-		for i, a := range x.Assignees {
-			assignees[i] = formatUserName(a)
-		}
-		return strings.Join(assignees, " ")
+		return userList(x.Assignees)
 	case "comments":
-		return fmt.Sprintf("%d", x.Comments)
+		return x.Comments
 	case "owner":
 		return x.Repository.Owner
 	case "repo":
 		return x.Repository.Name
 	}
-	return ""
+	return nil
 }
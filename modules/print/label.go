@@ -4,13 +4,12 @@
 package print
 
 import (
-	"strconv"
-
 	"code.gitea.io/sdk/gitea"
+	"io"
 )
 
 // LabelsList prints a listing of labels
-func LabelsList(labels []*gitea.Label, output string) {
+func LabelsList(w io.Writer, labels []*gitea.Label, output string) error {
 	t := tableWithHeader(
 		"Index",
 		"Color",
@@ -20,11 +19,11 @@ func LabelsList(labels []*gitea.Label, output string) {
 
 	for _, label := range labels {
 		t.addRow(
-			strconv.FormatInt(label.ID, 10),
-			formatLabel(label, !isMachineReadable(output), label.Color),
+			label.ID,
+			labelColor{label},
 			label.Name,
 			label.Description,
 		)
 	}
-	t.print(output)
+	return t.print(w, output)
 }
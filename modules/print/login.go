@@ -5,6 +5,7 @@ package print
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -12,7 +13,7 @@ import (
 )
 
 // LoginDetails print login entry to stdout
-func LoginDetails(login *config.Login) {
+func LoginDetails(w io.Writer, login *config.Login) {
 	in := fmt.Sprintf("# %s\n\n[@%s](%s/%s)\n",
 		login.Name,
 		login.User,
@@ -25,13 +26,16 @@ func LoginDetails(login *config.Login) {
 			login.SSHHost,
 		)
 	}
+	if len(login.SecretStore) != 0 {
+		in += fmt.Sprintf("\nToken stored in: %s\n", login.SecretStore)
+	}
 	in += fmt.Sprintf("\nCreated: %s", time.Unix(login.Created, 0).Format(time.RFC822))
 
-	_ = outputMarkdown(in, "")
+	_ = outputMarkdown(w, in, "")
 }
 
 // LoginsList prints a listing of logins
-func LoginsList(logins []config.Login, output string) {
+func LoginsList(w io.Writer, logins []config.Login, output string) error {
 	t := tableWithHeader(
 		"Name",
 		"URL",
@@ -46,9 +50,9 @@ func LoginsList(logins []config.Login, output string) {
 			l.URL,
 			l.GetSSHHost(),
 			l.User,
-			fmt.Sprint(l.Default),
+			l.Default,
 		)
 	}
 
-	t.print(output)
+	return t.print(w, output)
 }
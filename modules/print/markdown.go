@@ -5,6 +5,7 @@ package print
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/charmbracelet/glamour"
@@ -14,7 +15,7 @@ import (
 // outputMarkdown prints markdown to stdout, formatted for terminals.
 // If the input could not be parsed, it is printed unformatted, the error
 // is returned anyway.
-func outputMarkdown(markdown string, baseURL string) error {
+func outputMarkdown(w io.Writer, markdown string, baseURL string) error {
 	var styleOption glamour.TermRendererOption
 	if IsInteractive() {
 		styleOption = glamour.WithAutoStyle()
@@ -29,16 +30,16 @@ func outputMarkdown(markdown string, baseURL string) error {
 		glamour.WithWordWrap(getWordWrap()),
 	)
 	if err != nil {
-		fmt.Print(markdown)
+		fmt.Fprint(w, markdown)
 		return err
 	}
 
 	out, err := renderer.Render(markdown)
 	if err != nil {
-		fmt.Print(markdown)
+		fmt.Fprint(w, markdown)
 		return err
 	}
-	fmt.Print(out)
+	fmt.Fprint(w, out)
 	return nil
 }
 
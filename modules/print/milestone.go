@@ -5,32 +5,33 @@ package print
 
 import (
 	"fmt"
+	"io"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // MilestoneDetails print an milestone formatted to stdout
-func MilestoneDetails(milestone *gitea.Milestone) {
-	fmt.Printf("%s\n",
+func MilestoneDetails(w io.Writer, milestone *gitea.Milestone) {
+	fmt.Fprintf(w, "%s\n",
 		milestone.Title,
 	)
 	if len(milestone.Description) != 0 {
-		outputMarkdown(milestone.Description, "")
+		outputMarkdown(w, milestone.Description, "")
 	}
 	if milestone.Deadline != nil && !milestone.Deadline.IsZero() {
-		fmt.Printf("\nDeadline: %s\n", FormatTime(*milestone.Deadline, false))
+		fmt.Fprintf(w, "\nDeadline: %s\n", FormatTime(*milestone.Deadline, false))
 	}
 }
 
 // MilestonesList prints a listing of milestones
-func MilestonesList(news []*gitea.Milestone, output string, fields []string) {
+func MilestonesList(w io.Writer, news []*gitea.Milestone, output string, fields []string) error {
 	printables := make([]printable, len(news))
 	for i, x := range news {
 		printables[i] = &printableMilestone{x}
 	}
-	t := tableFromItems(fields, printables, isMachineReadable(output))
+	t := tableFromItems(fields, printables)
 	t.sort(0, true)
-	t.print(output)
+	return t.print(w, output)
 }
 
 // MilestoneFields are all available fields to print with MilestonesList
@@ -52,36 +53,30 @@ type printableMilestone struct {
 	*gitea.Milestone
 }
 
-func (m printableMilestone) FormatField(field string, machineReadable bool) string {
+func (m printableMilestone) FieldValue(field string) any {
 	switch field {
 	case "title":
 		return m.Title
 	case "state":
 		return string(m.State)
 	case "items_open":
-		return fmt.Sprintf("%d", m.OpenIssues)
+		return m.OpenIssues
 	case "items_closed":
-		return fmt.Sprintf("%d", m.ClosedIssues)
+		return m.ClosedIssues
 	case "items":
 		return fmt.Sprintf("%d/%d", m.OpenIssues, m.ClosedIssues)
 	case "duedate":
-		if m.Deadline != nil && !m.Deadline.IsZero() {
-			return FormatTime(*m.Deadline, machineReadable)
-		}
+		return m.Deadline
 	case "id":
-		return fmt.Sprintf("%d", m.ID)
+		return m.ID
 	case "description":
 		return m.Description
 	case "created":
-		return FormatTime(m.Created, machineReadable)
+		return m.Created
 	case "updated":
-		if m.Updated != nil {
-			return FormatTime(*m.Updated, machineReadable)
-		}
+		return m.Updated
 	case "closed":
-		if m.Closed != nil {
-			return FormatTime(*m.Closed, machineReadable)
-		}
+		return m.Closed
 	}
-	return ""
+	return nil
 }
@@ -4,20 +4,20 @@
 package print
 
 import (
-	"fmt"
+	"io"
 	"strings"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // NotificationsList prints a listing of notification threads
-func NotificationsList(news []*gitea.NotificationThread, output string, fields []string) {
+func NotificationsList(w io.Writer, news []*gitea.NotificationThread, output string, fields []string) error {
 	var printables = make([]printable, len(news))
 	for i, x := range news {
 		printables[i] = &printableNotification{x}
 	}
-	t := tableFromItems(fields, printables, isMachineReadable(output))
-	t.print(output)
+	t := tableFromItems(fields, printables)
+	return t.print(w, output)
 }
 
 // NotificationFields are all available fields to print with NotificationsList
@@ -38,10 +38,10 @@ type printableNotification struct {
 	*gitea.NotificationThread
 }
 
-func (n printableNotification) FormatField(field string, machineReadable bool) string {
+func (n printableNotification) FieldValue(field string) any {
 	switch field {
 	case "id":
-		return fmt.Sprintf("%d", n.ID)
+		return n.ID
 
 	case "status":
 		status := "read"
@@ -53,7 +53,7 @@ func (n printableNotification) FormatField(field string, machineReadable bool) s
 		return status
 
 	case "updated":
-		return FormatTime(n.UpdatedAt, machineReadable)
+		return n.UpdatedAt
 
 	case "index":
 		var index string
@@ -78,5 +78,5 @@ func (n printableNotification) FormatField(field string, machineReadable bool) s
 	case "repo", "repository":
 		return n.Repository.FullName
 	}
-	return ""
+	return nil
 }
@@ -5,13 +5,14 @@ package print
 
 import (
 	"fmt"
+	"io"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // OrganizationDetails prints details of an org with formatting
-func OrganizationDetails(org *gitea.Organization) {
-	_ = outputMarkdown(fmt.Sprintf(
+func OrganizationDetails(w io.Writer, org *gitea.Organization) {
+	_ = outputMarkdown(w, fmt.Sprintf(
 		"# %s\n%s\n\n- Visibility: %s\n- Location: %s\n- Website: %s\n",
 		org.UserName,
 		org.Description,
@@ -22,10 +23,10 @@ func OrganizationDetails(org *gitea.Organization) {
 }
 
 // OrganizationsList prints a listing of the organizations
-func OrganizationsList(organizations []*gitea.Organization, output string) {
+func OrganizationsList(w io.Writer, organizations []*gitea.Organization, output string) error {
 	if len(organizations) == 0 {
-		fmt.Println("No organizations found")
-		return
+		fmt.Fprintln(w, "No organizations found")
+		return nil
 	}
 
 	t := tableWithHeader(
@@ -46,5 +47,5 @@ func OrganizationsList(organizations []*gitea.Organization, output string) {
 		)
 	}
 
-	t.print(output)
+	return t.print(w, output)
 }
@@ -0,0 +1,47 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// WithPager passes a writer to fn, which pipes the output through the pager
+// from $PAGER (less by default) when out is stdout, a terminal and paging is
+// enabled, or writes to out directly otherwise.
+func WithPager(enabled bool, out io.Writer, fn func(w io.Writer)) error {
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if _, set := os.LookupEnv("PAGER"); !set {
+		pager = []string{"less"}
+	}
+	if !enabled || out != os.Stdout || !IsInteractive() || len(pager) == 0 || pager[0] == "cat" {
+		fn(out)
+		return nil
+	}
+
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if _, set := os.LookupEnv("LESS"); !set {
+		// quit if the output fits on one screen, keep colors and the screen content
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		// no usable pager, don't fail over it
+		fn(os.Stdout)
+		return nil
+	}
+
+	fn(in)
+	in.Close()
+	return cmd.Wait()
+}
@@ -5,6 +5,7 @@ package print
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"code.gitea.io/sdk/gitea"
@@ -18,8 +19,13 @@ var ciStatusSymbols = map[gitea.StatusState]string{
 	gitea.StatusFailure: "❌ ",
 }
 
-// PullDetails print an pull rendered to stdout
-func PullDetails(pr *gitea.PullRequest, reviews []*gitea.PullReview, ciStatus *gitea.CombinedStatus) {
+// PullDetails print an pull rendered to stdout, or formatted
+// via template or jq expression if given as output
+func PullDetails(w io.Writer, pr *gitea.PullRequest, reviews []*gitea.PullReview, ciStatus *gitea.CombinedStatus, output string) error {
+	if printed, err := outputItem(w, &printablePull{pr}, PullFields, output); printed || err != nil {
+		return err
+	}
+
 	base := pr.Base.Name
 	head := formatPRHead(pr)
 	state := formatPRState(pr)
@@ -67,7 +73,8 @@ func PullDetails(pr *gitea.PullRequest, reviews []*gitea.PullReview, ciStatus *g
 		out += "- Maintainers are allowed to edit\n"
 	}
 
-	outputMarkdown(out, getRepoURL(pr.HTMLURL))
+	outputMarkdown(w, out, getRepoURL(pr.HTMLURL))
+	return nil
 }
 
 func formatPRHead(pr *gitea.PullRequest) string {
@@ -139,8 +146,8 @@ func formatReviews(pr *gitea.PullRequest, reviews []*gitea.PullReview) string {
 }
 
 // PullsList prints a listing of pulls
-func PullsList(prs []*gitea.PullRequest, output string, fields []string) {
-	printPulls(prs, output, fields)
+func PullsList(w io.Writer, prs []*gitea.PullRequest, output string, fields []string) error {
+	return printPulls(w, prs, output, fields)
 }
 
 // PullFields are all available fields to print with PullsList()
@@ -171,35 +178,24 @@ var PullFields = []string{
 	"comments",
 }
 
-func printPulls(pulls []*gitea.PullRequest, output string, fields []string) {
-	labelMap := map[int64]string{}
-	var printables = make([]printable, len(pulls))
-	machineReadable := isMachineReadable(output)
-
+func printPulls(w io.Writer, pulls []*gitea.PullRequest, output string, fields []string) error {
+	printables := make([]printable, len(pulls))
 	for i, x := range pulls {
-		// pre-serialize labels for performance
-		for _, label := range x.Labels {
-			if _, ok := labelMap[label.ID]; !ok {
-				labelMap[label.ID] = formatLabel(label, !machineReadable, "")
-			}
-		}
-		// store items with printable interface
-		printables[i] = &printablePull{x, &labelMap}
+		printables[i] = &printablePull{x}
 	}
 
-	t := tableFromItems(fields, printables, machineReadable)
-	t.print(output)
+	t := tableFromItems(fields, printables)
+	return t.print(w, output)
 }
 
 type printablePull struct {
 	*gitea.PullRequest
-	formattedLabels *map[int64]string
 }
 
-func (x printablePull) FormatField(field string, machineReadable bool) string {
+func (x printablePull) FieldValue(field string) any {
 	switch field {
 	case "index":
-		return fmt.Sprintf("%d", x.Index)
+		return x.Index
 	case "state":
 		return formatPRState(x.PullRequest)
 	case "author":
@@ -213,36 +209,24 @@ func (x printablePull) FormatField(field string, machineReadable bool) string {
 	case "body":
 		return x.Body
 	case "created":
-		return FormatTime(*x.Created, machineReadable)
+		return x.Created
 	case "updated":
-		return FormatTime(*x.Updated, machineReadable)
+		return x.Updated
 	case "deadline":
-		if x.Deadline == nil {
-			return ""
-		}
-		return FormatTime(*x.Deadline, machineReadable)
+		return x.Deadline
 	case "milestone":
 		if x.Milestone != nil {
 			return x.Milestone.Title
 		}
 		return ""
 	case "labels":
-		var labels = make([]string, len(x.Labels))
-		for i, l := range x.Labels {
-			labels[i] = (*x.formattedLabels)[l.ID]
-		}
-		return strings.Join(labels, " ")
+		return labelList(x.Labels)
 	case "assignees":
-		var assignees = make([]string, len(x.Assignees))
-		for i, a := range x.Assignees {
-			assignees[i] = formatUserName(a)
-		}
-		return strings.Join(assignees, " ")
+		return userList(x.Assignees)
 	case "comments":
-		return fmt.Sprintf("%d", x.Comments)
+		return x.Comments
 	case "mergeable":
-		isMergeable := x.Mergeable && x.State == gitea.StateOpen
-		return formatBoolean(isMergeable, !machineReadable)
+		return x.Mergeable && x.State == gitea.StateOpen
 	case "base":
 		return x.Base.Ref
 	case "base-commit":
@@ -254,5 +238,5 @@ func (x printablePull) FormatField(field string, machineReadable bool) string {
 	case "patch":
 		return x.PatchURL
 	}
-	return ""
+	return nil
 }
@@ -5,10 +5,11 @@ package print
 
 import (
 	"code.gitea.io/sdk/gitea"
+	"io"
 )
 
 // ReleasesList prints a listing of releases
-func ReleasesList(releases []*gitea.Release, output string) {
+func ReleasesList(w io.Writer, releases []*gitea.Release, output string) error {
 	t := tableWithHeader(
 		"Tag-Name",
 		"Title",
@@ -27,11 +28,14 @@ func ReleasesList(releases []*gitea.Release, output string) {
 		t.addRow(
 			release.TagName,
 			release.Title,
-			FormatTime(release.PublishedAt, isMachineReadable(output)),
+			release.PublishedAt,
 			status,
-			release.TarURL+"\n"+release.ZipURL,
+			formatted{
+				value: map[string]string{"tar": release.TarURL, "zip": release.ZipURL},
+				text:  release.TarURL + "\n" + release.ZipURL,
+			},
 		)
 	}
 
-	t.print(output)
+	return t.print(w, output)
 }
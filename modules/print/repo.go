@@ -5,6 +5,7 @@ package print
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -12,17 +13,22 @@ import (
 )
 
 // ReposList prints a listing of the repos
-func ReposList(repos []*gitea.Repository, output string, fields []string) {
+func ReposList(w io.Writer, repos []*gitea.Repository, output string, fields []string) error {
 	printables := make([]printable, len(repos))
 	for i, r := range repos {
 		printables[i] = &printableRepo{r}
 	}
-	t := tableFromItems(fields, printables, isMachineReadable(output))
-	t.print(output)
+	t := tableFromItems(fields, printables)
+	return t.print(w, output)
 }
 
-// RepoDetails print an repo formatted to stdout
-func RepoDetails(repo *gitea.Repository, topics []string) {
+// RepoDetails print an repo formatted to stdout, or formatted
+// via template or jq expression if given as output
+func RepoDetails(w io.Writer, repo *gitea.Repository, topics []string, output string) error {
+	if printed, err := outputItem(w, &printableRepo{repo}, RepoFields, output); printed || err != nil {
+		return err
+	}
+
 	title := "# " + repo.FullName
 	if repo.Mirror {
 		title += " (mirror)"
@@ -77,7 +83,7 @@ func RepoDetails(repo *gitea.Repository, topics []string) {
 		tops = fmt.Sprintf("- Topics:\t%s\n", strings.Join(topics, ", "))
 	}
 
-	outputMarkdown(fmt.Sprintf(
+	outputMarkdown(w, fmt.Sprintf(
 		"%s%s\n%s\n%s%s%s%s",
 		title,
 		desc,
@@ -87,6 +93,7 @@ func RepoDetails(repo *gitea.Repository, topics []string) {
 		perm,
 		tops,
 	), repo.HTMLURL)
+	return nil
 }
 
 // RepoFields are the available fields to print with ReposList()
@@ -106,12 +113,12 @@ var RepoFields = []string{
 
 type printableRepo struct{ *gitea.Repository }
 
-func (x printableRepo) FormatField(field string, machineReadable bool) string {
+func (x printableRepo) FieldValue(field string) any {
 	switch field {
 	case "description":
 		return x.Description
 	case "forks":
-		return fmt.Sprintf("%d", x.Forks)
+		return x.Forks
 	case "id":
 		return x.FullName
 	case "name":
@@ -119,11 +126,11 @@ func (x printableRepo) FormatField(field string, machineReadable bool) string {
 	case "owner":
 		return x.Owner.UserName
 	case "stars":
-		return fmt.Sprintf("%d", x.Stars)
+		return x.Stars
 	case "ssh":
 		return x.SSHURL
 	case "updated":
-		return FormatTime(x.Updated, machineReadable)
+		return x.Updated
 	case "url":
 		return x.HTMLURL
 	case "permission":
@@ -137,5 +144,5 @@ func (x printableRepo) FormatField(field string, machineReadable bool) string {
 		}
 		return "source"
 	}
-	return ""
+	return nil
 }
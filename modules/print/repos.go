@@ -0,0 +1,83 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"sync"
+)
+
+// Collector is the output of a command run for one of multiple repositories.
+// Printers given a Collector as writer collect their tables, to print them as
+// one table with a repository column, while anything else is buffered, so the
+// output of the commands run in parallel doesn't interleave.
+type Collector struct {
+	Repo   string
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	tables []table
+}
+
+// NewCollector returns a collector for the output of a command run for repo
+func NewCollector(repo string) *Collector {
+	return &Collector{Repo: repo}
+}
+
+// Write buffers output other than tables
+func (c *Collector) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.Write(p)
+}
+
+// WriteTo writes the buffered output to w
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.WriteTo(w)
+}
+
+func (c *Collector) add(t table) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tables = append(c.tables, t)
+}
+
+// ReposTable prints the tables collected for multiple repositories as one table,
+// with the repository as first column. Nothing is printed if no table was collected.
+func ReposTable(w io.Writer, repos []*Collector, output string) error {
+	if !slices.ContainsFunc(repos, func(c *Collector) bool { return len(c.tables) != 0 }) {
+		return nil
+	}
+	t := reposTable(repos)
+	return t.print(w, output)
+}
+
+func reposTable(repos []*Collector) table {
+	t := tableWithHeader("repository")
+	for _, r := range repos {
+		for _, rt := range r.tables {
+			for _, h := range rt.headers {
+				if !slices.Contains(t.headers, h) {
+					t.headers = append(t.headers, h)
+				}
+			}
+		}
+	}
+	for _, r := range repos {
+		for _, rt := range r.tables {
+			for _, values := range rt.values {
+				row := make([]any, len(t.headers))
+				row[0] = r.Repo
+				for i, v := range values {
+					row[slices.Index(t.headers, rt.headers[i])] = v
+				}
+				t.addRowSlice(row)
+			}
+		}
+	}
+	return t
+}
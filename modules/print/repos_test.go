@@ -0,0 +1,64 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReposTable(t *testing.T) {
+	updated := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	a := NewCollector("org/a")
+	tblA := tableWithHeader("index", "title", "labels", "updated")
+	tblA.addRow(int64(2), "Crash", []string{"bug"}, updated)
+	tblA.addRow(int64(1), "Docs", []string{}, time.Time{})
+	require.NoError(t, tblA.print(a, "csv"))
+
+	b := NewCollector("org/b")
+	tblB := tableWithHeader("title", "index", "milestone")
+	tblB.addRow("Slow", int64(7), "v1")
+	require.NoError(t, tblB.print(b, "csv"))
+
+	empty := NewCollector("org/c")
+	tblEmpty := tableWithHeader("index", "title")
+	require.NoError(t, tblEmpty.print(empty, "csv"))
+
+	tbl := reposTable([]*Collector{a, b, empty})
+	assert.Equal(t, []string{"repository", "index", "title", "labels", "updated", "milestone"}, tbl.headers)
+	require.Len(t, tbl.values, 3)
+	assert.Equal(t, updated, tbl.values[0][4])
+	assert.Equal(t, []string{"bug"}, tbl.values[0][3])
+	assert.Equal(t, "org/b", tbl.values[2][0])
+	assert.Equal(t, "Slow", tbl.values[2][2])
+	assert.Nil(t, tbl.values[2][3])
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, tbl.fprint(buf, "csv"))
+	assert.Equal(t, `"repository","index","title","labels","updated","milestone"
+"org/a","2","Crash","bug","2025-01-02T10:00:00Z",""
+"org/a","1","Docs","","",""
+"org/b","7","Slow","","","v1"
+`, buf.String())
+}
+
+func TestCollectorBuffersOutput(t *testing.T) {
+	c := NewCollector("org/a")
+	tbl := tableWithHeader("index", "title")
+	tbl.addRow(int64(1), "Docs")
+	require.NoError(t, tbl.print(c, "json"))
+	ActionVariableDetails(c, &gitea.RepoActionVariable{Name: "CI", Value: "true"})
+
+	buf := &bytes.Buffer{}
+	_, err := c.WriteTo(buf)
+	require.NoError(t, err)
+	// tables are collected, anything else is buffered
+	assert.Equal(t, "Name: CI\nValue: true\nRepository ID: 0\nOwner ID: 0\n", buf.String())
+	assert.Len(t, c.tables, 1)
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// PullReviewsList prints a listing of the reviews of a PR
+func PullReviewsList(w io.Writer, reviews []*gitea.PullReview, output string) error {
+	t := tableWithHeader(
+		"ID",
+		"Reviewer",
+		"State",
+		"Comments",
+		"Stale",
+		"Dismissed",
+		"Submitted",
+	)
+
+	for _, r := range reviews {
+		reviewer := ""
+		if r.Reviewer != nil {
+			reviewer = r.Reviewer.UserName
+		} else if r.ReviewerTeam != nil {
+			reviewer = r.ReviewerTeam.Name
+		}
+		t.addRow(
+			r.ID,
+			reviewer,
+			string(r.State),
+			r.CodeCommentsCount,
+			r.Stale,
+			r.Dismissed,
+			r.Submitted,
+		)
+	}
+	return t.print(w, output)
+}
+
+// ReviewThread is a conversation of review comments on a line of a PR
+type ReviewThread struct {
+	Path string
+	// Line is the line number in the new file, OldLine in the old file for comments on removed lines
+	Line     uint64
+	OldLine  uint64
+	DiffHunk string
+	// Outdated is set if the thread was started on an older commit than the head of the PR
+	Outdated bool
+	// Resolver is set if the thread was marked as resolved
+	Resolver *gitea.User
+	Comments []*gitea.PullReviewComment
+}
+
+// GroupReviewThreads groups review comments into threads by file and line,
+// in the order of the files and lines
+func GroupReviewThreads(comments []*gitea.PullReviewComment, headSha string) []*ReviewThread {
+	type location struct {
+		path          string
+		line, oldLine uint64
+	}
+	byLocation := map[location]*ReviewThread{}
+	var threads []*ReviewThread
+
+	sorted := slices.Clone(comments)
+	slices.SortStableFunc(sorted, func(a, b *gitea.PullReviewComment) int {
+		return a.Created.Compare(b.Created)
+	})
+	for _, c := range sorted {
+		loc := location{c.Path, c.LineNum, c.OldLineNum}
+		thread, ok := byLocation[loc]
+		if !ok {
+			thread = &ReviewThread{
+				Path:     c.Path,
+				Line:     c.LineNum,
+				OldLine:  c.OldLineNum,
+				DiffHunk: c.DiffHunk,
+				Outdated: headSha != "" && c.CommitID != headSha,
+			}
+			byLocation[loc] = thread
+			threads = append(threads, thread)
+		}
+		if c.Resolver != nil {
+			thread.Resolver = c.Resolver
+		}
+		thread.Comments = append(thread.Comments, c)
+	}
+
+	slices.SortStableFunc(threads, func(a, b *ReviewThread) int {
+		return cmp.Or(
+			cmp.Compare(a.Path, b.Path),
+			cmp.Compare(max(a.Line, a.OldLine), max(b.Line, b.OldLine)),
+		)
+	})
+	return threads
+}
+
+// ReviewThreads renders review threads with their diff hunk to stdout, or
+// prints their comments as a table if an output format is given
+func ReviewThreads(w io.Writer, threads []*ReviewThread, output string) error {
+	if output != "" {
+		t := reviewCommentsTable(threads)
+		return t.print(w, output)
+	}
+	if len(threads) == 0 {
+		fmt.Fprintln(w, "No review comments")
+		return nil
+	}
+
+	var baseURL string
+	out := make([]string, len(threads))
+	for i, t := range threads {
+		baseURL = getRepoURL(t.Comments[0].HTMLURL)
+		out[i] = formatReviewThread(t)
+	}
+	_ = outputMarkdown(w, strings.Join(out, "\n"), baseURL)
+	return nil
+}
+
+func formatReviewThread(t *ReviewThread) string {
+	var markers []string
+	if t.Outdated {
+		markers = append(markers, "outdated")
+	}
+	if t.Resolver != nil {
+		markers = append(markers, "resolved by @"+t.Resolver.UserName)
+	}
+	out := fmt.Sprintf("## %s:%s", t.Path, formatThreadLine(t))
+	if len(markers) != 0 {
+		out += fmt.Sprintf(" *(%s)*", strings.Join(markers, ", "))
+	}
+	out += "\n\n"
+	if hunk := strings.TrimRight(t.DiffHunk, "\n"); hunk != "" {
+		out += "```diff\n" + hunk + "\n```\n\n"
+	}
+
+	for _, c := range t.Comments {
+		edited := ""
+		if c.Updated.After(c.Created) {
+			edited = fmt.Sprintf(" *(edited on %s)*", FormatTime(c.Updated, false))
+		}
+		reviewer := "ghost"
+		if c.Reviewer != nil {
+			reviewer = c.Reviewer.UserName
+		}
+		out += fmt.Sprintf("**@%s** wrote on %s%s (comment %d):\n\n%s\n\n",
+			reviewer,
+			FormatTime(c.Created, false),
+			edited,
+			c.ID,
+			c.Body,
+		)
+	}
+	return out + "---\n"
+}
+
+func formatThreadLine(t *ReviewThread) string {
+	if t.Line == 0 && t.OldLine != 0 {
+		return fmt.Sprintf("%d (old)", t.OldLine)
+	}
+	return fmt.Sprint(t.Line)
+}
+
+func reviewCommentsTable(threads []*ReviewThread) table {
+	t := tableWithHeader(
+		"ID",
+		"Review",
+		"Path",
+		"Line",
+		"Old Line",
+		"Reviewer",
+		"Outdated",
+		"Resolved",
+		"Created",
+		"Body",
+	)
+
+	for _, thread := range threads {
+		for _, c := range thread.Comments {
+			reviewer := ""
+			if c.Reviewer != nil {
+				reviewer = c.Reviewer.UserName
+			}
+			t.addRow(
+				c.ID,
+				c.ReviewID,
+				c.Path,
+				c.LineNum,
+				c.OldLineNum,
+				reviewer,
+				thread.Outdated,
+				thread.Resolver != nil,
+				c.Created,
+				c.Body,
+			)
+		}
+	}
+	return t
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupReviewThreads(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	alice := &gitea.User{UserName: "alice"}
+	bob := &gitea.User{UserName: "bob"}
+	comments := []*gitea.PullReviewComment{
+		{ID: 3, Reviewer: bob, Path: "main.go", LineNum: 12, CommitID: "new", Created: day(3), Body: "Because."},
+		{ID: 1, Reviewer: alice, Path: "main.go", LineNum: 12, CommitID: "old", Created: day(1), DiffHunk: "@@ -10,3 +10,3 @@", Body: "Why?"},
+		{ID: 2, Reviewer: alice, Path: "main.go", OldLineNum: 4, CommitID: "new", Created: day(2), Resolver: bob},
+		{ID: 4, Reviewer: alice, Path: "README.md", LineNum: 1, CommitID: "new", Created: day(4)},
+	}
+
+	threads := GroupReviewThreads(comments, "new")
+	require.Len(t, threads, 3)
+
+	assert.Equal(t, "README.md", threads[0].Path)
+
+	assert.Equal(t, uint64(4), threads[1].OldLine)
+	assert.False(t, threads[1].Outdated)
+	assert.Equal(t, bob, threads[1].Resolver)
+
+	assert.Equal(t, uint64(12), threads[2].Line)
+	assert.True(t, threads[2].Outdated)
+	assert.Nil(t, threads[2].Resolver)
+	assert.Equal(t, "@@ -10,3 +10,3 @@", threads[2].DiffHunk)
+	require.Len(t, threads[2].Comments, 2)
+	assert.Equal(t, int64(1), threads[2].Comments[0].ID)
+	assert.Equal(t, int64(3), threads[2].Comments[1].ID)
+
+	formatted := formatReviewThread(threads[2])
+	assert.Contains(t, formatted, "## main.go:12 *(outdated)*\n\n```diff\n@@ -10,3 +10,3 @@\n```\n\n")
+	assert.Contains(t, formatted, "**@bob** wrote on")
+	assert.Contains(t, formatReviewThread(threads[1]), "## main.go:4 (old) *(resolved by @bob)*")
+}
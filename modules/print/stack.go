@@ -0,0 +1,70 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"fmt"
+	"io"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// StackBranch is a local branch of a stack, with the PR of its changes
+type StackBranch struct {
+	Branch string
+	// Base is the branch below in the stack, or the trunk the stack is based on
+	Base string
+	// Pull is nil, if no PR was created for the branch yet
+	Pull    *gitea.PullRequest
+	Current bool
+}
+
+// Stack prints the branches of a stack, which are ordered from the bottom to the top.
+// Without output format, they are shown like `git log`: the top first, trunk last.
+func Stack(w io.Writer, branches []*StackBranch, output string) error {
+	if output == "" {
+		stackGraph(w, branches)
+		return nil
+	}
+
+	t := tableWithHeader(
+		"Branch",
+		"Base",
+		"Index",
+		"State",
+		"Title",
+		"URL",
+		"Current",
+	)
+	for _, b := range branches {
+		if b.Pull == nil {
+			t.addRow(b.Branch, b.Base, nil, nil, nil, nil, b.Current)
+			continue
+		}
+		t.addRow(b.Branch, b.Base, b.Pull.Index, formatPRState(b.Pull), b.Pull.Title, b.Pull.HTMLURL, b.Current)
+	}
+	return t.print(w, output)
+}
+
+func stackGraph(w io.Writer, branches []*StackBranch) {
+	width := 0
+	for _, b := range branches {
+		width = max(width, len(b.Branch))
+	}
+	for i := len(branches) - 1; i >= 0; i-- {
+		b := branches[i]
+		marker := "○"
+		if b.Current {
+			marker = "●"
+		}
+		pull := "no pull request"
+		if b.Pull != nil {
+			pull = fmt.Sprintf("#%d %s [%s]", b.Pull.Index, b.Pull.Title, formatPRState(b.Pull))
+		}
+		fmt.Fprintf(w, "%s %-*s  %s\n│\n", marker, width, b.Branch, pull)
+	}
+	if len(branches) != 0 {
+		fmt.Fprintln(w, branches[0].Base)
+	}
+}
@@ -4,38 +4,43 @@
 package print
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 
+	"code.gitea.io/tea/modules/utils"
+
 	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
 )
 
-// table provides infrastructure to easily print (sorted) lists in different formats
+// table provides infrastructure to easily print (sorted) lists in different formats.
+// Cells hold typed values, see value.go for how they are rendered.
 type table struct {
 	headers    []string
-	values     [][]string
+	values     [][]any
 	sortDesc   bool // used internally by sortable interface
 	sortColumn uint // ↑
 }
 
 // printable can be implemented for structs to put fields dynamically into a table
 type printable interface {
-	FormatField(field string, machineReadable bool) string
+	// FieldValue returns the typed value of the given field
+	FieldValue(field string) any
 }
 
 // high level api to print a table of items with dynamic fields
-func tableFromItems(fields []string, values []printable, machineReadable bool) table {
+func tableFromItems(fields []string, values []printable) table {
 	t := table{headers: fields}
 	for _, v := range values {
-		row := make([]string, len(fields))
+		row := make([]any, len(fields))
 		for i, f := range fields {
-			row[i] = v.FormatField(f, machineReadable)
+			row[i] = v.FieldValue(f)
 		}
 		t.addRowSlice(row)
 	}
@@ -47,12 +52,12 @@ func tableWithHeader(header ...string) table {
 }
 
 // it's the callers responsibility to ensure row length is equal to header length!
-func (t *table) addRow(row ...string) {
+func (t *table) addRow(row ...any) {
 	t.addRowSlice(row)
 }
 
 // it's the callers responsibility to ensure row length is equal to header length!
-func (t *table) addRowSlice(row []string) {
+func (t *table) addRowSlice(row []any) {
 	t.values = append(t.values, row)
 }
 
@@ -69,38 +74,59 @@ func (t table) Less(i, j int) bool {
 	if t.sortDesc {
 		i, j = j, i
 	}
-	return t.values[i][t.sortColumn] < t.values[j][t.sortColumn]
+	return lessValue(t.values[i][t.sortColumn], t.values[j][t.sortColumn])
 }
 
-func (t *table) print(output string) {
-	t.fprint(os.Stdout, output)
+// print prints the table to w, unless w is a Collector, which collects it instead
+func (t *table) print(w io.Writer, output string) error {
+	if c, ok := w.(*Collector); ok {
+		c.add(*t)
+		return nil
+	}
+	return t.fprint(w, output)
 }
 
-func (t *table) fprint(f io.Writer, output string) {
+func (t *table) fprint(f io.Writer, output string) error {
+	if IsQueryOutput(output) {
+		return outputQuery(f, output, t.jsonRows())
+	}
+
 	switch output {
 	case "", "table":
-		outputTable(f, t.headers, t.values)
+		return outputTable(f, t.headers, t.textValues(false))
 	case "csv":
-		outputDsv(f, t.headers, t.values, ",")
+		outputDsv(f, t.headers, t.textValues(true), ",")
 	case "simple":
-		outputSimple(f, t.headers, t.values)
+		outputSimple(f, t.headers, t.textValues(false))
 	case "tsv":
-		outputDsv(f, t.headers, t.values, "\t")
+		outputDsv(f, t.headers, t.textValues(true), "\t")
 	case "yml", "yaml":
 		outputYaml(f, t.headers, t.values)
 	case "json":
 		outputJSON(f, t.headers, t.values)
 	default:
-		fmt.Fprintf(f, `"unknown output type '%s', available types are:
+		return utils.NewValidationErrorf(`unknown output type '%s', available types are:
 - csv: comma-separated values
 - simple: space-separated values
 - table: auto-aligned table format (default)
 - tsv: tab-separated values
 - yaml: YAML format
 - json: JSON format
-`, output)
-		os.Exit(1)
+- template=<go-template>: go-template executed for each item, e.g. 'template={{.index}} {{.title}}'`, output)
+	}
+	return nil
+}
+
+// textValues renders all cells as text
+func (t *table) textValues(machineReadable bool) [][]string {
+	values := make([][]string, len(t.values))
+	for i, row := range t.values {
+		values[i] = make([]string, len(row))
+		for j, v := range row {
+			values[i][j] = formatText(v, machineReadable)
+		}
 	}
+	return values
 }
 
 // outputTable prints structured data as table
@@ -141,18 +167,30 @@ func outputDsv(f io.Writer, headers []string, values [][]string, delimiterOpt ..
 }
 
 // outputYaml prints structured data as yaml
-func outputYaml(f io.Writer, headers []string, values [][]string) {
-	for _, value := range values {
-		fmt.Fprintln(f, "-")
-		for j, val := range value {
-			intVal, _ := strconv.Atoi(val)
-			if strconv.Itoa(intVal) == val {
-				fmt.Fprintf(f, "  %s: %s\n", headers[j], val)
-			} else {
-				fmt.Fprintf(f, "  %s: '%s'\n", headers[j], strings.ReplaceAll(val, "'", "''"))
+func outputYaml(f io.Writer, headers []string, values [][]any) {
+	doc := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range values {
+		item := &yaml.Node{Kind: yaml.MappingNode}
+		for j, val := range row {
+			valNode := &yaml.Node{}
+			if err := valNode.Encode(structuredValue(val)); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to format YAML for value '%v': %v\n", val, err)
+				return
 			}
+			item.Content = append(item.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: headers[j]},
+				valNode,
+			)
 		}
+		doc.Content = append(doc.Content, item)
+	}
+
+	enc := yaml.NewEncoder(f)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to format YAML: %v\n", err)
 	}
+	enc.Close()
 }
 
 var (
@@ -166,45 +204,60 @@ func toSnakeCase(str string) string {
 	return strings.ToLower(snake)
 }
 
-// outputJSON prints structured data as json
-// Since golang's map is unordered, we need to ensure consistent ordering, we have
-// to output the JSON ourselves.
-func outputJSON(f io.Writer, headers []string, values [][]string) {
-	fmt.Fprintln(f, "[")
-	itemCount := len(values)
-	headersCount := len(headers)
-	const space = "  "
-	for i, value := range values {
-		fmt.Fprintf(f, "%s{\n", space)
-		for j, val := range value {
-			v, err := json.Marshal(val)
-			if err != nil {
-				fmt.Printf("Failed to format JSON for value '%s': %v\n", val, err)
-				return
-			}
-			key, err := json.Marshal(toSnakeCase(headers[j]))
-			if err != nil {
-				fmt.Printf("Failed to format JSON for header '%s': %v\n", headers[j], err)
-				return
-			}
-			fmt.Fprintf(f, "%s:%s", key, v)
-			if j != headersCount-1 {
-				fmt.Fprintln(f, ",")
-			} else {
-				fmt.Fprintln(f)
-			}
-		}
+// jsonRow is a table row, marshalled as object with keys in header order.
+// Since golang's map is unordered, we need to ensure consistent ordering ourselves.
+type jsonRow struct {
+	headers []string
+	values  []any
+}
 
-		if i != itemCount-1 {
-			fmt.Fprintf(f, "%s},\n", space)
-		} else {
-			fmt.Fprintf(f, "%s}\n", space)
+func (r jsonRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for j, val := range r.values {
+		if j != 0 {
+			buf.WriteByte(',')
 		}
+		key, err := json.Marshal(toSnakeCase(r.headers[j]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to format JSON for header '%s': %w", r.headers[j], err)
+		}
+		v, err := json.Marshal(structuredValue(val))
+		if err != nil {
+			return nil, fmt.Errorf("failed to format JSON for value '%v': %w", val, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(v)
 	}
-	fmt.Fprintln(f, "]")
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
 
-func isMachineReadable(outputFormat string) bool {
+func (t *table) jsonRows() []jsonRow {
+	rows := make([]jsonRow, len(t.values))
+	for i, row := range t.values {
+		rows[i] = jsonRow{headers: t.headers, values: row}
+	}
+	return rows
+}
+
+// outputJSON prints structured data as json
+func outputJSON(f io.Writer, headers []string, values [][]any) {
+	rows := (&table{headers: headers, values: values}).jsonRows()
+	data, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to format JSON: %v\n", err)
+		return
+	}
+	fmt.Fprintln(f, string(data))
+}
+
+// IsMachineReadable returns true for output formats meant to be parsed by other programs
+func IsMachineReadable(outputFormat string) bool {
+	if IsQueryOutput(outputFormat) {
+		return true
+	}
 	switch outputFormat {
 	case "yml", "yaml", "csv", "tsv", "json":
 		return true
@@ -7,8 +7,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestToSnakeCase(t *testing.T) {
@@ -18,7 +23,7 @@ func TestToSnakeCase(t *testing.T) {
 func TestPrint(t *testing.T) {
 	tData := &table{
 		headers: []string{"A", "B"},
-		values: [][]string{
+		values: [][]any{
 			{"new a", "some bbbb"},
 			{"AAAAA", "b2"},
 			{"\"abc", "\"def"},
@@ -53,20 +58,83 @@ func TestPrint(t *testing.T) {
 
 	tData.fprint(buf, "yaml")
 
-	assert.Equal(t, `-
-  A: 'new a'
-  B: 'some bbbb'
--
-  A: 'AAAAA'
-  B: 'b2'
--
-  A: '"abc'
+	assert.Equal(t, `- A: new a
+  B: some bbbb
+- A: AAAAA
+  B: b2
+- A: '"abc'
   B: '"def'
--
-  A: '''abc'
-  B: 'de''f'
--
-  A: '\abc'
+- A: '''abc'
+  B: de'f
+- A: \abc
   B: '''def\'
 `, buf.String())
 }
+
+func TestPrintTyped(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tData := tableFromItems(
+		[]string{"index", "title", "mergeable", "created", "deadline", "labels", "assignees"},
+		[]printable{
+			&printablePull{&gitea.PullRequest{
+				Index:     7,
+				Title:     "Add feature",
+				State:     gitea.StateOpen,
+				Mergeable: true,
+				Created:   &created,
+				Labels: []*gitea.Label{
+					{ID: 1, Name: "kind/feature", Color: "00ff00", Description: "New functionality"},
+				},
+				Assignees: []*gitea.User{{UserName: "alice", FullName: "Alice"}, {UserName: "bob"}},
+			}},
+		},
+	)
+
+	buf := &bytes.Buffer{}
+	tData.fprint(buf, "json")
+	assert.JSONEq(t, `[{
+		"index": 7,
+		"title": "Add feature",
+		"mergeable": true,
+		"created": "2025-03-01T12:00:00Z",
+		"deadline": null,
+		"labels": [{"id": 1, "name": "kind/feature", "color": "00ff00", "description": "New functionality"}],
+		"assignees": ["alice", "bob"]
+	}]`, buf.String())
+
+	buf.Reset()
+	tData.fprint(buf, "yaml")
+	var result []map[string]any
+	assert.NoError(t, yaml.Unmarshal(buf.Bytes(), &result))
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, 7, result[0]["index"])
+		assert.Equal(t, true, result[0]["mergeable"])
+		assert.Nil(t, result[0]["deadline"])
+		assert.Equal(t, []any{"alice", "bob"}, result[0]["assignees"])
+	}
+
+	buf.Reset()
+	tData.fprint(buf, "csv")
+	assert.Equal(t, `"index","title","mergeable","created","deadline","labels","assignees"
+"7","Add feature","true","2025-03-01T12:00:00Z","","kind/feature","Alice bob"
+`, buf.String())
+}
+
+func TestTableUnknownOutput(t *testing.T) {
+	tData := table{headers: []string{"id"}}
+	tData.addRow(int64(1))
+	buf := &bytes.Buffer{}
+	err := tData.fprint(buf, "xml")
+	assert.ErrorIs(t, err, utils.ErrValidation)
+	assert.Contains(t, err.Error(), "unknown output type 'xml'")
+	assert.Empty(t, buf.String())
+}
+
+func TestTableSortTyped(t *testing.T) {
+	tData := table{headers: []string{"id"}}
+	tData.addRow(int64(10))
+	tData.addRow(int64(9))
+	tData.addRow(int64(100))
+	tData.sort(0, false)
+	assert.Equal(t, [][]any{{int64(9)}, {int64(10)}, {int64(100)}}, tData.values)
+}
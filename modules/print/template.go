@@ -0,0 +1,161 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/itchyny/gojq"
+)
+
+const (
+	// templateOutputPrefix selects go-template output, e.g. -o 'template={{.index}} {{.title}}'
+	templateOutputPrefix = "template="
+	// jqOutputPrefix selects jq-style output, set via --jq <expr>
+	jqOutputPrefix = "jq="
+)
+
+// IsQueryOutput checks if the output format is a template or jq expression
+func IsQueryOutput(output string) bool {
+	return strings.HasPrefix(output, templateOutputPrefix) || strings.HasPrefix(output, jqOutputPrefix)
+}
+
+// outputQuery renders data with the template or jq expression given in the output format.
+// data is converted to plain json values first, so templates and jq expressions
+// see the same keys and types as the json output.
+func outputQuery(f io.Writer, output string, data any) error {
+	var err error
+	switch {
+	case strings.HasPrefix(output, templateOutputPrefix):
+		err = outputTemplate(f, strings.TrimPrefix(output, templateOutputPrefix), data)
+	case strings.HasPrefix(output, jqOutputPrefix):
+		err = outputJQ(f, strings.TrimPrefix(output, jqOutputPrefix), data)
+	}
+	if err != nil {
+		return utils.WrapError(utils.ErrValidation, err)
+	}
+	return nil
+}
+
+// outputTemplate executes a go-template for each item of a list, or once for a single item
+func outputTemplate(f io.Writer, text string, data any) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	doc, err := toJSONValue(data)
+	if err != nil {
+		return err
+	}
+
+	items, isList := doc.([]any)
+	if !isList {
+		items = []any{doc}
+	}
+	for _, item := range items {
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		out := buf.String()
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		fmt.Fprint(f, out)
+	}
+	return nil
+}
+
+var templateFuncs = template.FuncMap{
+	"join": func(sep string, list []any) string {
+		s := make([]string, len(list))
+		for i, v := range list {
+			s[i] = fmt.Sprint(v)
+		}
+		return strings.Join(s, sep)
+	},
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// outputJQ runs a jq expression on the data, printing string results raw and
+// everything else as indented json
+func outputJQ(f io.Writer, expr string, data any) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("invalid jq expression: %w", err)
+	}
+	doc, err := toJSONValue(data)
+	if err != nil {
+		return err
+	}
+
+	iter := query.Run(doc)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := v.(error); ok {
+			if err, ok := err.(*gojq.HaltError); ok && err.Value() == nil {
+				return nil
+			}
+			return fmt.Errorf("jq: %w", err)
+		}
+		if s, ok := v.(string); ok {
+			fmt.Fprintln(f, s)
+			continue
+		}
+		b, err := gojq.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, b, "", "  "); err != nil {
+			return err
+		}
+		fmt.Fprintln(f, indented.String())
+	}
+}
+
+// toJSONValue converts data to the generic json types understood by templates & gojq
+func toJSONValue(data any) (any, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format JSON: %w", err)
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("failed to format JSON: %w", err)
+	}
+	return v, nil
+}
+
+// outputItem prints a single item with the fields given, if the output format is
+// a template or jq expression. It reports whether the item was printed.
+func outputItem(w io.Writer, item printable, fields []string, output string) (bool, error) {
+	if !IsQueryOutput(output) {
+		return false, nil
+	}
+	return true, outputQuery(w, output, jsonRow{headers: fields, values: itemValues(item, fields)})
+}
+
+func itemValues(item printable, fields []string) []any {
+	values := make([]any, len(fields))
+	for i, f := range fields {
+		values[i] = item.FieldValue(f)
+	}
+	return values
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"bytes"
+	"testing"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIssueTable() table {
+	return tableFromItems(
+		[]string{"index", "title", "state", "labels"},
+		[]printable{
+			&printableIssue{&gitea.Issue{Index: 1, Title: "First", State: gitea.StateOpen,
+				Labels: []*gitea.Label{{Name: "bug"}, {Name: "ui"}}}},
+			&printableIssue{&gitea.Issue{Index: 2, Title: "Second", State: gitea.StateClosed}},
+		},
+	)
+}
+
+func TestOutputTemplate(t *testing.T) {
+	tData := testIssueTable()
+	buf := &bytes.Buffer{}
+	require.NoError(t, tData.fprint(buf, "template=#{{.index}} {{.title}} ({{.state}})"))
+	assert.Equal(t, "#1 First (open)\n#2 Second (closed)\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, outputTemplate(buf, `{{range .labels}}{{.name}} {{end}}`, []jsonRow{tData.jsonRows()[0]}))
+	assert.Equal(t, "bug ui \n", buf.String())
+
+	assert.Error(t, outputTemplate(buf, "{{.index", nil))
+}
+
+func TestOutputJQ(t *testing.T) {
+	tData := testIssueTable()
+	buf := &bytes.Buffer{}
+	tData.fprint(buf, `jq=.[] | select(.state == "open") | .title`)
+	assert.Equal(t, "First\n", buf.String())
+
+	buf.Reset()
+	tData.fprint(buf, `jq=map(.index)`)
+	assert.Equal(t, "[\n  1,\n  2\n]\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, outputJQ(buf, `.labels | length`, tData.jsonRows()[0]))
+	assert.Equal(t, "2\n", buf.String())
+
+	assert.Error(t, outputJQ(buf, `.[`, nil))
+}
+
+func TestOutputQueryError(t *testing.T) {
+	tData := testIssueTable()
+	buf := &bytes.Buffer{}
+	err := tData.fprint(buf, "template={{.index")
+	assert.ErrorIs(t, err, utils.ErrValidation)
+	assert.Equal(t, utils.ExitValidation, utils.ExitCode(err))
+
+	err = tData.fprint(buf, `jq=.[] | error("boom")`)
+	assert.ErrorIs(t, err, utils.ErrValidation)
+}
+
+func TestIsQueryOutput(t *testing.T) {
+	assert.True(t, IsQueryOutput("template={{.index}}"))
+	assert.True(t, IsQueryOutput("jq=.[]"))
+	assert.False(t, IsQueryOutput("json"))
+	assert.True(t, IsMachineReadable("jq=.[]"))
+}
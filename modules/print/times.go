@@ -5,28 +5,29 @@ package print
 
 import (
 	"fmt"
+	"io"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // TrackedTimesList print list of tracked times to stdout
-func TrackedTimesList(times []*gitea.TrackedTime, outputType string, fields []string, printTotal bool) {
+func TrackedTimesList(w io.Writer, times []*gitea.TrackedTime, outputType string, fields []string, printTotal bool) error {
 	var printables = make([]printable, len(times))
 	var totalDuration int64
 	for i, t := range times {
 		totalDuration += t.Time
-		printables[i] = &printableTrackedTime{t, outputType}
+		printables[i] = &printableTrackedTime{t}
 	}
-	t := tableFromItems(fields, printables, isMachineReadable(outputType))
+	t := tableFromItems(fields, printables)
 
 	if printTotal {
-		total := make([]string, len(fields))
+		total := make([]any, len(fields))
 		total[0] = "TOTAL"
-		total[len(fields)-1] = formatDuration(totalDuration, outputType)
+		total[len(fields)-1] = duration(totalDuration)
 		t.addRowSlice(total)
 	}
 
-	t.print(outputType)
+	return t.print(w, outputType)
 }
 
 // TrackedTimeFields contains all available fields for printing of tracked times.
@@ -41,15 +42,14 @@ var TrackedTimeFields = []string{
 
 type printableTrackedTime struct {
 	*gitea.TrackedTime
-	outputFormat string
 }
 
-func (t printableTrackedTime) FormatField(field string, machineReadable bool) string {
+func (t printableTrackedTime) FieldValue(field string) any {
 	switch field {
 	case "id":
-		return fmt.Sprintf("%d", t.ID)
+		return t.ID
 	case "created":
-		return FormatTime(t.Created, machineReadable)
+		return t.Created
 	case "repo":
 		return t.Issue.Repository.FullName
 	case "issue":
@@ -57,7 +57,7 @@ func (t printableTrackedTime) FormatField(field string, machineReadable bool) st
 	case "user":
 		return t.UserName
 	case "duration":
-		return formatDuration(t.Time, t.outputFormat)
+		return duration(t.Time)
 	}
-	return ""
+	return nil
 }
@@ -5,12 +5,13 @@ package print
 
 import (
 	"fmt"
+	"io"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // UserDetails print a formatted user to stdout
-func UserDetails(user *gitea.User) {
+func UserDetails(w io.Writer, user *gitea.User) {
 	title := "# " + user.UserName
 	if user.IsAdmin {
 		title += " (admin)"
@@ -42,7 +43,7 @@ func UserDetails(user *gitea.User) {
 		user.StarredRepoCount,
 	)
 
-	outputMarkdown(fmt.Sprintf(
+	outputMarkdown(w, fmt.Sprintf(
 		"%s%s\n%s\n%s",
 		title,
 		desc,
@@ -52,13 +53,13 @@ func UserDetails(user *gitea.User) {
 }
 
 // UserList prints a listing of the users
-func UserList(user []*gitea.User, output string, fields []string) {
+func UserList(w io.Writer, user []*gitea.User, output string, fields []string) error {
 	var printables = make([]printable, len(user))
 	for i, u := range user {
 		printables[i] = &printableUser{u}
 	}
-	t := tableFromItems(fields, printables, isMachineReadable(output))
-	t.print(output)
+	t := tableFromItems(fields, printables)
+	return t.print(w, output)
 }
 
 // UserFields are the available fields to print with UserList()
@@ -83,10 +84,10 @@ var UserFields = []string{
 
 type printableUser struct{ *gitea.User }
 
-func (x printableUser) FormatField(field string, machineReadable bool) string {
+func (x printableUser) FieldValue(field string) any {
 	switch field {
 	case "id":
-		return fmt.Sprintf("%d", x.ID)
+		return x.ID
 	case "login":
 		if x.IsAdmin {
 			return fmt.Sprintf("%s (admin)", x.UserName)
@@ -110,13 +111,13 @@ func (x printableUser) FormatField(field string, machineReadable bool) string {
 	case "language":
 		return x.Language
 	case "is_admin":
-		return formatBoolean(x.IsAdmin, !machineReadable)
+		return x.IsAdmin
 	case "restricted":
-		return formatBoolean(x.Restricted, !machineReadable)
+		return x.Restricted
 	case "prohibit_login":
-		return formatBoolean(x.ProhibitLogin, !machineReadable)
+		return x.ProhibitLogin
 	case "activated":
-		return formatBoolean(x.IsActive, !machineReadable)
+		return x.IsActive
 	case "location":
 		return x.Location
 	case "website":
@@ -126,9 +127,9 @@ func (x printableUser) FormatField(field string, machineReadable bool) string {
 	case "visibility":
 		return string(x.Visibility)
 	case "created_at":
-		return FormatTime(x.Created, machineReadable)
+		return x.Created
 	case "lastlogin_at":
-		return FormatTime(x.LastLogin, machineReadable)
+		return x.LastLogin
 	}
-	return ""
+	return nil
 }
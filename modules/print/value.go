@@ -0,0 +1,180 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// Table cells hold typed values. They are rendered as text for the table, csv,
+// tsv and simple output formats, and as native values (numbers, booleans, arrays,
+// objects) for the json and yaml output formats.
+//
+// Besides basic types, time.Time and []string, the following types are supported,
+// and any type can provide custom representations via textFormatter and structuredFormatter.
+
+// textFormatter is implemented by values with a custom text representation
+type textFormatter interface {
+	formatText(machineReadable bool) string
+}
+
+// structuredFormatter is implemented by values with a custom representation in json & yaml
+type structuredFormatter interface {
+	structured() any
+}
+
+// formatted is a value with an explicit text representation,
+// e.g. a shortened or decorated version of the value
+type formatted struct {
+	value any
+	text  string
+}
+
+func (f formatted) formatText(bool) string { return f.text }
+func (f formatted) structured() any        { return structuredValue(f.value) }
+
+// duration is a duration in seconds
+type duration int64
+
+func (d duration) formatText(machineReadable bool) string {
+	if machineReadable {
+		return fmt.Sprint(int64(d))
+	}
+	return time.Duration(1e9 * d).String()
+}
+
+// labelList is a list of labels, printed as colored names
+type labelList []*gitea.Label
+
+func (l labelList) formatText(machineReadable bool) string {
+	labels := make([]string, len(l))
+	for i, label := range l {
+		labels[i] = formatLabel(label, !machineReadable, "")
+	}
+	return strings.Join(labels, " ")
+}
+
+func (l labelList) structured() any {
+	labels := make([]map[string]any, len(l))
+	for i, label := range l {
+		labels[i] = map[string]any{
+			"id":          label.ID,
+			"name":        label.Name,
+			"color":       label.Color,
+			"description": label.Description,
+		}
+	}
+	return labels
+}
+
+// labelColor is the color of a label, printed in that color
+type labelColor struct{ *gitea.Label }
+
+func (l labelColor) formatText(machineReadable bool) string {
+	return formatLabel(l.Label, !machineReadable, l.Color)
+}
+
+func (l labelColor) structured() any { return l.Color }
+
+// userList is a list of users, printed as their display names
+type userList []*gitea.User
+
+func (l userList) formatText(bool) string {
+	users := make([]string, len(l))
+	for i, u := range l {
+		users[i] = formatUserName(u)
+	}
+	return strings.Join(users, " ")
+}
+
+func (l userList) structured() any {
+	users := make([]string, len(l))
+	for i, u := range l {
+		users[i] = u.UserName
+	}
+	return users
+}
+
+// formatText renders a typed value as text
+func formatText(v any, machineReadable bool) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case textFormatter:
+		return x.formatText(machineReadable)
+	case string:
+		return x
+	case bool:
+		return formatBoolean(x, !machineReadable)
+	case time.Time:
+		return FormatTime(x, machineReadable)
+	case *time.Time:
+		if x == nil {
+			return ""
+		}
+		return FormatTime(*x, machineReadable)
+	case []string:
+		return strings.Join(x, " ")
+	}
+	return fmt.Sprint(v)
+}
+
+// structuredValue converts a typed value for json & yaml output
+func structuredValue(v any) any {
+	switch x := v.(type) {
+	case structuredFormatter:
+		return x.structured()
+	case time.Time:
+		if x.IsZero() {
+			return nil
+		}
+		return x.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if x == nil {
+			return nil
+		}
+		return structuredValue(*x)
+	case []string:
+		if x == nil {
+			return []string{}
+		}
+	}
+	return v
+}
+
+// lessValue compares two typed values, numerically for numbers and times,
+// and by their text representation otherwise
+func lessValue(a, b any) bool {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return x < y
+		}
+	}
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Before(y)
+		}
+	}
+	return formatText(a, true) < formatText(b, true)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch x := v.(type) {
+	case int:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case float64:
+		return x, true
+	case duration:
+		return float64(x), true
+	}
+	return 0, false
+}
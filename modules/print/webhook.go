@@ -5,14 +5,14 @@ package print
 
 import (
 	"fmt"
-	"strconv"
+	"io"
 	"strings"
 
 	"code.gitea.io/sdk/gitea"
 )
 
 // WebhooksList prints a listing of webhooks
-func WebhooksList(hooks []*gitea.Hook, output string) {
+func WebhooksList(w io.Writer, hooks []*gitea.Hook, output string) error {
 	t := tableWithHeader(
 		"ID",
 		"Type",
@@ -28,55 +28,55 @@ func WebhooksList(hooks []*gitea.Hook, output string) {
 			url = hook.Config["url"]
 		}
 
-		events := strings.Join(hook.Events, ",")
-		if len(events) > 40 {
-			events = events[:37] + "..."
+		events := formatted{value: hook.Events, text: strings.Join(hook.Events, ",")}
+		if len(events.text) > 40 {
+			events.text = events.text[:37] + "..."
 		}
 
-		active := "✓"
+		active := formatted{value: hook.Active, text: "✓"}
 		if !hook.Active {
-			active = "✗"
+			active.text = "✗"
 		}
 
 		t.addRow(
-			strconv.FormatInt(hook.ID, 10),
+			hook.ID,
 			string(hook.Type),
 			url,
 			events,
 			active,
-			FormatTime(hook.Updated, false),
+			hook.Updated,
 		)
 	}
 
-	t.print(output)
+	return t.print(w, output)
 }
 
 // WebhookDetails prints detailed information about a webhook
-func WebhookDetails(hook *gitea.Hook) {
-	fmt.Printf("# Webhook %d\n\n", hook.ID)
-	fmt.Printf("- **Type**: %s\n", hook.Type)
-	fmt.Printf("- **Active**: %t\n", hook.Active)
-	fmt.Printf("- **Created**: %s\n", FormatTime(hook.Created, false))
-	fmt.Printf("- **Updated**: %s\n", FormatTime(hook.Updated, false))
+func WebhookDetails(w io.Writer, hook *gitea.Hook) {
+	fmt.Fprintf(w, "# Webhook %d\n\n", hook.ID)
+	fmt.Fprintf(w, "- **Type**: %s\n", hook.Type)
+	fmt.Fprintf(w, "- **Active**: %t\n", hook.Active)
+	fmt.Fprintf(w, "- **Created**: %s\n", FormatTime(hook.Created, false))
+	fmt.Fprintf(w, "- **Updated**: %s\n", FormatTime(hook.Updated, false))
 
 	if hook.Config != nil {
-		fmt.Printf("- **URL**: %s\n", hook.Config["url"])
+		fmt.Fprintf(w, "- **URL**: %s\n", hook.Config["url"])
 		if contentType, ok := hook.Config["content_type"]; ok {
-			fmt.Printf("- **Content Type**: %s\n", contentType)
+			fmt.Fprintf(w, "- **Content Type**: %s\n", contentType)
 		}
 		if method, ok := hook.Config["http_method"]; ok {
-			fmt.Printf("- **HTTP Method**: %s\n", method)
+			fmt.Fprintf(w, "- **HTTP Method**: %s\n", method)
 		}
 		if branchFilter, ok := hook.Config["branch_filter"]; ok && branchFilter != "" {
-			fmt.Printf("- **Branch Filter**: %s\n", branchFilter)
+			fmt.Fprintf(w, "- **Branch Filter**: %s\n", branchFilter)
 		}
 		if _, hasSecret := hook.Config["secret"]; hasSecret {
-			fmt.Printf("- **Secret**: (configured)\n")
+			fmt.Fprintf(w, "- **Secret**: (configured)\n")
 		}
 		if _, hasAuth := hook.Config["authorization_header"]; hasAuth {
-			fmt.Printf("- **Authorization Header**: (configured)\n")
+			fmt.Fprintf(w, "- **Authorization Header**: (configured)\n")
 		}
 	}
 
-	fmt.Printf("- **Events**: %s\n", strings.Join(hook.Events, ", "))
+	fmt.Fprintf(w, "- **Events**: %s\n", strings.Join(hook.Events, ", "))
 }
@@ -4,6 +4,7 @@
 package print
 
 import (
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -53,7 +54,7 @@ func TestWebhooksList(t *testing.T) {
 		t.Run("Format_"+format, func(t *testing.T) {
 			// Should not panic
 			assert.NotPanics(t, func() {
-				WebhooksList(hooks, format)
+				WebhooksList(io.Discard, hooks, format)
 			})
 		})
 	}
@@ -64,14 +65,14 @@ func TestWebhooksListEmpty(t *testing.T) {
 	hooks := []*gitea.Hook{}
 
 	assert.NotPanics(t, func() {
-		WebhooksList(hooks, "table")
+		WebhooksList(io.Discard, hooks, "table")
 	})
 }
 
 func TestWebhooksListNil(t *testing.T) {
 	// Test with nil hook list
 	assert.NotPanics(t, func() {
-		WebhooksList(nil, "table")
+		WebhooksList(io.Discard, nil, "table")
 	})
 }
 
@@ -143,7 +144,7 @@ func TestWebhookDetails(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Should not panic
 			assert.NotPanics(t, func() {
-				WebhookDetails(tt.hook)
+				WebhookDetails(io.Discard, tt.hook)
 			})
 		})
 	}
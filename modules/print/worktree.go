@@ -0,0 +1,39 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package print
+
+import (
+	"code.gitea.io/sdk/gitea"
+	"io"
+)
+
+// PullWorktree is a linked git worktree, in which the branch of a PR is checked out
+type PullWorktree struct {
+	Index int64
+	// Pull is nil, if the PR does not exist in the repo
+	Pull    *gitea.PullRequest
+	Branch  string
+	Path    string
+	Current bool
+}
+
+// PullWorktrees prints the worktrees of PRs with the state of their PRs
+func PullWorktrees(w io.Writer, worktrees []*PullWorktree, output string) error {
+	t := tableWithHeader(
+		"Index",
+		"State",
+		"Title",
+		"Branch",
+		"Path",
+		"Current",
+	)
+	for _, wt := range worktrees {
+		if wt.Pull == nil {
+			t.addRow(wt.Index, "not found", nil, wt.Branch, wt.Path, wt.Current)
+			continue
+		}
+		t.addRow(wt.Index, formatPRState(wt.Pull), wt.Pull.Title, wt.Branch, wt.Path, wt.Current)
+	}
+	return t.print(w, output)
+}
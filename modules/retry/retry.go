@@ -0,0 +1,256 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package retry implements retries of failed requests to the Gitea API.
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.gitea.io/tea/modules/debug"
+)
+
+// Policy configures how failed requests are retried
+type Policy struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled for every further retry
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts. Servers asking to wait longer
+	// via Retry-After or rate-limit headers are not retried.
+	MaxDelay time.Duration
+}
+
+// DefaultPolicy is used for logins without retry configuration
+var DefaultPolicy = Policy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+type safeWritesKey struct{}
+
+// WithSafeWrites marks requests made with the returned context as safe to retry,
+// even if their method isn't idempotent. Use it for writes that have the same
+// effect when applied twice, like setting the state of an issue.
+func WithSafeWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, safeWritesKey{}, true)
+}
+
+func isSafe(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	safe, _ := req.Context().Value(safeWritesKey{}).(bool)
+	return safe
+}
+
+// Transport is a http.RoundTripper retrying requests that failed because of
+// connection problems, gateway errors or rate limiting, with exponential backoff.
+// Requests that may have been processed by the server are only retried if they
+// are idempotent or marked with WithSafeWrites.
+type Transport struct {
+	Next   http.RoundTripper
+	Policy Policy
+
+	mu sync.Mutex
+	// rateLimitReset is when an exhausted rate limit is reset
+	rateLimitReset time.Time
+
+	sleep func(ctx context.Context, d time.Duration) error
+	now   func() time.Time
+}
+
+// NewTransport returns a Transport using policy
+func NewTransport(next http.RoundTripper, policy Policy) *Transport {
+	return &Transport{Next: next, Policy: policy}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// wait for an exhausted rate limit to be reset, if that's not too long
+	if wait := t.rateLimitWait(); wait > 0 && wait <= t.Policy.MaxDelay {
+		debug.Printf("retry: rate limit exhausted, waiting %s", wait)
+		if err := t.wait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.Next.RoundTrip(req)
+		if resp != nil {
+			t.trackRateLimit(resp)
+		}
+
+		if attempt >= t.Policy.MaxRetries || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+		delay, ok := t.delay(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		if resp != nil {
+			debug.Printf("retry: %s %s returned %s, retrying in %s", req.Method, req.URL.Redacted(), resp.Status, delay)
+			// drain the body, so the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			resp.Body.Close()
+		} else {
+			debug.Printf("retry: %s %s failed: %s, retrying in %s", req.Method, req.URL.Redacted(), err, delay)
+		}
+		if err := t.wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable checks if a request should be retried after the given outcome
+func (t *Transport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		// the server hasn't seen requests that failed to connect
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return isSafe(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// rate limited requests weren't processed
+		return true
+	case http.StatusForbidden:
+		return rateLimitExhausted(resp.Header)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isSafe(req)
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt, and false if the server
+// asks to wait longer than the policy allows.
+func (t *Transport) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := t.serverDelay(resp.Header); ok {
+			return wait, wait <= t.Policy.MaxDelay
+		}
+	}
+
+	backoff := t.Policy.BaseDelay << attempt
+	if backoff <= 0 || backoff > t.Policy.MaxDelay {
+		backoff = t.Policy.MaxDelay
+	}
+	// equal jitter: wait between half and the full backoff
+	half := backoff / 2
+	return half + rand.N(half+1), true
+}
+
+// serverDelay returns the delay requested by the Retry-After or rate-limit headers
+func (t *Transport) serverDelay(header http.Header) (time.Duration, bool) {
+	if v := header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(v); err == nil {
+			return max(date.Sub(t.time()), 0), true
+		}
+	}
+	if rateLimitExhausted(header) {
+		if reset, ok := t.rateLimitResetTime(header); ok {
+			return max(reset.Sub(t.time()), 0), true
+		}
+	}
+	return 0, false
+}
+
+// rateLimitExhausted checks the X-RateLimit-Remaining & RateLimit-Remaining headers
+func rateLimitExhausted(header http.Header) bool {
+	for _, name := range []string{"X-RateLimit-Remaining", "RateLimit-Remaining"} {
+		if v := header.Get(name); v != "" {
+			remaining, err := strconv.Atoi(v)
+			return err == nil && remaining <= 0
+		}
+	}
+	return false
+}
+
+// rateLimitResetTime parses the X-RateLimit-Reset & RateLimit-Reset headers,
+// which are either a unix timestamp or a number of seconds.
+func (t *Transport) rateLimitResetTime(header http.Header) (time.Time, bool) {
+	for _, name := range []string{"X-RateLimit-Reset", "RateLimit-Reset"} {
+		if v := header.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return time.Time{}, false
+			}
+			if n > 1_000_000_000 {
+				return time.Unix(n, 0), true
+			}
+			return t.time().Add(time.Duration(n) * time.Second), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// trackRateLimit remembers when an exhausted rate limit is reset,
+// so following requests don't run into it.
+func (t *Transport) trackRateLimit(resp *http.Response) {
+	if !rateLimitExhausted(resp.Header) {
+		return
+	}
+	if reset, ok := t.rateLimitResetTime(resp.Header); ok {
+		t.mu.Lock()
+		t.rateLimitReset = reset
+		t.mu.Unlock()
+	}
+}
+
+func (t *Transport) rateLimitWait() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rateLimitReset.Sub(t.time())
+}
+
+func (t *Transport) time() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+func (t *Transport) wait(ctx context.Context, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package retry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client retrying requests to a server using handler,
+// and records the delays between attempts instead of sleeping.
+func newTestClient(t *testing.T, handler func(w http.ResponseWriter, attempt int)) (*http.Client, *[]time.Duration, *int, string) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Body", string(body))
+		handler(w, attempts)
+		attempts++
+	}))
+	t.Cleanup(server.Close)
+
+	var delays []time.Duration
+	transport := NewTransport(http.DefaultTransport, DefaultPolicy)
+	transport.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return &http.Client{Transport: transport}, &delays, &attempts, server.URL
+}
+
+func statuses(codes ...int) func(w http.ResponseWriter, attempt int) {
+	return func(w http.ResponseWriter, attempt int) {
+		w.WriteHeader(codes[min(attempt, len(codes)-1)])
+	}
+}
+
+func TestRetryIdempotent(t *testing.T) {
+	client, delays, attempts, url := newTestClient(t, statuses(502, 503, 200))
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, *attempts)
+	require.Len(t, *delays, 2)
+	// exponential backoff with jitter
+	assert.GreaterOrEqual(t, (*delays)[0], 250*time.Millisecond)
+	assert.LessOrEqual(t, (*delays)[0], 500*time.Millisecond)
+	assert.GreaterOrEqual(t, (*delays)[1], 500*time.Millisecond)
+	assert.LessOrEqual(t, (*delays)[1], time.Second)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	client, _, attempts, url := newTestClient(t, statuses(502))
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, DefaultPolicy.MaxRetries+1, *attempts)
+}
+
+func TestRetryUnsafeWrite(t *testing.T) {
+	client, _, attempts, url := newTestClient(t, statuses(502, 200))
+
+	resp, err := client.Post(url, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, 1, *attempts)
+}
+
+func TestRetrySafeWrite(t *testing.T) {
+	client, _, attempts, url := newTestClient(t, statuses(502, 200))
+
+	req, err := http.NewRequestWithContext(WithSafeWrites(context.Background()), http.MethodPatch, url, strings.NewReader(`{"state":"closed"}`))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, *attempts)
+	// the body is sent again
+	assert.Equal(t, `{"state":"closed"}`, resp.Header.Get("X-Body"))
+}
+
+func TestRetryAfter(t *testing.T) {
+	client, delays, attempts, url := newTestClient(t, func(w http.ResponseWriter, attempt int) {
+		if attempt == 0 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	// rate limited writes are retried, as they weren't processed
+	resp, err := client.Post(url, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 2, *attempts)
+	assert.Equal(t, []time.Duration{7 * time.Second}, *delays)
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	client, _, attempts, url := newTestClient(t, func(w http.ResponseWriter, attempt int) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, 1, *attempts)
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	client, delays, attempts, url := newTestClient(t, func(w http.ResponseWriter, attempt int) {
+		if attempt == 0 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(5))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, *attempts)
+	require.Len(t, *delays, 1)
+	assert.InDelta(t, 5*time.Second, (*delays)[0], float64(time.Second))
+}
+
+func TestNoRetryForClientErrors(t *testing.T) {
+	client, _, attempts, url := newTestClient(t, statuses(404))
+
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 1, *attempts)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"code.gitea.io/tea/modules/dryrun"
+)
+
+// BatchError is returned by ForEachItem if some items failed
+type BatchError struct {
+	Total  int
+	Failed []string
+	Errs   []error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("failed for %d of %d items: %s", len(e.Failed), e.Total, strings.Join(e.Failed, ", "))
+}
+
+// Unwrap returns the errors of the failed items
+func (e *BatchError) Unwrap() []error {
+	return e.Errs
+}
+
+// batchOutput is where ForEachItem reports failures
+var batchOutput io.Writer = os.Stderr
+
+// ForEachItem runs fn for the items of a multi-argument command.
+// A failing item doesn't abort the remaining ones: the failure is reported on stderr,
+// and once all items are processed, the succeeded items are listed and a BatchError
+// naming the failed ones is returned. For a single item, its error is returned as is.
+// Items skipped in dry-run mode count as succeeded.
+func ForEachItem[T any](items []T, name func(T) string, fn func(T) error) error {
+	if len(items) == 1 {
+		return fn(items[0])
+	}
+
+	var succeeded []string
+	batchErr := &BatchError{Total: len(items)}
+	for _, item := range items {
+		if err := fn(item); err != nil && !errors.Is(err, dryrun.ErrSkipped) {
+			fmt.Fprintf(batchOutput, "Error: %s: %v\n", name(item), err)
+			batchErr.Failed = append(batchErr.Failed, name(item))
+			batchErr.Errs = append(batchErr.Errs, err)
+		} else {
+			succeeded = append(succeeded, name(item))
+		}
+	}
+
+	if len(batchErr.Failed) == 0 {
+		return nil
+	}
+	if len(succeeded) != 0 {
+		fmt.Fprintf(batchOutput, "Succeeded: %s\n", strings.Join(succeeded, ", "))
+	}
+	return batchErr
+}
+
+// IndexName formats an issue or pull request index for ForEachItem
+func IndexName(index int64) string {
+	return fmt.Sprintf("#%d", index)
+}
+
+// ArgName formats a plain argument, like a tag or milestone name, for ForEachItem
+func ArgName(arg string) string {
+	return arg
+}
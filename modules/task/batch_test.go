@@ -0,0 +1,52 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"code.gitea.io/tea/modules/dryrun"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachItem(t *testing.T) {
+	out := &bytes.Buffer{}
+	defer func(w io.Writer) { batchOutput = w }(batchOutput)
+	batchOutput = out
+
+	var processed []int64
+	err := ForEachItem([]int64{1, 2, 3}, IndexName, func(index int64) error {
+		processed = append(processed, index)
+		if index == 2 {
+			return utils.NewNotFoundErrorf("issue does not exist")
+		}
+		return nil
+	})
+
+	// all items are processed, even after a failure
+	assert.Equal(t, []int64{1, 2, 3}, processed)
+	assert.EqualError(t, err, "failed for 1 of 3 items: #2")
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	assert.Equal(t, "Error: #2: issue does not exist\nSucceeded: #1, #3\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, ForEachItem([]string{"v1", "v2"}, ArgName, func(string) error { return nil }))
+	assert.Empty(t, out.String())
+
+	// items skipped in dry-run mode don't fail the batch
+	assert.NoError(t, ForEachItem([]string{"v1", "v2"}, ArgName, func(string) error {
+		return fmt.Errorf("Post \"https://gitea.com\": %w", dryrun.ErrSkipped)
+	}))
+	assert.Empty(t, out.String())
+
+	// a single item's error is returned unchanged
+	single := errors.New("boom")
+	assert.Equal(t, single, ForEachItem([]string{"v1"}, ArgName, func(string) error { return single }))
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// CreateComment adds a comment to an issue or PR
+func CreateComment(ctx *context.TeaContext, idx int64, body string) (*gitea.Comment, error) {
+	if len(body) == 0 {
+		return nil, utils.NewValidationErrorf("no comment content provided")
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
+	return utils.APIResult(client.CreateIssueComment(ctx.Owner, ctx.Repo, idx, gitea.CreateIssueCommentOption{
+		Body: body,
+	}))
+}
@@ -7,25 +7,38 @@ import (
 	"fmt"
 
 	"code.gitea.io/sdk/gitea"
-	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
 )
 
 // CreateIssue creates an issue in the given repo and prints the result
-func CreateIssue(login *config.Login, repoOwner, repoName string, opts gitea.CreateIssueOption) error {
+func CreateIssue(ctx *context.TeaContext, repoOwner, repoName string, opts gitea.CreateIssueOption) error {
 	// title is required
 	if len(opts.Title) == 0 {
 		return fmt.Errorf("Title is required")
 	}
 
-	issue, _, err := login.Client().CreateIssue(repoOwner, repoName, opts)
+	client, err := ctx.Client()
 	if err != nil {
-		return fmt.Errorf("could not create issue: %s", err)
+		return err
+	}
+	issue, err := utils.APIResult(client.CreateIssue(repoOwner, repoName, opts))
+	if err != nil {
+		return fmt.Errorf("could not create issue: %w", err)
+	}
+	if dryrun.Enabled() {
+		// the issue isn't created in dry-run mode
+		return nil
 	}
 
-	print.IssueDetails(issue, nil)
-
-	fmt.Println(issue.HTMLURL)
+	if err := print.IssueDetails(ctx.Out(), issue, nil, ctx.Output); err != nil {
+		return err
+	}
+	if !print.IsQueryOutput(ctx.Output) {
+		fmt.Fprintln(ctx.Out(), issue.HTMLURL)
+	}
 
 	return nil
 }
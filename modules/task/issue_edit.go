@@ -9,6 +9,7 @@ import (
 
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 )
 
 // EditIssueOption wraps around gitea.EditIssueOption which has bad & incosistent semantics.
@@ -64,7 +65,7 @@ func (o EditIssueOption) toSdkOptions(ctx *context.TeaContext, client *gitea.Cli
 		if *o.Milestone == "" {
 			issueOpts.Milestone = gitea.OptionalInt64(0)
 		} else {
-			ms, _, err := client.GetMilestoneByName(ctx.Owner, ctx.Repo, *o.Milestone)
+			ms, err := utils.APIResult(client.GetMilestoneByName(ctx.Owner, ctx.Repo, *o.Milestone))
 			if err != nil {
 				return nil, nil, nil, fmt.Errorf("Milestone '%s' not found", *o.Milestone)
 			}
@@ -93,7 +94,11 @@ func (o EditIssueOption) toSdkOptions(ctx *context.TeaContext, client *gitea.Cli
 // EditIssue edits an issue and returns the updated issue.
 func EditIssue(ctx *context.TeaContext, client *gitea.Client, opts EditIssueOption) (*gitea.Issue, error) {
 	if client == nil {
-		client = ctx.Login.Client()
+		var err error
+		client, err = ctx.Client()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	issueOpts, addLabelOpts, rmLabelOpts, err := opts.toSdkOptions(ctx, client)
@@ -104,30 +109,30 @@ func EditIssue(ctx *context.TeaContext, client *gitea.Client, opts EditIssueOpti
 	if rmLabelOpts != nil {
 		// NOTE: as of 1.17, there is no API to remove multiple labels at once.
 		for _, id := range rmLabelOpts.Labels {
-			_, err := client.DeleteIssueLabel(ctx.Owner, ctx.Repo, opts.Index, id)
+			err := utils.APIError(client.DeleteIssueLabel(ctx.Owner, ctx.Repo, opts.Index, id))
 			if err != nil {
-				return nil, fmt.Errorf("could not remove labels: %s", err)
+				return nil, fmt.Errorf("could not remove labels: %w", err)
 			}
 		}
 	}
 
 	if addLabelOpts != nil {
-		_, _, err := client.AddIssueLabels(ctx.Owner, ctx.Repo, opts.Index, *addLabelOpts)
+		_, err := utils.APIResult(client.AddIssueLabels(ctx.Owner, ctx.Repo, opts.Index, *addLabelOpts))
 		if err != nil {
-			return nil, fmt.Errorf("could not add labels: %s", err)
+			return nil, fmt.Errorf("could not add labels: %w", err)
 		}
 	}
 
 	var issue *gitea.Issue
 	if issueOpts != nil {
-		issue, _, err = client.EditIssue(ctx.Owner, ctx.Repo, opts.Index, *issueOpts)
+		issue, err = utils.APIResult(client.EditIssue(ctx.Owner, ctx.Repo, opts.Index, *issueOpts))
 		if err != nil {
-			return nil, fmt.Errorf("could not edit issue: %s", err)
+			return nil, fmt.Errorf("could not edit issue: %w", err)
 		}
 	} else {
-		issue, _, err = client.GetIssue(ctx.Owner, ctx.Repo, opts.Index)
+		issue, err = utils.APIResult(client.GetIssue(ctx.Owner, ctx.Repo, opts.Index))
 		if err != nil {
-			return nil, fmt.Errorf("could not get issue: %s", err)
+			return nil, fmt.Errorf("could not get issue: %w", err)
 		}
 	}
 	return issue, nil
@@ -0,0 +1,38 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"code.gitea.io/tea/modules/pagination"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// ListIssues fetches the issues of a repository selected by opt & paging.
+// If repo is empty, the issues of all repositories of owner are fetched.
+func ListIssues(client *gitea.Client, owner, repo string, opt gitea.ListIssueOption, paging pagination.Paging) ([]*gitea.Issue, error) {
+	return pagination.List(func(opts gitea.ListOptions) ([]*gitea.Issue, *gitea.Response, error) {
+		opt.ListOptions = opts
+		if len(repo) == 0 {
+			opt.Owner = owner
+			return client.ListIssues(opt)
+		}
+		return client.ListRepoIssues(owner, repo, opt)
+	}, paging)
+}
+
+// ListIssueComments fetches all comments of an issue or PR
+func ListIssueComments(client *gitea.Client, owner, repo string, idx int64) ([]*gitea.Comment, error) {
+	return pagination.All(func(opts gitea.ListOptions) ([]*gitea.Comment, *gitea.Response, error) {
+		return client.ListIssueComments(owner, repo, idx, gitea.ListIssueCommentOptions{ListOptions: opts})
+	}, pagination.Options{PageSize: 50})
+}
+
+// ListPulls fetches the PRs of a repository selected by opt & paging
+func ListPulls(client *gitea.Client, owner, repo string, opt gitea.ListPullRequestsOptions, paging pagination.Paging) ([]*gitea.PullRequest, error) {
+	return pagination.List(func(opts gitea.ListOptions) ([]*gitea.PullRequest, *gitea.Response, error) {
+		opt.ListOptions = opts
+		return client.ListRepoPullRequests(owner, repo, opt)
+	}, paging)
+}
@@ -4,6 +4,8 @@
 package task
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -47,7 +49,7 @@ func SetupHelper(login config.Login) (ok bool, err error) {
 }
 
 // CreateLogin create a login to be stored in config
-func CreateLogin(name, token, user, passwd, otp, scopes, sshKey, giteaURL, sshCertPrincipal, sshKeyFingerprint string, insecure, sshAgent, versionCheck, addHelper bool) error {
+func CreateLogin(ctx context.Context, name, token, user, passwd, otp, scopes, sshKey, giteaURL, sshCertPrincipal, sshKeyFingerprint string, insecure, sshAgent, versionCheck, addHelper bool) error {
 	// checks ...
 	// ... if we have a url
 	if len(giteaURL) == 0 {
@@ -55,12 +57,16 @@ func CreateLogin(name, token, user, passwd, otp, scopes, sshKey, giteaURL, sshCe
 	}
 
 	// ... if there already exist a login with same name
-	if login := config.GetLoginByName(name); login != nil {
-		return fmt.Errorf("login name '%s' has already been used", login.Name)
+	if login, err := config.GetLoginByName(name); err == nil {
+		return utils.NewValidationErrorf("login name '%s' has already been used", login.Name)
+	} else if !errors.Is(err, utils.ErrMissingLogin) {
+		return err
 	}
 	// ... if we already use this token
-	if login := config.GetLoginByToken(token); login != nil {
-		return fmt.Errorf("token already been used, delete login '%s' first", login.Name)
+	if login, err := config.GetLoginByToken(token); err == nil {
+		return utils.NewValidationErrorf("token already been used, delete login '%s' first", login.Name)
+	} else if !errors.Is(err, utils.ErrMissingLogin) {
+		return err
 	}
 
 	serverURL, err := utils.ValidateAuthenticationMethod(
@@ -93,15 +99,18 @@ func CreateLogin(name, token, user, passwd, otp, scopes, sshKey, giteaURL, sshCe
 	}
 
 	if len(token) == 0 {
-		if login.Token, err = generateToken(login, user, passwd, otp, scopes); err != nil {
+		if login.Token, err = generateToken(ctx, login, user, passwd, otp, scopes); err != nil {
 			return err
 		}
 	}
 
-	client := login.Client()
+	client, err := login.Client(ctx)
+	if err != nil {
+		return err
+	}
 
 	// Verify if authentication works and get user info
-	u, _, err := client.GetMyUserInfo()
+	u, err := utils.APIResult(client.GetMyUserInfo())
 	if err != nil {
 		return err
 	}
@@ -139,16 +148,19 @@ func CreateLogin(name, token, user, passwd, otp, scopes, sshKey, giteaURL, sshCe
 }
 
 // generateToken creates a new token when given BasicAuth credentials
-func generateToken(login config.Login, user, pass, otp, scopes string) (string, error) {
+func generateToken(ctx context.Context, login config.Login, user, pass, otp, scopes string) (string, error) {
 	opts := []gitea.ClientOption{gitea.SetBasicAuth(user, pass)}
 	if otp != "" {
 		opts = append(opts, gitea.SetOTP(otp))
 	}
-	client := login.Client(opts...)
+	client, err := login.Client(ctx, opts...)
+	if err != nil {
+		return "", err
+	}
 
-	tl, _, err := client.ListAccessTokens(gitea.ListAccessTokensOptions{
+	tl, err := utils.APIResult(client.ListAccessTokens(gitea.ListAccessTokensOptions{
 		ListOptions: gitea.ListOptions{Page: -1},
-	})
+	}))
 	if err != nil {
 		return "", err
 	}
@@ -172,10 +184,10 @@ func generateToken(login config.Login, user, pass, otp, scopes string) (string,
 		}
 	}
 
-	t, _, err := client.CreateAccessToken(gitea.CreateAccessTokenOption{
+	t, err := utils.APIResult(client.CreateAccessToken(gitea.CreateAccessTokenOption{
 		Name:   tokenName,
 		Scopes: tokenScopes,
-	})
+	}))
 	return t.Token, err
 }
 
@@ -189,8 +201,10 @@ func GenerateLoginName(url, user string) (string, error) {
 
 	// append user name if login name already exists
 	if len(user) != 0 {
-		if login := config.GetLoginByName(name); login != nil {
+		if _, err := config.GetLoginByName(name); err == nil {
 			return name + "_" + user, nil
+		} else if !errors.Is(err, utils.ErrMissingLogin) {
+			return "", err
 		}
 	}
 
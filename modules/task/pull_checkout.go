@@ -4,11 +4,14 @@
 package task
 
 import (
+	"context"
 	"fmt"
 
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/dryrun"
 	local_git "code.gitea.io/tea/modules/git"
+	"code.gitea.io/tea/modules/utils"
 	"code.gitea.io/tea/modules/workaround"
 
 	"github.com/go-git/go-git/v5"
@@ -18,18 +21,15 @@ import (
 
 // PullCheckout checkout current workdir to the head branch of specified pull request
 func PullCheckout(
+	ctx context.Context,
 	login *config.Login,
 	repoOwner, repoName string,
 	forceCreateBranch bool,
 	index int64,
 	callback func(string) (string, error),
 ) error {
-	client := login.Client()
-	pr, _, err := client.GetPullRequest(repoOwner, repoName, index)
+	pr, err := getPullForCheckout(ctx, login, repoOwner, repoName, index)
 	if err != nil {
-		return fmt.Errorf("couldn't fetch PR: %s", err)
-	}
-	if err := workaround.FixPullHeadSha(client, pr); err != nil {
 		return err
 	}
 
@@ -42,6 +42,9 @@ func PullCheckout(
 	// find or create a matching remote
 	remoteURL := remoteURLForPR(login, pr)
 	newRemoteName := fmt.Sprintf("pulls/%v", pr.Head.Repository.Owner.UserName)
+	if dryrun.Enabled() {
+		return printPRCheckout(localRepo, pr, remoteURL, newRemoteName, forceCreateBranch)
+	}
 	// verify related remote is in local repo, otherwise add it
 	localRemote, err := localRepo.GetOrCreateRemote(remoteURL, newRemoteName)
 	if err != nil {
@@ -49,7 +52,7 @@ func PullCheckout(
 	}
 	localRemoteName := localRemote.Config().Name
 
-	localRemoteBranchName, err := doPRFetch(login, pr, localRepo, localRemote, callback)
+	localRemoteBranchName, err := doPRFetch(ctx, login, pr, localRepo, localRemote, callback)
 	if err != nil {
 		return err
 	}
@@ -57,10 +60,86 @@ func PullCheckout(
 	return doPRCheckout(localRepo, pr, localRemoteName, localRemoteBranchName, remoteURL, forceCreateBranch)
 }
 
+// getPullForCheckout fetches a PR, with the sha of its head
+func getPullForCheckout(ctx context.Context, login *config.Login, repoOwner, repoName string, index int64) (*gitea.PullRequest, error) {
+	client, err := login.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := utils.APIResult(client.GetPullRequest(repoOwner, repoName, index))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch PR: %w", err)
+	}
+	if err := workaround.FixPullHeadSha(client, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// printPRCheckout prints the git operations PullCheckout would run in dry-run mode
+func printPRCheckout(
+	localRepo *local_git.TeaRepo,
+	pr *gitea.PullRequest,
+	remoteURL,
+	remoteName string,
+	forceCreateBranch bool,
+) error {
+	remoteName, remoteBranchName, err := printPRFetch(localRepo, pr, remoteURL, remoteName)
+	if err != nil {
+		return err
+	}
+
+	if b, _ := localRepo.TeaFindBranchBySha(pr.Head.Sha, remoteURL); b != nil {
+		dryrun.Printf("git checkout %s", b.Name)
+	} else if forceCreateBranch {
+		dryrun.Printf("git checkout -b %s --track %s/%s", pullBranchName(pr), remoteName, remoteBranchName)
+	} else {
+		dryrun.Printf("git checkout %s/%s", remoteName, remoteBranchName)
+	}
+	return nil
+}
+
+// printPRFetch prints the git operations doPRFetch would run in dry-run mode,
+// and returns the name of the remote and of the remote branch of the PR
+func printPRFetch(
+	localRepo *local_git.TeaRepo,
+	pr *gitea.PullRequest,
+	remoteURL,
+	remoteName string,
+) (string, string, error) {
+	remote, err := localRepo.GetRemote(remoteURL)
+	if err != nil {
+		return "", "", err
+	}
+	if remote == nil {
+		dryrun.Printf("git remote add %s %s", remoteName, remoteURL)
+	} else {
+		remoteName = remote.Config().Name
+	}
+
+	remoteBranchName := pr.Head.Ref
+	if isRemoteDeleted(pr) {
+		remoteBranchName = fmt.Sprintf("pulls/%d", pr.Index)
+		dryrun.Printf("git fetch %s %s:refs/remotes/%s/%s", remoteName, pr.Head.Ref, remoteName, remoteBranchName)
+	} else {
+		dryrun.Printf("git fetch %s", remoteName)
+	}
+	return remoteName, remoteBranchName, nil
+}
+
 func isRemoteDeleted(pr *gitea.PullRequest) bool {
 	return pr.Head.Ref == fmt.Sprintf("refs/pull/%d/head", pr.Index)
 }
 
+// pullBranchName returns the name of the local branch created for a PR
+func pullBranchName(pr *gitea.PullRequest) string {
+	name := fmt.Sprintf("pulls/%v", pr.Index)
+	if isRemoteDeleted(pr) {
+		name += "-" + pr.Head.Ref
+	}
+	return name
+}
+
 func remoteURLForPR(login *config.Login, pr *gitea.PullRequest) string {
 	repo := pr.Head.Repository
 	if isRemoteDeleted(pr) {
@@ -76,6 +155,7 @@ func remoteURLForPR(login *config.Login, pr *gitea.PullRequest) string {
 }
 
 func doPRFetch(
+	ctx context.Context,
 	login *config.Login,
 	pr *gitea.PullRequest,
 	localRepo *local_git.TeaRepo,
@@ -108,7 +188,7 @@ func doPRFetch(
 	}
 	fmt.Printf("Fetching PR %v (head %s:%s) from remote '%s'\n", pr.Index, url, pr.Head.Ref, localRemoteName)
 
-	err = localRemote.Fetch(fetchOpts)
+	err = localRemote.FetchContext(ctx, fetchOpts)
 	if err == git.NoErrAlreadyUpToDate {
 		fmt.Println(err)
 	} else if err != nil {
@@ -138,10 +218,7 @@ func doPRCheckout(
 	} else if forceCreateBranch {
 
 		// create a branch if wanted
-		localBranchName := fmt.Sprintf("pulls/%v", pr.Index)
-		if isRemoteDeleted(pr) {
-			localBranchName += "-" + pr.Head.Ref
-		}
+		localBranchName := pullBranchName(pr)
 		checkoutRef = git_plumbing.NewBranchReferenceName(localBranchName)
 		if err := localRepo.TeaCreateBranch(localBranchName, localRemoteBranchName, localRemoteName); err == nil {
 			info = fmt.Sprintf("Created branch '%s'\n", localBranchName)
@@ -0,0 +1,182 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.gitea.io/tea/modules/api"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// actionRun & actionJob hold the fields of Actions runs and jobs that are
+// needed to enrich checks. The SDK doesn't support these endpoints yet.
+type actionRun struct {
+	ID      int64  `json:"id"`
+	HTMLURL string `json:"html_url"`
+}
+
+type actionJob struct {
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// PullChecks returns the checks of the head commit of a PR, including the
+// checks required by the branch protection of the base branch that haven't
+// reported yet. With requiredOnly, only the required checks are returned.
+func PullChecks(ctx *context.TeaContext, pr *gitea.PullRequest, requiredOnly bool) ([]*print.PullCheck, error) {
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
+	combined, err := utils.APIResult(client.GetCombinedStatus(ctx.Owner, ctx.Repo, pr.Head.Sha))
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := requiredChecks(client, ctx.Owner, ctx.Repo, pr.Base.Ref)
+	if err != nil {
+		return nil, err
+	}
+	// Actions jobs are optional details, they are missing on servers without Actions
+	jobs := actionJobs(ctx, pr.Head.Sha)
+
+	checks := make([]*print.PullCheck, 0, len(combined.Statuses))
+	for _, s := range combined.Statuses {
+		check := &print.PullCheck{
+			Name:        s.Context,
+			State:       string(s.State),
+			Description: s.Description,
+			URL:         s.TargetURL,
+			Required:    matchesAny(s.Context, required),
+		}
+		if job, ok := jobs[strings.TrimSuffix(s.TargetURL, "/")]; ok {
+			check.State = jobState(job, check.State)
+			check.Started = job.StartedAt
+			check.Completed = job.CompletedAt
+		} else if s.State != gitea.StatusPending {
+			check.Started = s.Created
+			check.Completed = s.Updated
+		}
+		if requiredOnly && !check.Required {
+			continue
+		}
+		checks = append(checks, check)
+	}
+
+	// required checks that didn't report a status yet
+	for _, pattern := range required {
+		if !slices.ContainsFunc(checks, func(c *print.PullCheck) bool { return matchesAny(c.Name, []string{pattern}) }) {
+			checks = append(checks, &print.PullCheck{
+				Name:        pattern,
+				State:       print.CheckPending,
+				Description: "Expected, waiting for status to be reported",
+				Required:    true,
+			})
+		}
+	}
+
+	slices.SortStableFunc(checks, func(a, b *print.PullCheck) int { return strings.Compare(a.Name, b.Name) })
+	return checks, nil
+}
+
+// requiredChecks returns the status check patterns required by the branch protection of a branch
+func requiredChecks(client *gitea.Client, owner, repo, branch string) ([]string, error) {
+	b, err := utils.APIResult(client.GetRepoBranch(owner, repo, branch))
+	if err != nil {
+		return nil, fmt.Errorf("could not load branch protection of '%s': %w", branch, err)
+	}
+	if !b.Protected || !b.EnableStatusCheck {
+		return nil, nil
+	}
+	return b.StatusCheckContexts, nil
+}
+
+// matchesAny returns true if the status context matches any of the glob patterns.
+// Like in Gitea, * also matches slashes, which are common in status contexts.
+func matchesAny(statusContext string, patterns []string) bool {
+	noSlashes := strings.NewReplacer("/", "\x00")
+	for _, p := range patterns {
+		if p == statusContext {
+			return true
+		}
+		if ok, _ := path.Match(noSlashes.Replace(p), noSlashes.Replace(statusContext)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// actionJobs returns the Actions jobs of a commit by their web URL, which
+// Actions uses as target URL of the commit statuses it reports
+func actionJobs(ctx *context.TeaContext, sha string) map[string]*actionJob {
+	jobs := map[string]*actionJob{}
+	client, err := api.NewClient(ctx.Ctx, ctx.Login)
+	if err != nil {
+		return jobs
+	}
+
+	query := url.Values{"head_sha": {sha}}
+	data, err := client.Request(http.MethodGet, fmt.Sprintf("/repos/%s/%s/actions/runs?%s", ctx.Owner, ctx.Repo, query.Encode()), nil)
+	if err != nil {
+		return jobs
+	}
+	var runs struct {
+		WorkflowRuns []*actionRun `json:"workflow_runs"`
+	}
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return jobs
+	}
+
+	for _, run := range runs.WorkflowRuns {
+		data, err := client.Request(http.MethodGet, fmt.Sprintf("/repos/%s/%s/actions/runs/%d/jobs", ctx.Owner, ctx.Repo, run.ID), nil)
+		if err != nil {
+			continue
+		}
+		var runJobs struct {
+			Jobs []*actionJob `json:"jobs"`
+		}
+		if err := json.Unmarshal(data, &runJobs); err != nil {
+			continue
+		}
+		// the web URL of a job is addressed by its position in the run
+		for i, job := range runJobs.Jobs {
+			jobs[strings.TrimSuffix(run.HTMLURL, "/")+"/jobs/"+strconv.Itoa(i)] = job
+		}
+	}
+	return jobs
+}
+
+// jobState maps the status of an Actions job to a check state, falling back
+// to the state of its commit status
+func jobState(job *actionJob, fallback string) string {
+	if job.Status != "completed" {
+		return print.CheckPending
+	}
+	switch job.Conclusion {
+	case "success":
+		return "success"
+	case "failure":
+		return "failure"
+	case "cancelled":
+		return print.CheckCancelled
+	case "skipped":
+		return print.CheckSkipped
+	}
+	return fallback
+}
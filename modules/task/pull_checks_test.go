@@ -0,0 +1,34 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"testing"
+
+	"code.gitea.io/tea/modules/print"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesAny(t *testing.T) {
+	patterns := []string{"ci / build*", "lint"}
+	assert.True(t, matchesAny("ci / build (push)", patterns))
+	assert.True(t, matchesAny("lint", patterns))
+	assert.False(t, matchesAny("ci / test (push)", patterns))
+	assert.True(t, matchesAny("ci/docs/deploy", []string{"ci/*"}))
+	assert.False(t, matchesAny("lint", nil))
+}
+
+func TestJobState(t *testing.T) {
+	assert.Equal(t, print.CheckPending, jobState(&actionJob{Status: "in_progress"}, "success"))
+	assert.Equal(t, "failure", jobState(&actionJob{Status: "completed", Conclusion: "failure"}, "pending"))
+	assert.Equal(t, print.CheckCancelled, jobState(&actionJob{Status: "completed", Conclusion: "cancelled"}, "failure"))
+	assert.Equal(t, "warning", jobState(&actionJob{Status: "completed"}, "warning"))
+
+	assert.True(t, (&print.PullCheck{State: print.CheckCancelled}).Failed())
+	assert.False(t, (&print.PullCheck{State: "warning"}).Failed())
+	assert.Equal(t, "3 checks: 1 passed, 1 failed, 1 pending", print.PullChecksSummary([]*print.PullCheck{
+		{State: "success"}, {State: "error"}, {State: print.CheckPending},
+	}))
+}
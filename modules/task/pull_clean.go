@@ -4,10 +4,13 @@
 package task
 
 import (
+	"context"
 	"fmt"
 
 	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/dryrun"
 	local_git "code.gitea.io/tea/modules/git"
+	"code.gitea.io/tea/modules/utils"
 	"code.gitea.io/tea/modules/workaround"
 
 	"code.gitea.io/sdk/gitea"
@@ -15,11 +18,15 @@ import (
 	git_plumbing "github.com/go-git/go-git/v5/plumbing"
 )
 
-// PullClean deletes local & remote feature-branches for a closed pull
-func PullClean(login *config.Login, repoOwner, repoName string, index int64, ignoreSHA bool, callback func(string) (string, error)) error {
-	client := login.Client()
+// PullClean deletes local & remote feature-branches for a closed pull,
+// and removes the linked worktrees the local branch is checked out in
+func PullClean(ctx context.Context, login *config.Login, repoOwner, repoName string, index int64, ignoreSHA bool, callback func(string) (string, error)) error {
+	client, err := login.Client(ctx)
+	if err != nil {
+		return err
+	}
 
-	repo, _, err := client.GetRepo(repoOwner, repoName)
+	repo, err := utils.APIResult(client.GetRepo(repoOwner, repoName))
 	if err != nil {
 		return err
 	}
@@ -29,7 +36,7 @@ func PullClean(login *config.Login, repoOwner, repoName string, index int64, ign
 	}
 
 	// fetch PR source-repo & -branch from gitea
-	pr, _, err := client.GetPullRequest(repoOwner, repoName, index)
+	pr, err := utils.APIResult(client.GetPullRequest(repoOwner, repoName, index))
 	if err != nil {
 		return err
 	}
@@ -75,6 +82,9 @@ call me again with the --ignore-sha flag`, remoteBranch)
 	}
 
 	// prepare deletion of local branch:
+	if err := removePullWorktrees(ctx, r, branch.Name); err != nil {
+		return err
+	}
 	headRef, err := r.Head()
 	if err != nil {
 		return err
@@ -82,20 +92,26 @@ call me again with the --ignore-sha flag`, remoteBranch)
 	if headRef.Name().Short() == branch.Name {
 		fmt.Printf("Checking out '%s' to delete local branch '%s'\n", defaultBranch, branch.Name)
 		ref := git_plumbing.NewBranchReferenceName(defaultBranch)
-		if err = r.TeaCheckout(ref); err != nil {
-			return err
+		if !dryrun.Skip("git checkout %s", defaultBranch) {
+			if err = r.TeaCheckout(ref); err != nil {
+				return err
+			}
 		}
 	}
 
 	// remove local & remote branch
 	fmt.Printf("Deleting local branch %s\n", branch.Name)
-	err = r.TeaDeleteLocalBranch(branch)
-	if err != nil {
-		return err
+	if !dryrun.Skip("git branch -D %s", branch.Name) {
+		if err = r.TeaDeleteLocalBranch(branch); err != nil {
+			return err
+		}
 	}
 
 	if !remoteDeleted && pr.Head.Repository.Permissions.Push {
 		fmt.Printf("Deleting remote branch %s\n", remoteBranch)
+		if dryrun.Skip("git push %s --delete %s", branch.Remote, remoteBranch) {
+			return nil
+		}
 		url, err := r.TeaRemoteURL(branch.Remote)
 		if err != nil {
 			return err
@@ -104,7 +120,7 @@ call me again with the --ignore-sha flag`, remoteBranch)
 		if err != nil {
 			return err
 		}
-		err = r.TeaDeleteRemoteBranch(branch.Remote, remoteBranch, auth)
+		err = r.TeaDeleteRemoteBranch(ctx, branch.Remote, remoteBranch, auth)
 	}
 	return err
 }
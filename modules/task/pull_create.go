@@ -4,6 +4,7 @@
 package task
 
 import (
+	stdctx "context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/modules/config"
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
 	local_git "code.gitea.io/tea/modules/git"
 	"code.gitea.io/tea/modules/print"
 	"code.gitea.io/tea/modules/utils"
@@ -23,23 +25,46 @@ var (
 )
 
 // CreatePull creates a PR in the given repo and prints the result
-func CreatePull(ctx *context.TeaContext, base, head string, allowMaintainerEdits *bool, opts *gitea.CreateIssueOption) (err error) {
+func CreatePull(ctx *context.TeaContext, base, head string, allowMaintainerEdits *bool, opts *gitea.CreateIssueOption) error {
+	pr, err := CreatePullRequest(ctx, base, head, allowMaintainerEdits, opts)
+	if err != nil {
+		return err
+	}
+	if dryrun.Enabled() {
+		// the PR isn't created in dry-run mode
+		return nil
+	}
+
+	if err := print.PullDetails(ctx.Out(), pr, nil, nil, ctx.Output); err != nil {
+		return err
+	}
+
+	if !print.IsQueryOutput(ctx.Output) {
+		fmt.Fprintln(ctx.Out(), pr.HTMLURL)
+	}
+
+	return nil
+}
+
+// CreatePullRequest creates a PR in the given repo. Base and head default to the
+// default branch of the repo and the current branch of the local repo.
+func CreatePullRequest(ctx *context.TeaContext, base, head string, allowMaintainerEdits *bool, opts *gitea.CreateIssueOption) (pr *gitea.PullRequest, err error) {
 	// default is default branch
 	if len(base) == 0 {
-		base, err = GetDefaultPRBase(ctx.Login, ctx.Owner, ctx.Repo)
+		base, err = GetDefaultPRBase(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
 	// default is current one
 	if len(head) == 0 {
 		if ctx.LocalRepo == nil {
-			return fmt.Errorf("no local git repo detected, please specify head branch")
+			return nil, fmt.Errorf("no local git repo detected, please specify head branch")
 		}
 		headOwner, headBranch, err := GetDefaultPRHead(ctx.LocalRepo)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		head = GetHeadSpec(headOwner, headBranch, ctx.Owner)
@@ -47,7 +72,7 @@ func CreatePull(ctx *context.TeaContext, base, head string, allowMaintainerEdits
 
 	// head & base may not be the same
 	if head == base {
-		return fmt.Errorf("can't create PR from %s to %s", head, base)
+		return nil, fmt.Errorf("can't create PR from %s to %s", head, base)
 	}
 
 	// default is head branch name
@@ -56,12 +81,15 @@ func CreatePull(ctx *context.TeaContext, base, head string, allowMaintainerEdits
 	}
 	// title is required
 	if len(opts.Title) == 0 {
-		return fmt.Errorf("title is required")
+		return nil, fmt.Errorf("title is required")
 	}
 
-	client := ctx.Login.Client()
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
 
-	pr, _, err := client.CreatePullRequest(ctx.Owner, ctx.Repo, gitea.CreatePullRequestOption{
+	pr, err = utils.APIResult(client.CreatePullRequest(ctx.Owner, ctx.Repo, gitea.CreatePullRequestOption{
 		Head:      head,
 		Base:      base,
 		Title:     opts.Title,
@@ -70,32 +98,36 @@ func CreatePull(ctx *context.TeaContext, base, head string, allowMaintainerEdits
 		Labels:    opts.Labels,
 		Milestone: opts.Milestone,
 		Deadline:  opts.Deadline,
-	})
+	}))
 	if err != nil {
-		return fmt.Errorf("could not create PR from %s to %s:%s: %s", head, ctx.Owner, base, err)
+		return nil, fmt.Errorf("could not create PR from %s to %s:%s: %w", head, ctx.Owner, base, err)
 	}
 
 	if allowMaintainerEdits != nil && pr.AllowMaintainerEdit != *allowMaintainerEdits {
-		pr, _, err = client.EditPullRequest(ctx.Owner, ctx.Repo, pr.Index, gitea.EditPullRequestOption{
+		pr, err = utils.APIResult(client.EditPullRequest(ctx.Owner, ctx.Repo, pr.Index, gitea.EditPullRequestOption{
 			AllowMaintainerEdit: allowMaintainerEdits,
-		})
+		}))
 		if err != nil {
-			return fmt.Errorf("could not enable maintainer edit on pull: %v", err)
+			return nil, fmt.Errorf("could not enable maintainer edit on pull: %w", err)
 		}
 	}
 
-	print.PullDetails(pr, nil, nil)
-
-	fmt.Println(pr.HTMLURL)
-
-	return err
+	return pr, nil
 }
 
 // GetDefaultPRBase retrieves the default base branch for the given repo
-func GetDefaultPRBase(login *config.Login, owner, repo string) (string, error) {
-	meta, _, err := login.Client().GetRepo(owner, repo)
+// unless a base branch is configured as flag default.
+func GetDefaultPRBase(ctx stdctx.Context, login *config.Login, owner, repo string) (string, error) {
+	if base := config.GetPreferences().FlagDefaults.PullBase; len(base) != 0 {
+		return base, nil
+	}
+	client, err := login.Client(ctx)
+	if err != nil {
+		return "", err
+	}
+	meta, err := utils.APIResult(client.GetRepo(owner, repo))
 	if err != nil {
-		return "", fmt.Errorf("could not fetch repo meta: %s", err)
+		return "", fmt.Errorf("could not fetch repo meta: %w", err)
 	}
 	return meta.DefaultBranch, nil
 }
@@ -111,7 +143,7 @@ func GetDefaultPRHead(localRepo *local_git.TeaRepo) (owner, branch string, err e
 
 	remote, err := localRepo.TeaFindBranchRemote(branch, sha)
 	if err != nil {
-		err = fmt.Errorf("could not determine remote for current branch: %s", err)
+		err = fmt.Errorf("could not determine remote for current branch: %w", err)
 		return
 	}
 
@@ -4,16 +4,28 @@
 package task
 
 import (
+	stdctx "context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/api"
 	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 )
 
 // PullMerge merges a PR
-func PullMerge(login *config.Login, repoOwner, repoName string, index int64, opt gitea.MergePullRequestOption) error {
-	client := login.Client()
-	success, _, err := client.MergePullRequest(repoOwner, repoName, index, opt)
+func PullMerge(ctx stdctx.Context, login *config.Login, repoOwner, repoName string, index int64, opt gitea.MergePullRequestOption) error {
+	client, err := login.Client(ctx)
+	if err != nil {
+		return err
+	}
+	success, err := utils.APIResult(client.MergePullRequest(repoOwner, repoName, index, opt))
 	if err != nil {
 		return err
 	}
@@ -22,3 +34,172 @@ func PullMerge(login *config.Login, repoOwner, repoName string, index int64, opt
 	}
 	return nil
 }
+
+// PullScheduleMerge lets the server merge a PR as soon as its checks succeed.
+// It returns false if the PR was merged right away, as the checks already succeeded.
+func PullScheduleMerge(ctx stdctx.Context, login *config.Login, repoOwner, repoName string, index int64, opt gitea.MergePullRequestOption) (bool, error) {
+	client, err := login.Client(ctx)
+	if err != nil {
+		return false, err
+	}
+	opt.MergeWhenChecksSucceed = true
+	_, resp, err := client.MergePullRequest(repoOwner, repoName, index, opt)
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		return false, utils.NewValidationErrorf("#%d is already scheduled to be merged", index)
+	}
+	if err != nil {
+		return false, utils.APIError(resp, err)
+	}
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusOK:
+		return false, nil
+	}
+	return false, fmt.Errorf("Failed to schedule the merge of PR. Is it still open?")
+}
+
+// PullCancelScheduledMerge cancels the scheduled merge of a PR.
+// The SDK doesn't support this yet, so the API is requested directly.
+func PullCancelScheduledMerge(ctx stdctx.Context, login *config.Login, repoOwner, repoName string, index int64) error {
+	client, err := api.NewClient(ctx, login)
+	if err != nil {
+		return err
+	}
+	_, err = client.Request(http.MethodDelete, fmt.Sprintf("/repos/%s/%s/pulls/%d/merge", repoOwner, repoName, index), nil)
+	if errors.Is(err, utils.ErrNotFound) {
+		return utils.NewNotFoundErrorf("#%d is not scheduled to be merged", index)
+	}
+	return err
+}
+
+// PullMergeBlockers returns the reasons why a PR can't be merged yet. An error
+// is returned if the PR can't become mergeable by waiting, e.g. if a check failed.
+func PullMergeBlockers(ctx *context.TeaContext, pr *gitea.PullRequest) ([]string, error) {
+	if pr.HasMerged {
+		return nil, utils.NewValidationErrorf("#%d is already merged", pr.Index)
+	}
+	if pr.State != gitea.StateOpen {
+		return nil, utils.NewValidationErrorf("#%d is closed", pr.Index)
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	var blockers []string
+	if !pr.Mergeable {
+		blockers = append(blockers, "not mergeable: it has conflicts, or the server is still checking it")
+	}
+
+	branch, err := utils.APIResult(client.GetRepoBranch(ctx.Owner, ctx.Repo, pr.Base.Ref))
+	if err != nil {
+		return nil, err
+	}
+	if branch.Protected && branch.EnableStatusCheck {
+		checkBlockers, err := pullCheckBlockers(ctx, pr)
+		if err != nil {
+			return nil, err
+		}
+		blockers = append(blockers, checkBlockers...)
+	}
+	if branch.RequiredApprovals > 0 {
+		reviews, err := ListPullReviews(client, ctx.Owner, ctx.Repo, pr.Index)
+		if err != nil {
+			return nil, err
+		}
+		if approvals := officialApprovals(reviews); approvals < branch.RequiredApprovals {
+			blockers = append(blockers, fmt.Sprintf("waiting for approvals: %d of %d", approvals, branch.RequiredApprovals))
+		}
+	}
+	return blockers, nil
+}
+
+// pullCheckBlockers returns the checks a PR waits for, or an error if one of them failed.
+// Like the server, all checks are required if the branch protection doesn't name any.
+func pullCheckBlockers(ctx *context.TeaContext, pr *gitea.PullRequest) ([]string, error) {
+	checks, err := PullChecks(ctx, pr, false)
+	if err != nil {
+		return nil, err
+	}
+	hasRequired := false
+	for _, c := range checks {
+		hasRequired = hasRequired || c.Required
+	}
+	var pending, failed []string
+	for _, c := range checks {
+		if hasRequired && !c.Required {
+			continue
+		}
+		if c.Failed() {
+			failed = append(failed, c.Name)
+		} else if !c.Done() {
+			pending = append(pending, c.Name)
+		}
+	}
+	if len(failed) != 0 {
+		return nil, fmt.Errorf("#%d can't be merged, checks failed: %s", pr.Index, strings.Join(failed, ", "))
+	}
+	if len(pending) != 0 {
+		return []string{"waiting for checks: " + strings.Join(pending, ", ")}, nil
+	}
+	return nil, nil
+}
+
+// officialApprovals counts the reviewers whose latest official review is an approval
+func officialApprovals(reviews []*gitea.PullReview) int64 {
+	latest := map[int64]*gitea.PullReview{}
+	for _, r := range reviews {
+		if r.Reviewer == nil || !r.Official || r.Dismissed {
+			continue
+		}
+		if r.State != gitea.ReviewStateApproved && r.State != gitea.ReviewStateRequestChanges {
+			continue
+		}
+		if l, ok := latest[r.Reviewer.ID]; !ok || r.Submitted.After(l.Submitted) {
+			latest[r.Reviewer.ID] = r
+		}
+	}
+	var approvals int64
+	for _, r := range latest {
+		if r.State == gitea.ReviewStateApproved {
+			approvals++
+		}
+	}
+	return approvals
+}
+
+// PullWaitAndMerge polls a PR until nothing blocks its merge, and merges it.
+// Whenever the reasons for blocking the merge change, they are printed to stderr.
+func PullWaitAndMerge(ctx *context.TeaContext, index int64, opt gitea.MergePullRequestOption, interval time.Duration) error {
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	lastBlockers := ""
+	for {
+		pr, err := utils.APIResult(client.GetPullRequest(ctx.Owner, ctx.Repo, index))
+		if err != nil {
+			return err
+		}
+		blockers, err := PullMergeBlockers(ctx, pr)
+		if err != nil {
+			return err
+		}
+		if len(blockers) == 0 {
+			break
+		}
+		if msg := strings.Join(blockers, "\n  "); msg != lastBlockers {
+			fmt.Fprintf(os.Stderr, "#%d is blocked:\n  %s\n", index, msg)
+			lastBlockers = msg
+		}
+
+		select {
+		case <-ctx.Ctx.Done():
+			return ctx.Ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+
+	return PullMerge(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo, index, opt)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfficialApprovals(t *testing.T) {
+	alice := &gitea.User{ID: 1}
+	bob := &gitea.User{ID: 2}
+	carol := &gitea.User{ID: 3}
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	reviews := []*gitea.PullReview{
+		// approval superseded by a later change request
+		{Reviewer: alice, Official: true, State: gitea.ReviewStateApproved, Submitted: day(1)},
+		{Reviewer: alice, Official: true, State: gitea.ReviewStateRequestChanges, Submitted: day(2)},
+		// comments don't override an approval
+		{Reviewer: bob, Official: true, State: gitea.ReviewStateApproved, Submitted: day(1)},
+		{Reviewer: bob, Official: true, State: gitea.ReviewStateComment, Submitted: day(3)},
+		// unofficial and dismissed reviews don't count
+		{Reviewer: carol, Official: false, State: gitea.ReviewStateApproved, Submitted: day(1)},
+		{Reviewer: carol, Official: true, Dismissed: true, State: gitea.ReviewStateApproved, Submitted: day(2)},
+	}
+	assert.EqualValues(t, 1, officialApprovals(reviews))
+
+	reviews = append(reviews, &gitea.PullReview{Reviewer: alice, Official: true, State: gitea.ReviewStateApproved, Submitted: day(4)})
+	assert.EqualValues(t, 2, officialApprovals(reviews))
+	assert.EqualValues(t, 0, officialApprovals(nil))
+}
+
+func TestPullMergeBlockers(t *testing.T) {
+	protected := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/repos/o/r/branches/main":
+			if protected {
+				_, _ = w.Write([]byte(`{"name":"main","protected":true,"enable_status_check":true}`))
+			} else {
+				_, _ = w.Write([]byte(`{"name":"main"}`))
+			}
+		case "/api/v1/repos/o/r/commits/abc123/status":
+			_, _ = w.Write([]byte(`{"state":"failure","statuses":[{"context":"lint","status":"failure"},{"context":"test","status":"pending"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := &context.TeaContext{
+		Ctx:   t.Context(),
+		Login: &config.Login{Name: "test", URL: server.URL, Token: "token"},
+		Owner: "o",
+		Repo:  "r",
+	}
+	pr := &gitea.PullRequest{
+		Index:     5,
+		State:     gitea.StateOpen,
+		Mergeable: true,
+		Head:      &gitea.PRBranchInfo{Sha: "abc123"},
+		Base:      &gitea.PRBranchInfo{Ref: "main"},
+	}
+
+	// the server merges into unprotected branches regardless of the checks
+	blockers, err := PullMergeBlockers(ctx, pr)
+	require.NoError(t, err)
+	assert.Empty(t, blockers)
+
+	protected = true
+	_, err = PullMergeBlockers(ctx, pr)
+	assert.ErrorContains(t, err, "checks failed: lint")
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
 
 	"code.gitea.io/sdk/gitea"
 	unidiff "gitea.com/noerw/unidiff-comments"
@@ -27,27 +28,34 @@ var diffReviewHelp = `# This is the current diff of PR #%d on %s.
 
 `
 
-// CreatePullReview submits a review for a PR
-func CreatePullReview(ctx *context.TeaContext, idx int64, status gitea.ReviewStateType, comment string, codeComments []gitea.CreatePullReviewComment) error {
-	c := ctx.Login.Client()
+// CreatePullReview submits a review for a PR, and returns it
+func CreatePullReview(ctx *context.TeaContext, idx int64, status gitea.ReviewStateType, comment string, codeComments []gitea.CreatePullReviewComment) (*gitea.PullReview, error) {
+	c, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
 
-	review, _, err := c.CreatePullReview(ctx.Owner, ctx.Repo, idx, gitea.CreatePullReviewOptions{
+	review, err := utils.APIResult(c.CreatePullReview(ctx.Owner, ctx.Repo, idx, gitea.CreatePullReviewOptions{
 		State:    status,
 		Body:     comment,
 		Comments: codeComments,
-	})
+	}))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fmt.Println(review.HTMLURL)
-	return nil
+	fmt.Fprintln(ctx.Out(), review.HTMLURL)
+	return review, nil
 }
 
 // SavePullDiff fetches the diff of a pull request and stores it as a temporary file.
 // The path to the file is returned.
 func SavePullDiff(ctx *context.TeaContext, idx int64) (string, error) {
-	diff, _, err := ctx.Login.Client().GetPullRequestDiff(ctx.Owner, ctx.Repo, idx, gitea.PullRequestDiffOptions{})
+	client, err := ctx.Client()
+	if err != nil {
+		return "", err
+	}
+	diff, err := utils.APIResult(client.GetPullRequestDiff(ctx.Owner, ctx.Repo, idx, gitea.PullRequestDiffOptions{}))
 	if err != nil {
 		return "", err
 	}
@@ -72,13 +80,13 @@ func SavePullDiff(ctx *context.TeaContext, idx int64) (string, error) {
 func ParseDiffComments(diffFile string) ([]gitea.CreatePullReviewComment, error) {
 	reader, err := os.Open(diffFile)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't load diff: %s", err)
+		return nil, fmt.Errorf("couldn't load diff: %w", err)
 	}
 	defer reader.Close()
 
 	changeset, err := unidiff.ReadChangeset(reader)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't parse patch: %s", err)
+		return nil, fmt.Errorf("couldn't parse patch: %w", err)
 	}
 
 	var comments []gitea.CreatePullReviewComment
@@ -0,0 +1,145 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"code.gitea.io/tea/modules/api"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/pagination"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// ListPullReviews fetches all reviews of a PR
+func ListPullReviews(client *gitea.Client, owner, repo string, idx int64) ([]*gitea.PullReview, error) {
+	return pagination.All(func(opts gitea.ListOptions) ([]*gitea.PullReview, *gitea.Response, error) {
+		return client.ListPullReviews(owner, repo, idx, gitea.ListPullReviewsOptions{ListOptions: opts})
+	}, pagination.Options{PageSize: 50})
+}
+
+// ListPullReviewComments fetches the inline comments of all reviews of a PR
+func ListPullReviewComments(client *gitea.Client, owner, repo string, idx int64) ([]*gitea.PullReviewComment, error) {
+	reviews, err := ListPullReviews(client, owner, repo, idx)
+	if err != nil {
+		return nil, err
+	}
+	var comments []*gitea.PullReviewComment
+	for _, r := range reviews {
+		if r.CodeCommentsCount == 0 {
+			continue
+		}
+		reviewComments, err := utils.APIResult(client.ListPullReviewComments(owner, repo, idx, r.ID))
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, reviewComments...)
+	}
+	return comments, nil
+}
+
+// findReviewComment returns the inline review comment of a PR with the given ID
+func findReviewComment(client *gitea.Client, owner, repo string, idx, id int64) (*gitea.PullReviewComment, error) {
+	comments, err := ListPullReviewComments(client, owner, repo, idx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, utils.NewNotFoundErrorf("pull request #%d has no review comment %d", idx, id)
+}
+
+// ReplyToReviewComment answers the review thread of a comment. The API can't reply to a
+// comment directly, so a comment review is submitted with a new comment on the same line
+// of the commit the comment was made on, which Gitea shows as part of the same thread.
+func ReplyToReviewComment(ctx *context.TeaContext, idx, commentID int64, body string) (*gitea.PullReview, error) {
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
+	c, err := findReviewComment(client, ctx.Owner, ctx.Repo, idx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := gitea.CreatePullReviewComment{
+		Path: c.Path,
+		Body: body,
+	}
+	if c.LineNum != 0 {
+		reply.NewLineNum = int64(c.LineNum)
+	} else {
+		reply.OldLineNum = int64(c.OldLineNum)
+	}
+	review, err := utils.APIResult(client.CreatePullReview(ctx.Owner, ctx.Repo, idx, gitea.CreatePullReviewOptions{
+		State:    gitea.ReviewStateComment,
+		CommitID: c.CommitID,
+		Comments: []gitea.CreatePullReviewComment{reply},
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("could not reply to comment %d: %w", commentID, err)
+	}
+	return review, nil
+}
+
+// ResolveReviewComment marks the review thread of a comment as resolved, or unresolved.
+// The SDK doesn't support this yet, so the API is requested directly. Servers without
+// the endpoint return a validation error, pointing to the comment in the web UI.
+func ResolveReviewComment(ctx *context.TeaContext, idx, commentID int64, resolve bool) error {
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	// check that the comment belongs to the PR, so a missing endpoint can be told apart
+	c, err := findReviewComment(client, ctx.Owner, ctx.Repo, idx, commentID)
+	if err != nil {
+		return err
+	}
+
+	action := "resolve"
+	if !resolve {
+		action = "unresolve"
+	}
+	raw, err := api.NewClient(ctx.Ctx, ctx.Login)
+	if err != nil {
+		return err
+	}
+	resp, err := raw.Do(http.MethodPost, fmt.Sprintf("/repos/%s/%s/pulls/comments/%d/%s",
+		ctx.Owner, ctx.Repo, commentID, action), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+		return utils.NewValidationErrorf("this Gitea server doesn't support to %s review threads via its API, use the web UI instead: %s", action, c.HTMLURL)
+	case resp.StatusCode >= 400:
+		data, _ := io.ReadAll(resp.Body)
+		return utils.StatusError(resp.StatusCode, fmt.Errorf("could not %s the thread of comment %d: %s", action, commentID, data))
+	}
+	return nil
+}
+
+// DismissPullReview dismisses a review of a PR with a message, or restores a dismissed review
+func DismissPullReview(ctx *context.TeaContext, idx, reviewID int64, message string, dismiss bool) error {
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	if dismiss {
+		err = utils.APIError(client.DismissPullReview(ctx.Owner, ctx.Repo, idx, reviewID, gitea.DismissPullReviewOptions{
+			Message: message,
+		}))
+	} else {
+		err = utils.APIError(client.UnDismissPullReview(ctx.Owner, ctx.Repo, idx, reviewID))
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplyToReviewComment(t *testing.T) {
+	var reviewRequest string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/repos/o/r/pulls/5/reviews":
+			_, _ = w.Write([]byte(`[{"id":1,"comments_count":1}]`))
+		case "GET /api/v1/repos/o/r/pulls/5/reviews/1/comments":
+			_, _ = w.Write([]byte(`[{"id":9,"path":"main.go","position":12,"commit_id":"abc123"}]`))
+		case "POST /api/v1/repos/o/r/pulls/5/reviews":
+			body, _ := io.ReadAll(r.Body)
+			reviewRequest = string(body)
+			_, _ = w.Write([]byte(`{"id":2,"state":"COMMENT"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := &context.TeaContext{
+		Ctx:   t.Context(),
+		Login: &config.Login{Name: "test", URL: server.URL, Token: "token"},
+		Owner: "o",
+		Repo:  "r",
+	}
+	_, err := ReplyToReviewComment(ctx, 5, 9, "Fixed")
+	require.NoError(t, err)
+	// the reply is a new comment on the line & commit of the answered comment
+	assert.JSONEq(t, `{"event":"COMMENT","body":"","commit_id":"abc123",
+		"comments":[{"path":"main.go","body":"Fixed","old_position":0,"new_position":12}]}`, reviewRequest)
+
+	_, err = ReplyToReviewComment(ctx, 5, 10, "Fixed")
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+}
+
+func TestResolveReviewComment(t *testing.T) {
+	supported := false
+	var resolved []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/repos/o/r/pulls/5/reviews":
+			_, _ = w.Write([]byte(`[{"id":1,"comments_count":1}]`))
+		case "GET /api/v1/repos/o/r/pulls/5/reviews/1/comments":
+			_, _ = w.Write([]byte(`[{"id":9,"path":"main.go","html_url":"https://gitea.com/o/r/pulls/5#issuecomment-9"}]`))
+		case "POST /api/v1/repos/o/r/pulls/comments/9/resolve", "POST /api/v1/repos/o/r/pulls/comments/9/unresolve":
+			if supported {
+				resolved = append(resolved, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			fallthrough
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := &context.TeaContext{
+		Ctx:   t.Context(),
+		Login: &config.Login{Name: "test", URL: server.URL, Token: "token"},
+		Owner: "o",
+		Repo:  "r",
+	}
+	err := ResolveReviewComment(ctx, 5, 9, true)
+	assert.ErrorIs(t, err, utils.ErrValidation)
+	assert.ErrorContains(t, err, "https://gitea.com/o/r/pulls/5#issuecomment-9")
+	// an unknown comment is reported as such, not as missing support
+	assert.ErrorIs(t, ResolveReviewComment(ctx, 5, 10, true), utils.ErrNotFound)
+
+	supported = true
+	require.NoError(t, ResolveReviewComment(ctx, 5, 9, true))
+	require.NoError(t, ResolveReviewComment(ctx, 5, 9, false))
+	assert.Equal(t, []string{"/api/v1/repos/o/r/pulls/comments/9/resolve", "/api/v1/repos/o/r/pulls/comments/9/unresolve"}, resolved)
+}
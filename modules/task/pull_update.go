@@ -0,0 +1,147 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	stdctx "context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"code.gitea.io/tea/modules/api"
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/dryrun"
+	local_git "code.gitea.io/tea/modules/git"
+	"code.gitea.io/tea/modules/utils"
+	"code.gitea.io/tea/modules/workaround"
+
+	git_config "github.com/go-git/go-git/v5/config"
+	git_plumbing "github.com/go-git/go-git/v5/plumbing"
+)
+
+// PullUpdate updates the head branch of a PR with the changes of its base branch,
+// by merging the base into it, or by rebasing it onto the base.
+// The SDK doesn't support this yet, so the API is requested directly.
+func PullUpdate(ctx stdctx.Context, login *config.Login, repoOwner, repoName string, index int64, rebase bool) error {
+	client, err := api.NewClient(ctx, login)
+	if err != nil {
+		return err
+	}
+	style := "merge"
+	if rebase {
+		style = "rebase"
+	}
+	resp, err := client.Do(http.MethodPost, fmt.Sprintf("/repos/%s/%s/pulls/%d/update?style=%s", repoOwner, repoName, index, style), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusConflict:
+		return utils.NewValidationErrorf("can't update #%d, the %s has conflicts with the base branch. Resolve them locally and push the result", index, style)
+	case resp.StatusCode >= 400:
+		data, _ := io.ReadAll(resp.Body)
+		return utils.StatusError(resp.StatusCode,
+			fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(data)))
+	}
+	return nil
+}
+
+// PullUpdateLocal fetches the head branch of a PR, and fast-forwards the local
+// branch tracking it, as created by PullCheckout.
+func PullUpdateLocal(ctx stdctx.Context, login *config.Login, repoOwner, repoName string, index int64, callback func(string) (string, error)) error {
+	client, err := login.Client(ctx)
+	if err != nil {
+		return err
+	}
+	pr, err := utils.APIResult(client.GetPullRequest(repoOwner, repoName, index))
+	if err != nil {
+		return err
+	}
+	if err := workaround.FixPullHeadSha(client, pr); err != nil {
+		return err
+	}
+
+	localRepo, err := local_git.RepoForWorkdir()
+	if err != nil {
+		return err
+	}
+	localRemote, err := localRepo.GetRemote(remoteURLForPR(login, pr))
+	if err != nil {
+		return err
+	}
+	if localRemote == nil {
+		return utils.NewNotFoundErrorf("#%d is not checked out locally, run `tea pulls checkout --branch %d` first", index, index)
+	}
+	remoteName := localRemote.Config().Name
+	remoteRefName := git_plumbing.NewRemoteReferenceName(remoteName, pr.Head.Ref)
+
+	// remember where the remote branch was, to find local branches that were at it
+	var oldHash git_plumbing.Hash
+	if ref, err := localRepo.Reference(remoteRefName, true); err == nil {
+		oldHash = ref.Hash()
+	}
+	branch, err := findPullBranch(localRepo, remoteName, pr.Head.Ref, oldHash)
+	if err != nil {
+		return err
+	}
+	if branch == nil {
+		return utils.NewNotFoundErrorf("no local branch tracks #%d, run `tea pulls checkout --branch %d` first", index, index)
+	}
+
+	if dryrun.Enabled() {
+		dryrun.Printf("git fetch %s", remoteName)
+		dryrun.Printf("git merge --ff-only %s/%s (on branch %s)", remoteName, pr.Head.Ref, branch.Name)
+		return nil
+	}
+	if _, err := doPRFetch(ctx, login, pr, localRepo, localRemote, callback); err != nil {
+		return err
+	}
+	ref, err := localRepo.Reference(remoteRefName, true)
+	if err != nil {
+		return err
+	}
+	updated, err := localRepo.TeaFastForward(branch.Name, ref.Hash())
+	if err != nil {
+		return err
+	}
+	if updated {
+		fmt.Printf("Fast-forwarded local branch '%s' to %s\n", branch.Name, ref.Hash().String()[:10])
+	} else {
+		fmt.Printf("Local branch '%s' is up to date\n", branch.Name)
+	}
+	return nil
+}
+
+// findPullBranch returns the local branch tracking the head branch of a PR. Branches
+// without tracking configuration are found by the commit they are at, if any.
+func findPullBranch(localRepo *local_git.TeaRepo, remoteName, remoteBranch string, hash git_plumbing.Hash) (*git_config.Branch, error) {
+	conf, err := localRepo.Config()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range conf.Branches {
+		if b.Remote == remoteName && b.Merge == git_plumbing.NewBranchReferenceName(remoteBranch) {
+			return b, nil
+		}
+	}
+	if hash.IsZero() {
+		return nil, nil
+	}
+
+	branches, err := localRepo.Branches()
+	if err != nil {
+		return nil, err
+	}
+	defer branches.Close()
+	var found *git_config.Branch
+	err = branches.ForEach(func(ref *git_plumbing.Reference) error {
+		if found == nil && ref.Hash() == hash {
+			found = &git_config.Branch{Name: ref.Name().Short(), Remote: remoteName, Merge: ref.Name()}
+		}
+		return nil
+	})
+	return found, err
+}
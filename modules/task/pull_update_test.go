@@ -0,0 +1,37 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/o/r/pulls/1/update":
+			w.WriteHeader(http.StatusOK)
+		case "/api/v1/repos/o/r/pulls/2/update":
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	login := &config.Login{Name: "test", URL: server.URL, Token: "token"}
+
+	require.NoError(t, PullUpdate(t.Context(), login, "o", "r", 1, false))
+	err := PullUpdate(t.Context(), login, "o", "r", 2, true)
+	assert.ErrorIs(t, err, utils.ErrValidation)
+	assert.Equal(t, utils.ExitValidation, utils.ExitCode(err))
+	assert.ErrorIs(t, PullUpdate(t.Context(), login, "o", "r", 3, false), utils.ErrNotFound)
+}
@@ -0,0 +1,254 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	stdctx "context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
+	local_git "code.gitea.io/tea/modules/git"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+	"code.gitea.io/tea/modules/workaround"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/go-git/go-git/v5"
+	git_config "github.com/go-git/go-git/v5/config"
+)
+
+// pullBranchPattern matches the local branches created by pullBranchName
+var pullBranchPattern = regexp.MustCompile(`^pulls/(\d+)(-|$)`)
+
+// PullCheckoutWorktree checks out the head branch of a PR in a new linked worktree,
+// leaving the current worktree untouched. Path defaults to a directory named after
+// the PR in the configured worktree directory.
+func PullCheckoutWorktree(
+	ctx stdctx.Context,
+	login *config.Login,
+	repoOwner, repoName string,
+	index int64,
+	path string,
+	callback func(string) (string, error),
+) error {
+	pr, err := getPullForCheckout(ctx, login, repoOwner, repoName, index)
+	if err != nil {
+		return err
+	}
+	localRepo, err := local_git.RepoForWorkdir()
+	if err != nil {
+		return err
+	}
+	worktrees, err := localRepo.TeaWorktrees(ctx)
+	if err != nil {
+		return err
+	}
+
+	localBranchName := pullBranchName(pr)
+	for _, wt := range worktrees {
+		if wt.Branch == localBranchName {
+			fmt.Printf("PR %d is already checked out in worktree %s\n", pr.Index, wt.Path)
+			return nil
+		}
+	}
+	if len(path) == 0 {
+		path, err = pullWorktreePath(worktrees[0].Path, config.GetPreferences().FlagDefaults.WorktreeDir, pr.Index)
+	} else {
+		path, err = utils.AbsPathWithExpansion(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	remoteURL := remoteURLForPR(login, pr)
+	newRemoteName := fmt.Sprintf("pulls/%v", pr.Head.Repository.Owner.UserName)
+	if dryrun.Enabled() {
+		remoteName, remoteBranchName, err := printPRFetch(localRepo, pr, remoteURL, newRemoteName)
+		if err != nil {
+			return err
+		}
+		dryrun.Printf("git branch --track %s %s/%s", localBranchName, remoteName, remoteBranchName)
+		dryrun.Printf("git worktree add %s %s", path, localBranchName)
+		return nil
+	}
+	localRemote, err := localRepo.GetOrCreateRemote(remoteURL, newRemoteName)
+	if err != nil {
+		return err
+	}
+	localRemoteBranchName, err := doPRFetch(ctx, login, pr, localRepo, localRemote, callback)
+	if err != nil {
+		return err
+	}
+
+	err = localRepo.TeaCreateBranch(localBranchName, localRemoteBranchName, localRemote.Config().Name)
+	if err == nil {
+		fmt.Printf("Created branch '%s'\n", localBranchName)
+	} else if err == git.ErrBranchExists {
+		fmt.Println("There may be changes since you last checked out, run `git pull` in the worktree to get them.")
+	} else {
+		return err
+	}
+
+	fmt.Printf("Adding worktree for PR %d at %s\n", pr.Index, path)
+	return localRepo.TeaAddWorktree(ctx, path, localBranchName)
+}
+
+// pullWorktreePath returns the path of the worktree of a PR in dir, which is
+// relative to the main worktree of the repo
+func pullWorktreePath(mainWorktree, dir string, index int64) (string, error) {
+	if len(dir) == 0 {
+		dir = filepath.Join("..", filepath.Base(mainWorktree)+".worktrees")
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		var err error
+		if dir, err = utils.AbsPathWithExpansion(dir); err != nil {
+			return "", err
+		}
+	} else if !filepath.IsAbs(dir) {
+		dir = filepath.Join(mainWorktree, dir)
+	}
+	return filepath.Join(dir, fmt.Sprintf("pull-%d", index)), nil
+}
+
+// pullIndexFromBranch returns the index of the PR a branch created by pullBranchName is for
+func pullIndexFromBranch(branch string) (int64, bool) {
+	m := pullBranchPattern.FindStringSubmatch(branch)
+	if m == nil {
+		return 0, false
+	}
+	index, err := strconv.ParseInt(m[1], 10, 64)
+	return index, err == nil
+}
+
+// PullWorktrees returns the linked worktrees of the repo in which a PR branch is
+// checked out, with their PRs
+func PullWorktrees(ctx *context.TeaContext) ([]*print.PullWorktree, error) {
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
+	worktrees, err := ctx.LocalRepo.TeaWorktrees(ctx.Ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*print.PullWorktree
+	// the main worktree can't be removed, so it is not a PR worktree
+	for _, wt := range worktrees[1:] {
+		index, ok := pullIndexFromBranch(wt.Branch)
+		if !ok {
+			continue
+		}
+		pr, resp, err := client.GetPullRequest(ctx.Owner, ctx.Repo, index)
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			return nil, utils.APIError(resp, err)
+		}
+		result = append(result, &print.PullWorktree{
+			Index:   index,
+			Pull:    pr,
+			Branch:  wt.Branch,
+			Path:    wt.Path,
+			Current: wt.Current,
+		})
+	}
+	return result, nil
+}
+
+// PullCleanWorktrees removes the linked worktrees of all closed PRs, and deletes
+// their local branches, unless they have diverged from the PR
+func PullCleanWorktrees(ctx stdctx.Context, login *config.Login, repoOwner, repoName string) error {
+	client, err := login.Client(ctx)
+	if err != nil {
+		return err
+	}
+	r, err := local_git.RepoForWorkdir()
+	if err != nil {
+		return err
+	}
+	worktrees, err := r.TeaWorktrees(ctx)
+	if err != nil {
+		return err
+	}
+
+	closed := 0
+	for _, wt := range worktrees[1:] {
+		index, ok := pullIndexFromBranch(wt.Branch)
+		if !ok {
+			continue
+		}
+		pr, err := utils.APIResult(client.GetPullRequest(repoOwner, repoName, index))
+		if errors.Is(err, utils.ErrNotFound) {
+			// like in PullWorktrees, a branch named like a PR branch may not belong to a PR of this repo
+			fmt.Printf("Skipping worktree %s, as PR %d was not found\n", wt.Path, index)
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := workaround.FixPullHeadSha(client, pr); err != nil {
+			return err
+		}
+		if pr.State == gitea.StateOpen {
+			continue
+		}
+		closed++
+		if wt.Current {
+			fmt.Printf("Skipping worktree of PR %d at %s, as it is the current directory\n", index, wt.Path)
+			continue
+		}
+
+		fmt.Printf("Removing worktree of PR %d at %s\n", index, wt.Path)
+		if !dryrun.Skip("git worktree remove %s", wt.Path) {
+			if err := r.TeaRemoveWorktree(ctx, wt); err != nil {
+				return err
+			}
+		}
+		if wt.Head != pr.Head.Sha {
+			fmt.Printf("Keeping local branch %s, as it has diverged from the PR\n", wt.Branch)
+			continue
+		}
+		fmt.Printf("Deleting local branch %s\n", wt.Branch)
+		if !dryrun.Skip("git branch -D %s", wt.Branch) {
+			if err := r.TeaDeleteLocalBranch(&git_config.Branch{Name: wt.Branch}); err != nil {
+				return err
+			}
+		}
+	}
+	if closed == 0 {
+		fmt.Println("No worktrees of closed pull requests found")
+	}
+	return nil
+}
+
+// removePullWorktrees removes the linked worktrees the given branch of a closed PR
+// is checked out in, so that the branch can be deleted
+func removePullWorktrees(ctx stdctx.Context, r *local_git.TeaRepo, branch string) error {
+	worktrees, err := r.TeaWorktrees(ctx)
+	if err != nil {
+		return err
+	}
+	for _, wt := range worktrees[1:] {
+		if wt.Branch != branch {
+			continue
+		}
+		if wt.Current {
+			return fmt.Errorf("branch '%s' is checked out in the current worktree, run the command from another directory", branch)
+		}
+		fmt.Printf("Removing worktree %s\n", wt.Path)
+		if dryrun.Skip("git worktree remove %s", wt.Path) {
+			continue
+		}
+		if err := r.TeaRemoveWorktree(ctx, wt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
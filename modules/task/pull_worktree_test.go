@@ -0,0 +1,39 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullIndexFromBranch(t *testing.T) {
+	for branch, want := range map[string]int64{
+		"pulls/12":                 12,
+		"pulls/3-refs/pull/3/head": 3,
+		"pulls/12a":                0,
+		"pulls/owner":              0,
+		"feature/pulls/12":         0,
+		"main":                     0,
+	} {
+		index, ok := pullIndexFromBranch(branch)
+		assert.Equal(t, want, index, branch)
+		assert.Equal(t, want != 0, ok, branch)
+	}
+}
+
+func TestPullWorktreePath(t *testing.T) {
+	path, err := pullWorktreePath("/src/tea", "", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, "/src/tea.worktrees/pull-12", path)
+
+	path, err = pullWorktreePath("/src/tea", "wt", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, "/src/tea/wt/pull-12", path)
+
+	path, err = pullWorktreePath("/src/tea", "/tmp/tea-pulls", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/tea-pulls/pull-12", path)
+}
@@ -4,12 +4,15 @@
 package task
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 
 	"code.gitea.io/sdk/gitea"
 	"code.gitea.io/tea/modules/config"
+	"code.gitea.io/tea/modules/dryrun"
 	local_git "code.gitea.io/tea/modules/git"
+	"code.gitea.io/tea/modules/utils"
 
 	"github.com/go-git/go-git/v5"
 	git_config "github.com/go-git/go-git/v5/config"
@@ -19,23 +22,23 @@ import (
 // RepoClone creates a local git clone in the given path, and sets up upstream remote
 // for fork repos, for good usability with tea.
 func RepoClone(
+	ctx context.Context,
 	path string,
 	login *config.Login,
 	repoOwner, repoName string,
 	callback func(string) (string, error),
 	depth int,
 ) (*local_git.TeaRepo, error) {
-	repoMeta, _, err := login.Client().GetRepo(repoOwner, repoName)
+	client, err := login.Client(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	originURL, err := cloneURL(repoMeta, login)
+	repoMeta, err := utils.APIResult(client.GetRepo(repoOwner, repoName))
 	if err != nil {
 		return nil, err
 	}
 
-	auth, err := local_git.GetAuthForURL(originURL, login.Token, login.SSHKey, callback)
+	originURL, err := cloneURL(repoMeta, login)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +48,26 @@ func RepoClone(
 		path = repoName
 	}
 
-	repo, err := git.PlainClone(path, false, &git.CloneOptions{
+	if dryrun.Enabled() {
+		depthArg := ""
+		if depth > 0 {
+			depthArg = fmt.Sprintf(" --depth %d", depth)
+		}
+		dryrun.Printf("git clone%s %s %s", depthArg, originURL, path)
+		if repoMeta.Fork && repoMeta.Parent != nil {
+			if upstreamURL, err := cloneURL(repoMeta.Parent, login); err == nil {
+				dryrun.Printf("git remote add upstream %s", upstreamURL)
+			}
+		}
+		return nil, dryrun.ErrSkipped
+	}
+
+	auth, err := local_git.GetAuthForURL(originURL, login.Token, login.SSHKey, callback)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := git.PlainCloneContext(ctx, path, false, &git.CloneOptions{
 		URL:             originURL.String(),
 		Auth:            auth,
 		Depth:           depth,
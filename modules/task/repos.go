@@ -0,0 +1,116 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/pagination"
+	"code.gitea.io/tea/modules/utils"
+)
+
+// ResolveRepos returns the repositories selected by the given selectors, as
+// owner/repo in the order of the selectors, without duplicates.
+// A selector is either
+//   - a repository: owner/repo
+//   - a glob over the repositories of an organization or user: owner/*, owner/tea-*
+//   - a file listing one selector per line, blank lines & lines starting with # are ignored: @repos.txt
+//
+// Archived repositories are only matched by globs if they are named explicitly.
+func ResolveRepos(client *gitea.Client, selectors []string) ([]string, error) {
+	var repos []string
+	add := func(repo string) {
+		if !slices.Contains(repos, repo) {
+			repos = append(repos, repo)
+		}
+	}
+
+	selectors, err := expandRepoFiles(selectors)
+	if err != nil {
+		return nil, err
+	}
+	// the repositories of each owner are listed once, even for multiple globs
+	owned := map[string][]*gitea.Repository{}
+	for _, s := range selectors {
+		owner, pattern, ok := strings.Cut(s, "/")
+		if !ok || owner == "" || pattern == "" || strings.Contains(pattern, "/") {
+			return nil, utils.NewValidationErrorf("invalid repository '%s', expected owner/repo or owner/<glob>", s)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, utils.NewValidationErrorf("invalid glob '%s': %v", s, err)
+		}
+		if !strings.ContainsAny(pattern, "*?[") {
+			add(s)
+			continue
+		}
+
+		if _, ok := owned[owner]; !ok {
+			if owned[owner], err = listOwnerRepos(client, owner); err != nil {
+				return nil, err
+			}
+		}
+		for _, r := range owned[owner] {
+			if matched, _ := path.Match(pattern, r.Name); matched && !r.Archived {
+				add(r.FullName)
+			}
+		}
+	}
+	return repos, nil
+}
+
+// expandRepoFiles replaces @file selectors by the selectors listed in the file
+func expandRepoFiles(selectors []string) ([]string, error) {
+	var expanded []string
+	for _, s := range selectors {
+		s = strings.TrimSpace(s)
+		file, ok := strings.CutPrefix(s, "@")
+		if !ok {
+			if s != "" {
+				expanded = append(expanded, s)
+			}
+			continue
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, utils.WrapError(utils.ErrValidation, err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				expanded = append(expanded, line)
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return expanded, nil
+}
+
+// listOwnerRepos lists the repositories of an organization, or of a user if
+// there is no such organization
+func listOwnerRepos(client *gitea.Client, owner string) ([]*gitea.Repository, error) {
+	repos, err := pagination.All(func(opts gitea.ListOptions) ([]*gitea.Repository, *gitea.Response, error) {
+		return client.ListOrgRepos(owner, gitea.ListOrgReposOptions{ListOptions: opts})
+	}, pagination.Options{PageSize: 50})
+	if !errors.Is(err, utils.ErrNotFound) {
+		return repos, err
+	}
+	repos, err = pagination.All(func(opts gitea.ListOptions) ([]*gitea.Repository, *gitea.Response, error) {
+		return client.ListUserRepos(owner, gitea.ListReposOptions{ListOptions: opts})
+	}, pagination.Options{PageSize: 50})
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil, utils.NewNotFoundErrorf("no organization or user named '%s'", owner)
+	}
+	return repos, err
+}
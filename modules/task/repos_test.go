@@ -0,0 +1,63 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRepos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/orgs/org/repos":
+			if r.URL.Query().Get("page") != "1" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[
+				{"name":"tea","full_name":"org/tea"},
+				{"name":"tea-docs","full_name":"org/tea-docs"},
+				{"name":"tea-old","full_name":"org/tea-old","archived":true},
+				{"name":"gitea","full_name":"org/gitea"}
+			]`))
+		case "/api/v1/orgs/jane/repos":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		case "/api/v1/users/jane/repos":
+			_, _ = w.Write([]byte(`[{"name":"dotfiles","full_name":"jane/dotfiles"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+	client, err := gitea.NewClient(server.URL, gitea.SetGiteaVersion(""))
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "repos.txt")
+	require.NoError(t, os.WriteFile(file, []byte("# our repos\norg/gitea\n\njane/*\n"), 0o644))
+
+	repos, err := ResolveRepos(client, []string{"org/tea-*", "org/tea", "@" + file, "org/tea-old"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/tea-docs", "org/tea", "org/gitea", "jane/dotfiles", "org/tea-old"}, repos)
+
+	_, err = ResolveRepos(client, []string{"nobody/*"})
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+
+	for _, invalid := range []string{"tea", "org/", "org/tea/x", "org/[", "@missing.txt"} {
+		_, err = ResolveRepos(client, []string{invalid})
+		assert.ErrorIs(t, err, utils.ErrValidation, invalid)
+	}
+}
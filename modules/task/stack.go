@@ -0,0 +1,389 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/dryrun"
+	local_git "code.gitea.io/tea/modules/git"
+	"code.gitea.io/tea/modules/pagination"
+	"code.gitea.io/tea/modules/print"
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/go-git/go-git/v5"
+	git_transport "github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// markers of the stack section in the description of the PRs of a stack
+const (
+	stackSectionStart = "<!-- tea stack -->"
+	stackSectionEnd   = "<!-- /tea stack -->"
+)
+
+// Stack is a linear chain of local branches, each based on the one below,
+// with the PRs of their changes
+type Stack struct {
+	// Trunk is the branch the stack is based on
+	Trunk string
+	// Remote is the name of the local remote of the repo
+	Remote string
+	// Branches are ordered from the bottom to the top of the stack
+	Branches []*print.StackBranch
+}
+
+// LoadStack detects the stack of local branches the current branch is part of,
+// and finds the PRs of its branches. Trunk defaults to the default PR base.
+func LoadStack(ctx *context.TeaContext, trunk string) (*Stack, error) {
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
+	repo, err := utils.APIResult(client.GetRepo(ctx.Owner, ctx.Repo))
+	if err != nil {
+		return nil, err
+	}
+	if len(trunk) == 0 {
+		if trunk, err = GetDefaultPRBase(ctx.Ctx, ctx.Login, ctx.Owner, ctx.Repo); err != nil {
+			return nil, err
+		}
+	}
+	remote, err := ctx.LocalRepo.GetRemote(repo.CloneURL)
+	if err != nil {
+		return nil, err
+	}
+	if remote == nil {
+		return nil, fmt.Errorf("no remote of the local repo points to %s", repo.FullName)
+	}
+
+	current, _, err := ctx.LocalRepo.TeaGetCurrentBranchNameAndSHA()
+	if err != nil {
+		return nil, err
+	}
+	names, err := ctx.LocalRepo.TeaStack(trunk, current)
+	if err != nil {
+		return nil, err
+	}
+	pulls, err := stackPulls(client, repo, names)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := &Stack{Trunk: trunk, Remote: remote.Config().Name}
+	base := trunk
+	for _, name := range names {
+		stack.Branches = append(stack.Branches, &print.StackBranch{
+			Branch:  name,
+			Base:    base,
+			Pull:    pulls[name],
+			Current: name == current,
+		})
+		base = name
+	}
+	return stack, nil
+}
+
+// stackPulls returns the PRs of the given branches of a repo. Open PRs are preferred,
+// for other branches recently closed PRs are searched.
+func stackPulls(client *gitea.Client, repo *gitea.Repository, branches []string) (map[string]*gitea.PullRequest, error) {
+	pulls := map[string]*gitea.PullRequest{}
+	add := func(prs []*gitea.PullRequest) {
+		for _, pr := range prs {
+			if pr.Head == nil || pr.Head.Repository == nil || pr.Head.Repository.ID != repo.ID {
+				continue
+			}
+			for _, b := range branches {
+				if pr.Head.Ref == b && pulls[b] == nil {
+					pulls[b] = pr
+				}
+			}
+		}
+	}
+
+	for _, opt := range []struct {
+		state    gitea.StateType
+		maxItems int
+	}{
+		{gitea.StateOpen, 0},
+		{gitea.StateClosed, 100},
+	} {
+		if len(pulls) == len(branches) {
+			break
+		}
+		prs, err := pagination.All(func(lo gitea.ListOptions) ([]*gitea.PullRequest, *gitea.Response, error) {
+			return client.ListRepoPullRequests(repo.Owner.UserName, repo.Name, gitea.ListPullRequestsOptions{
+				ListOptions: lo,
+				State:       opt.state,
+				Sort:        "recentupdate",
+			})
+		}, pagination.Options{PageSize: 50, MaxItems: opt.maxItems})
+		if err != nil {
+			return nil, err
+		}
+		add(prs)
+	}
+	return pulls, nil
+}
+
+// StackSubmit pushes the branches of a stack, and creates or updates one PR per
+// branch, based on the branch below it. The description of each PR gets a section
+// listing all PRs of the stack.
+func StackSubmit(ctx *context.TeaContext, stack *Stack, callback func(string) (string, error)) error {
+	for _, b := range stack.Branches {
+		if b.Pull != nil && b.Pull.HasMerged {
+			return fmt.Errorf("#%d of branch '%s' is merged already, run `tea stack sync` first", b.Pull.Index, b.Branch)
+		}
+	}
+	auth, err := stackAuth(ctx, stack, callback)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range stack.Branches {
+		if err := pushStackBranch(ctx, stack, b.Branch, auth); err != nil {
+			return err
+		}
+
+		if b.Pull == nil || b.Pull.State != gitea.StateOpen {
+			pr, err := CreatePullRequest(ctx, b.Base, b.Branch, nil, &gitea.CreateIssueOption{})
+			if err != nil {
+				return err
+			} else if dryrun.Enabled() {
+				// the PR wasn't created, so it isn't listed in the descriptions of the others
+				continue
+			}
+			b.Pull = pr
+			fmt.Printf("Created #%d for branch '%s': %s\n", pr.Index, b.Branch, pr.HTMLURL)
+			continue
+		}
+		if err := retargetStackPull(ctx, b); err != nil {
+			return err
+		}
+	}
+
+	return updateStackSections(ctx, stack)
+}
+
+// StackSync restacks the branches of a stack once the PRs at its bottom are merged:
+// the local branches above them are rebased onto the trunk and pushed, and their
+// PRs are retargeted. Running it again after resolving conflicts of the rebase
+// completes the sync.
+func StackSync(ctx *context.TeaContext, stack *Stack, callback func(string) (string, error)) error {
+	// PRs of a stack are merged from the bottom, as each is based on the one below
+	merged := 0
+	for merged < len(stack.Branches) && isMergedStackBranch(stack.Branches[merged]) {
+		merged++
+	}
+	for _, b := range stack.Branches[merged:] {
+		if isMergedStackBranch(b) {
+			return fmt.Errorf("#%d of branch '%s' was merged into '%s', restack the branches above it manually",
+				b.Pull.Index, b.Branch, b.Pull.Base.Ref)
+		}
+	}
+
+	mergedBranches, remaining := stack.Branches[:merged], stack.Branches[merged:]
+	restack := merged != 0 && len(remaining) != 0
+	if restack {
+		// checked upfront, so nothing is changed if the stack can't be rebased
+		if err := ctx.LocalRepo.TeaCheckRebaseStack(ctx.Ctx); err != nil {
+			return err
+		}
+	}
+
+	auth, err := stackAuth(ctx, stack, callback)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Fetching remote '%s'\n", stack.Remote)
+	if !dryrun.Skip("git fetch %s", stack.Remote) {
+		remote, err := ctx.LocalRepo.Remote(stack.Remote)
+		if err != nil {
+			return err
+		}
+		if err := remote.FetchContext(ctx.Ctx, &git.FetchOptions{Auth: auth}); err != nil && err != git.NoErrAlreadyUpToDate {
+			return err
+		}
+	}
+
+	for _, b := range mergedBranches {
+		fmt.Printf("#%d of branch '%s' is merged\n", b.Pull.Index, b.Branch)
+	}
+	if restack {
+		current, _, err := ctx.LocalRepo.TeaGetCurrentBranchNameAndSHA()
+		if err != nil {
+			return err
+		}
+		// the commits above the merged branches are rebased, and the refs of the
+		// branches in between move along. Branches that are checked out are not
+		// updated by git, so the rebase checks out the top of the stack.
+		onto := stack.Remote + "/" + stack.Trunk
+		top := remaining[len(remaining)-1].Branch
+		fmt.Printf("Rebasing '%s' onto '%s'\n", strings.Join(stackBranchNames(remaining), "', '"), onto)
+		if !dryrun.Skip("git rebase --update-refs --onto %s %s %s", onto, stack.Branches[merged-1].Branch, top) {
+			if err := ctx.LocalRepo.TeaRebaseStack(ctx.Ctx, onto, stack.Branches[merged-1].Branch, top); err != nil {
+				return fmt.Errorf("rebasing the stack failed: %w\nResolve the conflicts, run `git rebase --continue` and then `tea stack sync` again", err)
+			}
+		}
+		if current != top && !dryrun.Skip("git checkout %s", current) {
+			if err := ctx.LocalRepo.TeaCheckoutBranch(ctx.Ctx, current); err != nil {
+				return err
+			}
+		}
+	}
+
+	base := stack.Trunk
+	for _, b := range remaining {
+		b.Base = base
+		base = b.Branch
+		if err := pushStackBranch(ctx, stack, b.Branch, auth); err != nil {
+			return err
+		}
+		if b.Pull != nil && b.Pull.State == gitea.StateOpen {
+			if err := retargetStackPull(ctx, b); err != nil {
+				return err
+			}
+		}
+	}
+	stack.Branches = remaining
+	if err := updateStackSections(ctx, stack); err != nil {
+		return err
+	}
+
+	for _, b := range mergedBranches {
+		fmt.Printf("Branch '%s' of #%d can be deleted with `tea pulls clean %d`\n", b.Branch, b.Pull.Index, b.Pull.Index)
+	}
+	return nil
+}
+
+// isMergedStackBranch returns true if the PR of a stack branch is merged
+func isMergedStackBranch(b *print.StackBranch) bool {
+	return b.Pull != nil && b.Pull.HasMerged
+}
+
+func stackBranchNames(branches []*print.StackBranch) []string {
+	names := make([]string, 0, len(branches))
+	for _, b := range branches {
+		names = append(names, b.Branch)
+	}
+	return names
+}
+
+// stackAuth returns the auth method for the remote of a stack
+func stackAuth(ctx *context.TeaContext, stack *Stack, callback func(string) (string, error)) (git_transport.AuthMethod, error) {
+	url, err := ctx.LocalRepo.TeaRemoteURL(stack.Remote)
+	if err != nil {
+		return nil, err
+	}
+	return local_git.GetAuthForURL(url, ctx.Login.Token, ctx.Login.SSHKey, callback)
+}
+
+// pushStackBranch pushes a branch of a stack to the remote of the stack
+func pushStackBranch(ctx *context.TeaContext, stack *Stack, branch string, auth git_transport.AuthMethod) error {
+	fmt.Printf("Pushing '%s' to '%s'\n", branch, stack.Remote)
+	if dryrun.Skip("git push --force-with-lease %s %s", stack.Remote, branch) {
+		return nil
+	}
+	return ctx.LocalRepo.TeaPushBranch(ctx.Ctx, stack.Remote, branch, auth)
+}
+
+// retargetStackPull changes the base of the PR of a stack branch to the branch below it
+func retargetStackPull(ctx *context.TeaContext, b *print.StackBranch) error {
+	if b.Pull.Base.Ref == b.Base {
+		return nil
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	pr, err := utils.APIResult(client.EditPullRequest(ctx.Owner, ctx.Repo, b.Pull.Index, gitea.EditPullRequestOption{Base: b.Base}))
+	if err != nil {
+		return fmt.Errorf("could not change the base of #%d to '%s': %w", b.Pull.Index, b.Base, err)
+	} else if dryrun.Enabled() {
+		// the PR wasn't changed, so the one loaded before is kept
+		return nil
+	}
+	fmt.Printf("Changed the base of #%d from '%s' to '%s'\n", pr.Index, b.Pull.Base.Ref, b.Base)
+	b.Pull = pr
+	return nil
+}
+
+// updateStackSections updates the section listing the PRs of the stack in the
+// description of each PR, if it changed
+func updateStackSections(ctx *context.TeaContext, stack *Stack) error {
+	var pulls []*gitea.PullRequest
+	for _, b := range stack.Branches {
+		if b.Pull != nil && b.Pull.State == gitea.StateOpen {
+			pulls = append(pulls, b.Pull)
+		}
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+
+	for _, pr := range pulls {
+		section := ""
+		// a single PR is no stack
+		if len(pulls) > 1 {
+			section = stackSection(stack.Trunk, pulls, pr.Index)
+		}
+		body := withStackSection(pr.Body, section)
+		if body == pr.Body {
+			continue
+		}
+		_, err := utils.APIResult(client.EditPullRequest(ctx.Owner, ctx.Repo, pr.Index, gitea.EditPullRequestOption{Body: &body}))
+		if err != nil {
+			return fmt.Errorf("could not update the description of #%d: %w", pr.Index, err)
+		}
+		fmt.Printf("Updated the stack in the description of #%d\n", pr.Index)
+	}
+	return nil
+}
+
+// stackSection returns the markdown list of the PRs of a stack, for the
+// description of the PR with the given index
+func stackSection(trunk string, pulls []*gitea.PullRequest, current int64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Stack** based on `%s`, from the bottom:\n\n", trunk)
+	for _, pr := range pulls {
+		if pr.Index == current {
+			fmt.Fprintf(&b, "1. **#%d %s** ← this pull request\n", pr.Index, pr.Title)
+		} else {
+			fmt.Fprintf(&b, "1. #%d %s\n", pr.Index, pr.Title)
+		}
+	}
+	return b.String()
+}
+
+// withStackSection replaces the stack section of a PR description, or appends it.
+// An empty section removes it.
+func withStackSection(body, section string) string {
+	block := ""
+	if len(section) != 0 {
+		block = stackSectionStart + "\n" + section + stackSectionEnd
+	}
+	start := strings.Index(body, stackSectionStart)
+	end := strings.Index(body, stackSectionEnd)
+	if start >= 0 && end > start {
+		return joinParagraphs(body[:start], block, body[end+len(stackSectionEnd):])
+	}
+	if len(block) == 0 {
+		return body
+	}
+	return joinParagraphs(body, block)
+}
+
+// joinParagraphs joins the non-empty texts, separated by blank lines
+func joinParagraphs(texts ...string) string {
+	var paragraphs []string
+	for _, t := range texts {
+		if t = strings.Trim(t, "\n"); len(strings.TrimSpace(t)) != 0 {
+			paragraphs = append(paragraphs, t)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackSection(t *testing.T) {
+	pulls := []*gitea.PullRequest{
+		{Index: 1, Title: "Add parser"},
+		{Index: 2, Title: "Use parser"},
+	}
+	assert.Equal(t, "**Stack** based on `main`, from the bottom:\n\n"+
+		"1. #1 Add parser\n"+
+		"1. **#2 Use parser** ← this pull request\n",
+		stackSection("main", pulls, 2))
+}
+
+func TestWithStackSection(t *testing.T) {
+	block := "<!-- tea stack -->\nstack\n<!-- /tea stack -->"
+
+	assert.Equal(t, block, withStackSection("", "stack\n"))
+	assert.Equal(t, "Description\n\n"+block, withStackSection("Description\n", "stack\n"))
+
+	// an existing section is replaced in place
+	body := "Description\n\n<!-- tea stack -->\nold\n<!-- /tea stack -->\n\nFooter"
+	assert.Equal(t, "Description\n\n"+block+"\n\nFooter", withStackSection(body, "stack\n"))
+	assert.Equal(t, "Description\n\nFooter", withStackSection(body, ""))
+	assert.Equal(t, "Description\n", withStackSection("Description\n", ""))
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package task
+
+import (
+	"slices"
+	"strings"
+
+	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/context"
+	"code.gitea.io/tea/modules/templates"
+	"code.gitea.io/tea/modules/utils"
+)
+
+// TemplateSource returns where the templates of the given repository are read
+// from: the local checkout if it is one of the repository, and the API otherwise
+func TemplateSource(ctx *context.TeaContext, owner, repo string) (templates.Source, error) {
+	if ctx.LocalRepo != nil && owner == ctx.Owner && repo == ctx.Repo {
+		// --repo may select another repository than the local one
+		isPath := true
+		if repoFlag := ctx.String("repo"); len(repoFlag) != 0 {
+			var err error
+			if isPath, err = utils.DirExists(repoFlag); err != nil {
+				return nil, err
+			}
+		}
+		if isPath {
+			if wt, err := ctx.LocalRepo.Worktree(); err == nil {
+				return templates.LocalSource(wt.Filesystem.Root()), nil
+			}
+		}
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return nil, err
+	}
+	return templates.APISource(client, owner, repo), nil
+}
+
+// FindIssueTemplate returns the issue template of the repository in ctx with the given name
+func FindIssueTemplate(ctx *context.TeaContext, name string) (*templates.Template, error) {
+	src, err := TemplateSource(ctx, ctx.Owner, ctx.Repo)
+	if err != nil {
+		return nil, err
+	}
+	issueTemplates, err := templates.LoadIssueTemplates(src)
+	if err != nil {
+		return nil, err
+	}
+	return templates.Find(issueTemplates.Templates, name)
+}
+
+// FindPullTemplate returns the pull request template of the repository in ctx,
+// which must match the given name
+func FindPullTemplate(ctx *context.TeaContext, name string) (*templates.Template, error) {
+	src, err := TemplateSource(ctx, ctx.Owner, ctx.Repo)
+	if err != nil {
+		return nil, err
+	}
+	t, err := templates.LoadPullTemplate(src)
+	if err != nil {
+		return nil, err
+	}
+	var found []*templates.Template
+	if t != nil {
+		found = append(found, t)
+	}
+	return templates.Find(found, name)
+}
+
+// ApplyTemplate sets the title prefix, labels, assignees & body of a template
+// on opts. A body given in opts takes precedence over the one of the template,
+// otherwise required fields of issue forms must have a default value.
+func ApplyTemplate(client *gitea.Client, owner, repo string, t *templates.Template, opts *gitea.CreateIssueOption) error {
+	if len(opts.Body) == 0 {
+		if missing := t.Missing(); len(missing) != 0 {
+			return utils.NewValidationErrorf("template '%s' requires %s: fill in the form interactively, or provide the content via --description",
+				t.Name, strings.Join(missing, ", "))
+		}
+		opts.Body = t.RenderBody()
+	}
+	if !strings.HasPrefix(opts.Title, t.Title) {
+		opts.Title = t.Title + opts.Title
+	}
+
+	for _, a := range t.Assignees {
+		if !slices.Contains(opts.Assignees, a) {
+			opts.Assignees = append(opts.Assignees, a)
+		}
+	}
+	if len(t.Labels) != 0 {
+		labels, err := ResolveLabelNames(client, owner, repo, t.Labels)
+		if err != nil {
+			return err
+		}
+		for _, id := range labels {
+			if !slices.Contains(opts.Labels, id) {
+				opts.Labels = append(opts.Labels, id)
+			}
+		}
+	}
+	return nil
+}
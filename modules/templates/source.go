@@ -0,0 +1,195 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package templates
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"code.gitea.io/sdk/gitea"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IssueTemplateDirs are the directories searched for issue templates, in order of precedence
+var IssueTemplateDirs = []string{
+	".gitea/ISSUE_TEMPLATE",
+	".gitea/issue_template",
+	".github/ISSUE_TEMPLATE",
+	".github/issue_template",
+}
+
+// PullTemplateFiles are the files searched for the pull request template, in order of precedence
+var PullTemplateFiles = []string{
+	".gitea/PULL_REQUEST_TEMPLATE.md",
+	".gitea/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	".github/pull_request_template.md",
+}
+
+// Source reads files of a repository
+type Source interface {
+	// ReadDir returns the paths of the files in dir, or nothing if dir doesn't exist
+	ReadDir(dir string) ([]string, error)
+	// ReadFile returns the content of a file, or nil if it doesn't exist
+	ReadFile(file string) ([]byte, error)
+}
+
+// IssueTemplates are the issue templates of a repository
+type IssueTemplates struct {
+	Templates []*Template
+	// BlankIssuesEnabled is false if issues must be created from a template
+	BlankIssuesEnabled bool
+}
+
+// LoadIssueTemplates reads the issue templates from the first of IssueTemplateDirs containing any
+func LoadIssueTemplates(src Source) (*IssueTemplates, error) {
+	result := &IssueTemplates{BlankIssuesEnabled: true}
+	for _, dir := range IssueTemplateDirs {
+		files, err := src.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			switch path.Base(file) {
+			case "config.yaml", "config.yml":
+				if err := loadIssueConfig(src, file, result); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			switch path.Ext(file) {
+			case ".md", ".yaml", ".yml":
+			default:
+				continue
+			}
+			data, err := src.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			t, err := Parse(file, data)
+			if err != nil {
+				return nil, err
+			}
+			result.Templates = append(result.Templates, t)
+		}
+		if len(result.Templates) != 0 {
+			break
+		}
+	}
+	return result, nil
+}
+
+func loadIssueConfig(src Source, file string, templates *IssueTemplates) error {
+	data, err := src.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var config struct {
+		BlankIssuesEnabled *bool `yaml:"blank_issues_enabled"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	if config.BlankIssuesEnabled != nil {
+		templates.BlankIssuesEnabled = *config.BlankIssuesEnabled
+	}
+	return nil
+}
+
+// LoadPullTemplate reads the first of PullTemplateFiles, and returns nil if none exists
+func LoadPullTemplate(src Source) (*Template, error) {
+	for _, file := range PullTemplateFiles {
+		data, err := src.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if data != nil {
+			return Parse(file, data)
+		}
+	}
+	return nil, nil
+}
+
+// localSource reads files from a local checkout
+type localSource string
+
+// LocalSource returns a Source reading files from the worktree at root
+func LocalSource(root string) Source {
+	return localSource(root)
+}
+
+func (s localSource) ReadDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(string(s), filepath.FromSlash(dir)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			files = append(files, path.Join(dir, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+func (s localSource) ReadFile(file string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(string(s), filepath.FromSlash(file)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// apiSource reads files of the default branch via the contents API
+type apiSource struct {
+	client      *gitea.Client
+	owner, repo string
+}
+
+// APISource returns a Source reading files of the default branch of a repository via the API
+func APISource(client *gitea.Client, owner, repo string) Source {
+	return &apiSource{client: client, owner: owner, repo: repo}
+}
+
+func (s *apiSource) ReadDir(dir string) ([]string, error) {
+	entries, resp, err := s.client.ListContents(s.owner, s.repo, "", dir)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, utils.APIError(resp, err)
+	}
+	var files []string
+	for _, e := range entries {
+		if e.Type == "file" {
+			files = append(files, e.Path)
+		}
+	}
+	return files, nil
+}
+
+func (s *apiSource) ReadFile(file string) ([]byte, error) {
+	content, resp, err := s.client.GetContents(s.owner, s.repo, "", file)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, utils.APIError(resp, err)
+	}
+	if content.Content == nil {
+		return []byte{}, nil
+	}
+	return base64.StdEncoding.DecodeString(*content.Content)
+}
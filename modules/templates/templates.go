@@ -0,0 +1,327 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+// Package templates discovers & parses the issue and pull request templates
+// of a repository, as supported by Gitea: markdown templates with optional
+// front matter, and YAML issue forms.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Template is an issue or pull request template
+type Template struct {
+	// Name is the name given in the template, or its file name
+	Name string
+	// File is the path of the template in the repository
+	File  string
+	About string
+	// Title is the initial title, usually a prefix like "[Bug]: "
+	Title     string
+	Labels    []string
+	Assignees []string
+	// Body is the initial body of markdown templates
+	Body string
+	// Fields are the fields of YAML issue forms
+	Fields []*Field
+}
+
+// IsForm returns true for YAML issue forms, whose body is rendered from the fields
+func (t *Template) IsForm() bool {
+	return len(t.Fields) != 0
+}
+
+// Field types of issue forms
+const (
+	FieldMarkdown   = "markdown"
+	FieldTextarea   = "textarea"
+	FieldInput      = "input"
+	FieldDropdown   = "dropdown"
+	FieldCheckboxes = "checkboxes"
+)
+
+// Field is a field of an issue form. Value & Selected hold the default,
+// and are updated with the user input before rendering the body.
+type Field struct {
+	Type        string
+	ID          string
+	Label       string
+	Description string
+	Placeholder string
+	// Render is the language of a textarea, which is rendered as code block
+	Render   string
+	Multiple bool
+	Options  []string
+	// RequiredOptions are the checkbox options which must be checked
+	RequiredOptions []string
+	Required        bool
+	// Value is the text of markdown, input & textarea fields
+	Value string
+	// Selected are the selected dropdown options & checked checkboxes
+	Selected []string
+}
+
+// stringList is a list of strings, given as YAML list or comma-separated string
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, s := range strings.Split(node.Value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				*l = append(*l, s)
+			}
+		}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// header holds the metadata of markdown front matter & issue forms
+type header struct {
+	Name        string     `yaml:"name"`
+	About       string     `yaml:"about"`
+	Description string     `yaml:"description"`
+	Title       string     `yaml:"title"`
+	Labels      stringList `yaml:"labels"`
+	Assignees   stringList `yaml:"assignees"`
+}
+
+type formField struct {
+	Type       string `yaml:"type"`
+	ID         string `yaml:"id"`
+	Attributes struct {
+		Label       string      `yaml:"label"`
+		Description string      `yaml:"description"`
+		Placeholder string      `yaml:"placeholder"`
+		Value       string      `yaml:"value"`
+		Render      string      `yaml:"render"`
+		Multiple    bool        `yaml:"multiple"`
+		Options     []yaml.Node `yaml:"options"`
+		Default     *int        `yaml:"default"`
+	} `yaml:"attributes"`
+	Validations struct {
+		Required bool `yaml:"required"`
+	} `yaml:"validations"`
+}
+
+// Parse parses the template in the given file, which is a YAML issue form
+// for .yaml & .yml files, and markdown with optional front matter otherwise
+func Parse(file string, data []byte) (*Template, error) {
+	t := &Template{File: file}
+	var h header
+	switch path.Ext(file) {
+	case ".yaml", ".yml":
+		var form struct {
+			header `yaml:",inline"`
+			Body   []formField `yaml:"body"`
+		}
+		if err := yaml.Unmarshal(data, &form); err != nil {
+			return nil, fmt.Errorf("invalid issue form %s: %w", file, err)
+		}
+		h = form.header
+		for i, f := range form.Body {
+			field, err := parseField(f)
+			if err != nil {
+				return nil, fmt.Errorf("invalid field %d of issue form %s: %w", i+1, file, err)
+			}
+			t.Fields = append(t.Fields, field)
+		}
+		if len(t.Fields) == 0 {
+			return nil, fmt.Errorf("issue form %s has no fields", file)
+		}
+
+	default:
+		body := bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+		if rest, ok := bytes.CutPrefix(body, []byte("---\n")); ok {
+			if frontMatter, content, ok := bytes.Cut(rest, []byte("\n---\n")); ok {
+				if err := yaml.Unmarshal(frontMatter, &h); err != nil {
+					return nil, fmt.Errorf("invalid front matter in template %s: %w", file, err)
+				}
+				body = content
+			}
+		}
+		t.Body = strings.TrimLeft(string(body), "\n")
+	}
+
+	t.Name = h.Name
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(path.Base(file), path.Ext(file))
+	}
+	t.About = h.About
+	if t.About == "" {
+		t.About = h.Description
+	}
+	t.Title = h.Title
+	t.Labels = h.Labels
+	t.Assignees = h.Assignees
+	return t, nil
+}
+
+func parseField(f formField) (*Field, error) {
+	a := f.Attributes
+	field := &Field{
+		Type:        f.Type,
+		ID:          f.ID,
+		Label:       a.Label,
+		Description: a.Description,
+		Placeholder: a.Placeholder,
+		Render:      a.Render,
+		Multiple:    a.Multiple,
+		Required:    f.Validations.Required,
+		Value:       a.Value,
+	}
+
+	switch f.Type {
+	case FieldMarkdown:
+		if a.Value == "" {
+			return nil, fmt.Errorf("markdown field without value")
+		}
+		return field, nil
+	case FieldTextarea, FieldInput:
+	case FieldDropdown:
+		for _, o := range a.Options {
+			field.Options = append(field.Options, o.Value)
+		}
+		if len(field.Options) == 0 {
+			return nil, fmt.Errorf("dropdown '%s' without options", a.Label)
+		}
+		if a.Default != nil {
+			if *a.Default < 0 || *a.Default >= len(field.Options) {
+				return nil, fmt.Errorf("invalid default of dropdown '%s'", a.Label)
+			}
+			field.Selected = []string{field.Options[*a.Default]}
+		}
+	case FieldCheckboxes:
+		for _, o := range a.Options {
+			var option struct {
+				Label    string `yaml:"label"`
+				Required bool   `yaml:"required"`
+			}
+			if err := o.Decode(&option); err != nil {
+				return nil, err
+			}
+			field.Options = append(field.Options, option.Label)
+			if option.Required {
+				field.RequiredOptions = append(field.RequiredOptions, option.Label)
+			}
+		}
+		if len(field.Options) == 0 {
+			return nil, fmt.Errorf("checkboxes '%s' without options", a.Label)
+		}
+	default:
+		return nil, fmt.Errorf("unknown type '%s'", f.Type)
+	}
+	if field.Label == "" {
+		return nil, fmt.Errorf("%s field without label", f.Type)
+	}
+	return field, nil
+}
+
+// Missing returns the labels of required fields without value, and of
+// checkboxes with unchecked required options
+func (t *Template) Missing() []string {
+	var missing []string
+	for _, f := range t.Fields {
+		switch f.Type {
+		case FieldMarkdown:
+			continue
+		case FieldCheckboxes:
+			for _, o := range f.RequiredOptions {
+				if !slices.Contains(f.Selected, o) {
+					missing = append(missing, f.Label)
+					break
+				}
+			}
+		case FieldDropdown:
+			if f.Required && len(f.Selected) == 0 {
+				missing = append(missing, f.Label)
+			}
+		default:
+			if f.Required && strings.TrimSpace(f.Value) == "" {
+				missing = append(missing, f.Label)
+			}
+		}
+	}
+	return missing
+}
+
+// RenderBody returns the body of an issue created from the template:
+// the body of markdown templates, and the field values of issue forms,
+// formatted like Gitea does
+func (t *Template) RenderBody() string {
+	if !t.IsForm() {
+		return t.Body
+	}
+
+	var b strings.Builder
+	for _, f := range t.Fields {
+		if f.Type == FieldMarkdown {
+			// markdown fields only describe the form
+			continue
+		}
+		fmt.Fprintf(&b, "### %s\n\n", f.Label)
+		switch f.Type {
+		case FieldCheckboxes:
+			for _, o := range f.Options {
+				check := " "
+				if slices.Contains(f.Selected, o) {
+					check = "x"
+				}
+				fmt.Fprintf(&b, "- [%s] %s\n", check, o)
+			}
+			b.WriteString("\n")
+			continue
+		case FieldDropdown:
+			writeValue(&b, strings.Join(f.Selected, ", "))
+		case FieldTextarea:
+			if f.Render != "" && strings.TrimSpace(f.Value) != "" {
+				fmt.Fprintf(&b, "```%s\n%s\n```", f.Render, strings.TrimSpace(f.Value))
+			} else {
+				writeValue(&b, f.Value)
+			}
+		default:
+			writeValue(&b, f.Value)
+		}
+		b.WriteString("\n\n")
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func writeValue(b *strings.Builder, value string) {
+	if value = strings.TrimSpace(value); value == "" {
+		value = "_No response_"
+	}
+	b.WriteString(value)
+}
+
+// Find returns the template with the given name or file name (without
+// extension), compared case-insensitively
+func Find(templates []*Template, name string) (*Template, error) {
+	var names []string
+	for _, t := range templates {
+		base := strings.TrimSuffix(path.Base(t.File), path.Ext(t.File))
+		if strings.EqualFold(t.Name, name) || strings.EqualFold(base, name) {
+			return t, nil
+		}
+		names = append(names, t.Name)
+	}
+	if len(names) == 0 {
+		return nil, utils.NewNotFoundErrorf("template '%s' not found, the repository has no templates", name)
+	}
+	return nil, utils.NewNotFoundErrorf("template '%s' not found, available templates: %s", name, strings.Join(names, ", "))
+}
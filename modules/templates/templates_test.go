@@ -0,0 +1,155 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.gitea.io/tea/modules/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bugForm = `name: Bug Report
+description: Something doesn't work
+title: "[Bug]: "
+labels: ["kind/bug", "triage"]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time to fill out this report!
+  - type: input
+    id: version
+    attributes:
+      label: Gitea version
+    validations:
+      required: true
+  - type: textarea
+    id: logs
+    attributes:
+      label: Log output
+      render: shell
+  - type: dropdown
+    id: db
+    attributes:
+      label: Database
+      options: [SQLite, PostgreSQL, MySQL]
+      default: 1
+  - type: checkboxes
+    attributes:
+      label: Checks
+      options:
+        - label: I searched for existing issues
+          required: true
+        - label: I can reproduce it on gitea.com
+`
+
+func TestParseMarkdown(t *testing.T) {
+	tmpl, err := Parse(".gitea/ISSUE_TEMPLATE/feature.md", []byte("---\r\nname: Feature\r\nabout: Suggest an idea\r\nlabels: kind/feature, triage\r\nassignees: [jane]\r\n---\r\n\r\n## Motivation\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "Feature", tmpl.Name)
+	assert.Equal(t, "Suggest an idea", tmpl.About)
+	assert.Equal(t, []string{"kind/feature", "triage"}, tmpl.Labels)
+	assert.Equal(t, []string{"jane"}, tmpl.Assignees)
+	assert.False(t, tmpl.IsForm())
+	assert.Equal(t, "## Motivation\n", tmpl.RenderBody())
+
+	// without front matter, the file name is used as name
+	tmpl, err = Parse(".gitea/PULL_REQUEST_TEMPLATE.md", []byte("Fixes #"))
+	require.NoError(t, err)
+	assert.Equal(t, "PULL_REQUEST_TEMPLATE", tmpl.Name)
+	assert.Equal(t, "Fixes #", tmpl.Body)
+}
+
+func TestParseForm(t *testing.T) {
+	tmpl, err := Parse(".gitea/ISSUE_TEMPLATE/bug.yaml", []byte(bugForm))
+	require.NoError(t, err)
+	assert.Equal(t, "Bug Report", tmpl.Name)
+	assert.Equal(t, "Something doesn't work", tmpl.About)
+	assert.Equal(t, "[Bug]: ", tmpl.Title)
+	require.Len(t, tmpl.Fields, 5)
+	assert.Equal(t, []string{"PostgreSQL"}, tmpl.Fields[3].Selected)
+	assert.Equal(t, []string{"I searched for existing issues"}, tmpl.Fields[4].RequiredOptions)
+	assert.Equal(t, []string{"Gitea version", "Checks"}, tmpl.Missing())
+
+	tmpl.Fields[1].Value = "1.24.0"
+	tmpl.Fields[4].Selected = []string{"I searched for existing issues"}
+	assert.Empty(t, tmpl.Missing())
+	assert.Equal(t, `### Gitea version
+
+1.24.0
+
+### Log output
+
+_No response_
+
+### Database
+
+PostgreSQL
+
+### Checks
+
+- [x] I searched for existing issues
+- [ ] I can reproduce it on gitea.com`, tmpl.RenderBody())
+
+	tmpl.Fields[2].Value = "panic: oops\n"
+	assert.Contains(t, tmpl.RenderBody(), "### Log output\n\n```shell\npanic: oops\n```\n\n")
+
+	for _, invalid := range []string{
+		"name: x\nbody: []",
+		"body:\n  - type: slider\n    attributes: {label: x}",
+		"body:\n  - type: dropdown\n    attributes: {label: x, options: [a], default: 2}",
+		"body:\n  - type: input",
+	} {
+		_, err := Parse("x.yaml", []byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLoadIssueTemplates(t *testing.T) {
+	root := t.TempDir()
+	write := func(file, content string) {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	src := LocalSource(root)
+
+	issueTemplates, err := LoadIssueTemplates(src)
+	require.NoError(t, err)
+	assert.Empty(t, issueTemplates.Templates)
+	assert.True(t, issueTemplates.BlankIssuesEnabled)
+	pull, err := LoadPullTemplate(src)
+	require.NoError(t, err)
+	assert.Nil(t, pull)
+
+	write(".github/ISSUE_TEMPLATE/bug.yaml", bugForm)
+	write(".github/ISSUE_TEMPLATE/config.yml", "blank_issues_enabled: false\n")
+	write(".github/ISSUE_TEMPLATE/feature.md", "---\nname: Feature\n---\nWhat should it do?")
+	write(".github/ISSUE_TEMPLATE/README.txt", "not a template")
+	write(".github/pull_request_template.md", "Fixes #")
+
+	issueTemplates, err = LoadIssueTemplates(src)
+	require.NoError(t, err)
+	require.Len(t, issueTemplates.Templates, 2)
+	assert.False(t, issueTemplates.BlankIssuesEnabled)
+	assert.Equal(t, ".github/ISSUE_TEMPLATE/bug.yaml", issueTemplates.Templates[0].File)
+
+	found, err := Find(issueTemplates.Templates, "bug")
+	require.NoError(t, err)
+	assert.Equal(t, "Bug Report", found.Name)
+	found, err = Find(issueTemplates.Templates, "feature")
+	require.NoError(t, err)
+	assert.Equal(t, "What should it do?", found.Body)
+	_, err = Find(issueTemplates.Templates, "question")
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	assert.ErrorContains(t, err, "available templates: Bug Report, Feature")
+
+	pull, err = LoadPullTemplate(src)
+	require.NoError(t, err)
+	assert.Equal(t, "Fixes #", pull.Body)
+}
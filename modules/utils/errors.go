@@ -0,0 +1,183 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// Exit codes of tea. Errors are mapped to them by ExitCode.
+const (
+	// ExitError is used for errors of no specific kind
+	ExitError = 1
+	// ExitValidation is used for invalid arguments or flags
+	ExitValidation = 2
+	// ExitMissingLogin is used when no (matching) login is configured
+	ExitMissingLogin = 3
+	// ExitMissingRepo is used when a command needs a repository or organization context
+	ExitMissingRepo = 4
+	// ExitNotFound is used when a requested entity doesn't exist
+	ExitNotFound = 5
+	// ExitUnauthorized is used when the server rejects the credentials, e.g. an expired token
+	ExitUnauthorized = 6
+	// ExitPermissionDenied is used when the login lacks the permission for an operation
+	ExitPermissionDenied = 7
+	// ExitNetwork is used when the server can't be reached
+	ExitNetwork = 8
+	// ExitAPI is used for other error responses of the server
+	ExitAPI = 9
+	// ExitInterrupted is used when the command was aborted by an interrupt (Ctrl-C)
+	ExitInterrupted = 130
+)
+
+// Kinds of errors, to be checked with errors.Is
+var (
+	ErrValidation       = errors.New("invalid argument")
+	ErrMissingLogin     = errors.New("login required")
+	ErrMissingRepo      = errors.New("repository required")
+	ErrNotFound         = errors.New("not found")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrNetwork          = errors.New("network error")
+	ErrAPI              = errors.New("API error")
+)
+
+var exitCodes = []struct {
+	kind error
+	code int
+	name string
+}{
+	{ErrValidation, ExitValidation, "validation"},
+	{ErrMissingLogin, ExitMissingLogin, "missing_login"},
+	{ErrMissingRepo, ExitMissingRepo, "missing_repo"},
+	{ErrNotFound, ExitNotFound, "not_found"},
+	{ErrUnauthorized, ExitUnauthorized, "unauthorized"},
+	{ErrPermissionDenied, ExitPermissionDenied, "permission_denied"},
+	{ErrNetwork, ExitNetwork, "network"},
+	{ErrAPI, ExitAPI, "api"},
+}
+
+// kindError is an error of a kind, with a message and an optional cause
+type kindError struct {
+	kind    error
+	message string
+	cause   error
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Unwrap() []error {
+	if e.cause == nil {
+		return []error{e.kind}
+	}
+	return []error{e.kind, e.cause}
+}
+
+// WrapError marks err as an error of the given kind, keeping its message
+func WrapError(kind, err error) error {
+	if err == nil || errors.Is(err, kind) {
+		return err
+	}
+	return &kindError{kind: kind, message: err.Error(), cause: err}
+}
+
+// NewValidationErrorf returns an error for invalid arguments or flags
+func NewValidationErrorf(format string, args ...any) error {
+	return &kindError{kind: ErrValidation, message: fmt.Sprintf(format, args...)}
+}
+
+// NewMissingLoginErrorf returns an error for a missing login
+func NewMissingLoginErrorf(format string, args ...any) error {
+	return &kindError{kind: ErrMissingLogin, message: fmt.Sprintf(format, args...)}
+}
+
+// NewMissingRepoErrorf returns an error for a missing repository or organization context
+func NewMissingRepoErrorf(format string, args ...any) error {
+	return &kindError{kind: ErrMissingRepo, message: fmt.Sprintf(format, args...)}
+}
+
+// NewNotFoundErrorf returns an error for an entity that doesn't exist
+func NewNotFoundErrorf(format string, args ...any) error {
+	return &kindError{kind: ErrNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+// StatusError classifies err by the HTTP status of the API response that caused it
+func StatusError(status int, err error) error {
+	switch {
+	case status == http.StatusNotFound:
+		return WrapError(ErrNotFound, err)
+	case status == http.StatusUnauthorized:
+		return WrapError(ErrUnauthorized, err)
+	case status == http.StatusForbidden:
+		return WrapError(ErrPermissionDenied, err)
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return WrapError(ErrValidation, err)
+	case status >= 400:
+		return WrapError(ErrAPI, err)
+	}
+	return err
+}
+
+// APIError marks the error of an SDK call with the kind matching the HTTP status
+// of its response, e.g. ErrNotFound for a 404
+func APIError(resp *gitea.Response, err error) error {
+	if err == nil || resp == nil || resp.Response == nil {
+		return err
+	}
+	return StatusError(resp.StatusCode, err)
+}
+
+// APIResult is APIError for SDK calls returning a value, e.g.
+//
+//	repo, err := utils.APIResult(client.GetRepo(owner, name))
+func APIResult[T any](v T, resp *gitea.Response, err error) (T, error) {
+	return v, APIError(resp, err)
+}
+
+// ErrorForExitCode returns an error with the given message, of the kind
+// mapped to the exit code, e.g. for a failed tea process
+func ErrorForExitCode(code int, message string) error {
+	for _, k := range exitCodes {
+		if k.code == code {
+			return &kindError{kind: k.kind, message: message}
+		}
+	}
+	return errors.New(message)
+}
+
+// ExitCode returns the exit code for an error: the code of its kind,
+// ExitNetwork for connection errors & timeouts, and ExitError otherwise.
+func ExitCode(err error) int {
+	code, _ := classify(err)
+	return code
+}
+
+// ErrorKind returns a short name of the kind of an error, e.g. "not_found"
+func ErrorKind(err error) string {
+	_, name := classify(err)
+	return name
+}
+
+func classify(err error) (int, string) {
+	for _, k := range exitCodes {
+		if errors.Is(err, k.kind) {
+			return k.code, k.name
+		}
+	}
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return ExitNetwork, "network"
+	}
+	return ExitError, "error"
+}
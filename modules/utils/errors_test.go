@@ -0,0 +1,74 @@
+// Copyright 2025 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+		kind string
+	}{
+		{"generic", errors.New("boom"), ExitError, "error"},
+		{"validation", NewValidationErrorf("invalid index '%s'", "x"), ExitValidation, "validation"},
+		{"missing login", NewMissingLoginErrorf("no login"), ExitMissingLogin, "missing_login"},
+		{"missing repo", NewMissingRepoErrorf("no repo"), ExitMissingRepo, "missing_repo"},
+		{"wrapped", fmt.Errorf("could not edit: %w", NewNotFoundErrorf("gone")), ExitNotFound, "not_found"},
+		{"status 401", StatusError(401, errors.New("401 Unauthorized")), ExitUnauthorized, "unauthorized"},
+		{"status 403", StatusError(403, errors.New("403 Forbidden")), ExitPermissionDenied, "permission_denied"},
+		{"status 422", StatusError(422, errors.New("invalid")), ExitValidation, "validation"},
+		{"status 500", StatusError(500, errors.New("oops")), ExitAPI, "api"},
+		{"status 200", StatusError(200, errors.New("decode")), ExitError, "error"},
+		{"network", &url.Error{Op: "Get", URL: "https://gitea.com", Err: errors.New("connection refused")}, ExitNetwork, "network"},
+		{"timeout", fmt.Errorf("fetch failed: %w", context.DeadlineExceeded), ExitNetwork, "network"},
+		{"exit code", ErrorForExitCode(ExitPermissionDenied, "forbidden"), ExitPermissionDenied, "permission_denied"},
+		{"unknown exit code", ErrorForExitCode(42, "crashed"), ExitError, "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, ExitCode(tt.err))
+			assert.Equal(t, tt.kind, ErrorKind(tt.err))
+		})
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	cause := errors.New("token expired")
+	err := WrapError(ErrUnauthorized, cause)
+	assert.Equal(t, "token expired", err.Error())
+	assert.ErrorIs(t, err, ErrUnauthorized)
+	assert.ErrorIs(t, err, cause)
+	assert.Nil(t, WrapError(ErrAPI, nil))
+}
+
+func TestAPIError(t *testing.T) {
+	response := func(status int) *gitea.Response {
+		return &gitea.Response{Response: &http.Response{StatusCode: status}}
+	}
+	cause := errors.New("The target couldn't be found.")
+
+	err := APIError(response(404), cause)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, cause.Error(), err.Error())
+
+	// each error is marked by the status of its own response
+	_, err = APIResult[*gitea.Repository](nil, response(500), errors.New("oops"))
+	assert.Equal(t, ExitAPI, ExitCode(err))
+
+	assert.Equal(t, cause, APIError(nil, cause))
+	assert.NoError(t, APIError(response(404), nil))
+}
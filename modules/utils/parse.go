@@ -23,7 +23,11 @@ func ArgsToIndices(args []string) ([]int64, error) {
 
 // ArgToIndex take issue/pull index as string and return int64
 func ArgToIndex(arg string) (int64, error) {
-	return strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	idx, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil {
+		return 0, NewValidationErrorf("invalid index '%s', expected a number", arg)
+	}
+	return idx, nil
 }
 
 // NormalizeURL normalizes the input with a protocol
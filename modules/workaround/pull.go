@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"code.gitea.io/sdk/gitea"
+	"code.gitea.io/tea/modules/utils"
 )
 
 // FixPullHeadSha is a workaround for https://github.com/go-gitea/gitea/issues/12675
@@ -17,7 +18,7 @@ func FixPullHeadSha(client *gitea.Client, pr *gitea.PullRequest) error {
 	owner := pr.Base.Repository.Owner.UserName
 	repo := pr.Base.Repository.Name
 	if pr.Head != nil && pr.Head.Sha == "" {
-		refs, _, err := client.GetRepoRefs(owner, repo, pr.Head.Ref)
+		refs, err := utils.APIResult(client.GetRepoRefs(owner, repo, pr.Head.Ref))
 		if err != nil {
 			return err
 		} else if len(refs) == 0 {